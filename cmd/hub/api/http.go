@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -234,10 +236,22 @@ func doWithAuthorization(client *http.Client, method, path string, reqBody io.Re
 }
 
 func do(client *http.Client, req *http.Request, jsResp interface{}) (int, error, []byte) {
+	span := tracing.StartClient(fmt.Sprintf("%s %s", req.Method, req.URL.Path),
+		"http.method", req.Method, "http.url", req.URL.String())
+	defer span.End()
+	if traceparent := span.Traceparent(); traceparent != "" {
+		req.Header.Set("traceparent", traceparent)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
+		span.SetError(err)
 		return 0, fmt.Errorf("Error during HTTP request: %v", err), nil
 	}
+	span.SetAttribute("http.status_code", strconv.Itoa(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetError(errors.New(resp.Status))
+	}
 	respBody := func() ([]byte, int64, error) {
 		var body bytes.Buffer
 		read, err := body.ReadFrom(resp.Body)
@@ -26,6 +26,36 @@
 //  cmd/hub/api/requests/openshift-adapter-template.json.template
 //  cmd/hub/initialize/hub.yaml.template
 //  cmd/hub/initialize/hub-component.yaml.template
+//  cmd/hub/initialize/archetypes/helm/archetype.yaml
+//  cmd/hub/initialize/archetypes/helm/chart/Chart.yaml
+//  cmd/hub/initialize/archetypes/helm/chart/templates/configmap.yaml
+//  cmd/hub/initialize/archetypes/helm/chart/values.yaml
+//  cmd/hub/initialize/archetypes/helm/hub-component.yaml
+//  cmd/hub/initialize/archetypes/helm/values.yaml.template
+//  cmd/hub/initialize/archetypes/kustomize/archetype.yaml
+//  cmd/hub/initialize/archetypes/kustomize/hub-component.yaml
+//  cmd/hub/initialize/archetypes/kustomize/kustomization.yaml.template
+//  cmd/hub/initialize/archetypes/kustomize/namespace.yaml.template
+//  cmd/hub/initialize/archetypes/makefile/Makefile
+//  cmd/hub/initialize/archetypes/makefile/archetype.yaml
+//  cmd/hub/initialize/archetypes/makefile/config.mk.template
+//  cmd/hub/initialize/archetypes/makefile/hub-component.yaml
+//  cmd/hub/initialize/archetypes/shell/archetype.yaml
+//  cmd/hub/initialize/archetypes/shell/config.env.template
+//  cmd/hub/initialize/archetypes/shell/deploy.sh
+//  cmd/hub/initialize/archetypes/shell/hub-component.yaml
+//  cmd/hub/initialize/archetypes/shell/undeploy.sh
+//  cmd/hub/initialize/archetypes/skaffold/Dockerfile
+//  cmd/hub/initialize/archetypes/skaffold/archetype.yaml
+//  cmd/hub/initialize/archetypes/skaffold/hub-component.yaml
+//  cmd/hub/initialize/archetypes/skaffold/k8s/deployment.yaml.template
+//  cmd/hub/initialize/archetypes/skaffold/skaffold.yaml
+//  cmd/hub/initialize/archetypes/terraform/archetype.yaml
+//  cmd/hub/initialize/archetypes/terraform/hub-component.yaml
+//  cmd/hub/initialize/archetypes/terraform/main.tf
+//  cmd/hub/initialize/archetypes/terraform/outputs.tf
+//  cmd/hub/initialize/archetypes/terraform/terraform.tfvars.template
+//  cmd/hub/initialize/archetypes/terraform/variables.tf
 
 package bindata
 
@@ -83,391 +113,424 @@ var _bindata = map[string]*asset{
 	"meta/hub-well-known-parameters.yaml": &asset{
 		name: "hub-well-known-parameters.yaml",
 		data: "" +
-			"\x8c\x93\x4d\x6e\xeb\x30\x0c\x84\xf7\x3e\x05\x2f\xe0\x1c\xc0\xbb\xf7\x9a\x45\x81\x00\x46\x51\x77" +
-			"\xd5\x1d\x25\x31\xad\x1a\xfd\x04\x94\x94\xc2\xb7\x2f\x1c\x4b\xb1\xe2\x24\x40\x36\x06\x2c\xce\x37" +
-			"\x34\xe9\x51\xdb\xb6\xcd\x11\x19\x2d\x45\xe2\xd0\x35\x2d\x38\xb4\xd4\x81\x34\x3e\xa9\x06\x40\xb0" +
-			"\xa6\x7d\x07\x2f\xf9\xf5\xa0\x9d\xea\x20\x05\xe2\x06\xa0\xe6\x00\x0a\x39\x49\x1a\x80\x6b\x14\x76" +
-			"\xe5\x54\xd1\x1e\x93\x89\x1d\xe0\x6f\xa8\xa8\x23\xfb\x93\x56\x67\xdb\x15\xf9\x56\x57\x1e\xd0\x28" +
-			"\xa5\x4f\x2e\xde\xc2\xff\xe6\x02\xf4\xc9\x0a\x62\xf0\x3c\xd9\xfd\x90\x8c\x15\xcc\xf4\xa5\xbd\xbb" +
-			"\x65\xdf\xcb\xf9\xa5\xcb\x09\xb5\x41\xa1\x8d\x8e\xe3\xa7\x77\x74\xa7\x5d\xa5\x80\x2c\x29\x74\x08" +
-			"\xdf\x3b\x1a\x6b\x66\x18\x5e\x61\x47\x23\xf4\x68\xe9\xb2\x78\xe5\xc2\xb2\xf6\x6d\x3f\x3c\xb7\xf4" +
-			"\xe9\x59\x5b\x6f\xfb\x61\xb6\xbd\x5a\x5a\xa4\x50\x0f\x2e\x30\xd0\xd6\x5b\xd4\x6e\x8d\xfe\xc7\x40" +
-			"\x50\x95\x2e\x0e\x21\xa2\x3c\x84\x8d\x22\xa3\x4f\xc4\x63\x65\xa6\xee\x1a\x65\x8f\x22\x8a\xc4\x8c" +
-			"\x7b\xcf\x76\x23\x92\x3c\x50\x5c\x26\xfd\x28\x95\xa9\x45\x24\x30\x5e\x62\x9c\xd7\xff\xdc\xc4\x6b" +
-			"\x83\xb9\x41\x91\x3d\xfe\xd7\x0f\xb8\x2c\x6c\x44\x72\xca\x50\x75\x31\xe6\xe0\xad\xbf\xe9\x7c\x5d" +
-			"\x36\x4b\x0c\xcb\x09\x2f\x19\xca\x92\xdb\x0c\x95\x52\x0e\xc8\x5f\x00\x00\x00\xff\xff",
+			"\x8c\x93\x4f\x6e\xb3\x30\x10\xc5\xf7\x9c\x62\x2e\x40\x0e\xc0\xee\xfb\x9a\x45\xa5\x48\xa8\x2a\x5d" +
+			"\x75\x37\xb6\x27\xad\x1b\xff\x89\xc6\x76\x2a\x6e\x5f\x51\x6c\x70\x43\x22\x65\x83\xc4\xcc\xfb\xbd" +
+			"\xc1\xc3\x73\xdb\xb6\xcd\x19\x19\x2d\x45\xe2\xd0\x35\x2d\x38\xb4\xd4\x81\x34\x3e\xa9\x06\x40\xb0" +
+			"\xa6\x63\x07\x4f\xf9\xf5\xa4\x9d\xea\x20\x05\xe2\x06\xa0\xe6\x00\x0a\x39\x49\x1a\x80\xbf\x28\x1c" +
+			"\x4a\x55\xd1\x11\x93\x89\x1d\xe0\x77\xa8\xa8\x33\xfb\x8b\x56\xc4\x5b\xf2\xa5\xee\xdc\xa1\x51\x4a" +
+			"\x9f\x5c\xdc\xc2\xff\xe6\x06\xf4\xc9\x0a\x62\xf0\x3c\xd9\x7d\x91\x8c\x15\xcc\xf4\xa1\xbd\xdb\xb2" +
+			"\xaf\xa5\xbe\x4c\xb9\xa0\x36\x28\xb4\xd1\x71\x7c\xf7\x8e\x6e\x8c\xab\x14\x90\x25\x85\x0e\xe1\xf3" +
+			"\x40\x63\xcd\x0c\xc3\x33\x1c\x68\x84\x1e\x2d\x2d\x8b\x57\x2e\xac\x6b\xdf\xf7\xc3\x63\x4b\x9f\x9e" +
+			"\xb5\xf5\xbe\x1f\xa0\x2f\xb5\x65\x69\x91\x42\x7d\x70\x81\x81\xf6\xde\xa2\x76\xd7\xe8\x7f\x0c\x04" +
+			"\x55\x6b\x71\x08\x11\xe5\x29\xec\x14\x19\x7d\x21\x1e\x2b\x33\x75\xd3\x28\x7b\x14\x51\x24\x66\x3c" +
+			"\x7a\xb6\x3b\x91\xe4\x89\xe2\x7a\xd2\xb7\xd2\x99\x46\x44\x02\xe3\x25\xc6\x79\xfd\x8f\x9d\xf8\xda" +
+			"\x60\x1e\x50\x64\xf7\xff\xf5\x1d\x2e\x0b\x1b\x91\x9c\x32\x54\x5d\x8c\x39\x78\xd7\xdf\xf4\x7b\x5d" +
+			"\x76\x6b\x0c\x4b\x85\xd7\x0c\x65\xc9\x36\x43\xa5\x95\x03\xf2\x33\x00",
 		size: 914,
-		mode: 0644,
-		time: time.Unix(1540400209, 965820203),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"meta/manifest.schema.json": &asset{
 		name: "manifest.schema.json",
 		data: "" +
-			"\xec\x5b\x4b\x8f\xdb\x36\x10\xbe\xef\xaf\x10\xd4\x1e\x9d\xb8\x3d\x15\xd8\x6b\x1f\xb7\x02\x05\x52" +
-			"\xf4\x12\xf8\x40\x49\x23\x9b\x59\xbe\x3a\x24\x9d\x18\xc5\xfe\xf7\x42\xb6\xd6\x4f\x3e\x2d\xcb\xf5" +
-			"\xd6\xce\x21\x58\x8b\x23\x3e\x86\x33\x1f\xe7\x1b\x8e\xfe\x79\x2a\x8a\xa2\x28\xbf\xa7\x4d\xf9\x5c" +
-			"\x94\xda\x2a\xc0\x85\xad\x3e\x52\x39\xe5\x44\xd0\x16\xb4\xf9\xa8\xeb\x05\x70\xf2\xf1\x8b\x96\xa2" +
-			"\x9c\xf4\xe2\x9b\x67\xdd\x2b\x0b\x63\xd4\xf3\x74\xda\xb5\x7e\xe8\x25\x25\xce\xa7\x0d\x92\xd6\x7c" +
-			"\xf8\xe1\xa7\xe9\xe6\xd9\x77\x6f\x6f\x1a\x6a\x18\x74\xef\xfd\xde\x77\xbf\x6d\x58\xa9\xee\xf9\xe7" +
-			"\x52\x56\x5f\xa0\x36\xe5\xa4\x28\x85\x65\xac\x9c\xf5\xed\xa4\x69\xa8\xa1\x52\x10\xf6\x07\x4a\x05" +
-			"\x68\x28\xe8\xf2\xb9\x68\x09\xd3\xd0\x8b\xa8\xfd\x86\xcd\xc2\xd6\xcf\x97\x80\x9a\x4a\x71\xf0\x70" +
-			"\xdd\x00\xc2\xf2\x6e\xcc\x83\xa7\xdd\xbf\x1f\x0f\x9e\xcc\xb6\xbf\x5e\x27\xbb\x5e\x5f\xa8\x68\x32" +
-			"\xba\x2c\xb5\x21\xf5\x4b\x39\x39\x6d\x20\x4a\x31\x5a\x93\x6e\x71\xae\xe6\x5a\x72\x25\x05\x08\xe3" +
-			"\x6a\x54\x04\x09\x07\x03\xa8\xcb\x84\x29\x73\x30\xe4\x74\xca\xbd\xe6\xb7\x8a\x3f\x6c\x45\xf8\xdb" +
-			"\x52\x84\xc6\xbd\x28\x41\x38\x1c\x8d\x7c\xf4\xbe\x67\x53\x0e\x7b\x70\xb5\x1c\xcc\x4d\x1b\xa4\x62" +
-			"\x5e\x9e\x08\xbd\x3a\x74\xd2\xa2\xe4\x9f\xd6\xca\xbe\x68\xb7\x6f\x96\x7b\xc1\x2e\x2b\xa4\xd0\x5e" +
-			"\xb6\xcb\x06\x74\x8d\x54\x19\x97\xbd\x0f\xea\xb8\x26\x06\xe6\x12\x57\x97\xed\xd5\xe7\x9a\xc7\x9d" +
-			"\x7e\x76\xb6\xf6\x7e\xb5\x1e\x6e\xe2\x97\x10\x96\x57\x80\xa5\x53\x60\x96\x34\x4d\x4e\x8c\x45\x6a" +
-			"\x02\x8b\xf7\xfa\xfd\x56\x62\x4e\x42\x73\xac\x3a\xd7\x0c\xb4\x13\xa6\x16\x64\xc8\x12\x18\xad\x41" +
-			"\xe8\x0b\x1b\xb0\x96\x16\xeb\x84\x3e\x7b\x68\x39\xed\xf3\xc9\xfd\x6b\x1f\xb4\xb6\xf8\xa7\xfd\xd0" +
-			"\x45\x10\xc9\xea\x18\xb9\xa8\x01\xee\x01\x9d\x20\xe4\x25\x1e\x37\xe9\x28\xb9\xc3\x39\xf7\x06\xbf" +
-			"\xa9\xf1\xa4\x71\xe6\x42\xfc\x30\x9e\xc6\x31\x35\x69\xb3\x3d\x1b\xde\x43\x8c\x02\xd1\xe8\xa4\x01" +
-			"\xfc\xfe\xb0\xd1\xb2\x63\xdf\x1c\xee\xcb\x58\xe9\x15\x99\x05\x9c\xc6\x6f\x01\xd9\xca\x38\xb5\xd6" +
-			"\x98\x9a\x22\xbe\x91\x66\x87\x67\xda\x63\x8e\xb5\xec\xf6\x95\x62\x54\x28\x4b\x5f\x01\xed\xec\x50" +
-			"\x91\x9a\xbc\x41\xa3\xaa\x1a\xa8\xb2\x74\x97\x76\xbc\xc1\xa5\x81\x32\x2a\x3c\x4b\x18\x3d\x63\xe3" +
-			"\x8e\xc7\x4f\x95\xcf\xde\xcb\xc4\x3d\xdd\x9b\x4f\x7b\x3b\x93\xd1\xb6\xfa\x25\xd1\xc0\xaf\x32\x1f" +
-			"\x26\x6b\xc2\xae\x33\xa3\xa7\x61\x12\xaf\xb9\x80\x78\xd6\x31\xdf\xbb\x9b\xff\x90\x77\xb0\x0f\xdf" +
-			"\xf1\xe1\x38\x32\x66\xf9\xc1\x81\x4b\xc7\xee\xb9\x2b\x94\x4b\xda\xbc\xd3\xb9\x33\x62\x5a\x89\x3c" +
-			"\x97\x17\xa6\xa3\x6b\x94\x02\x7a\xd5\x97\xc1\x02\x62\x91\x44\x20\x8a\xf0\xc0\x71\x42\xf4\x90\x16" +
-			"\x46\x85\xdd\xc1\xbd\x2b\x8c\xb6\x50\xaf\x6a\x07\xe1\xbc\xde\xb6\x54\x04\x61\x08\xe1\x21\x8c\xc9" +
-			"\xaf\x43\x28\xcb\x12\xb0\xba\x1f\xa3\x70\x28\x40\x62\x03\x78\xd7\x0a\x50\x1b\x5b\xbe\x67\x1d\x70" +
-			"\x22\x1a\x62\x52\x32\x2f\xff\x63\x25\x78\xa3\x83\x3c\x7e\x70\x06\x27\x48\x8d\xc4\xe3\xb6\x9a\xc7" +
-			"\x8d\x93\xf9\x71\x02\x47\x4e\x20\x1d\x89\x5c\x39\x9f\xff\x0d\x0f\x1e\x9d\xe6\x40\x9a\xd5\xcf\x52" +
-			"\x6c\x36\xf3\x1d\x9f\x11\xb7\x41\xfc\x85\xbe\x3e\xf1\xb7\xc8\xae\x3f\xe8\x57\x42\xcd\x27\xa8\x65" +
-			"\x2c\x7d\x75\x32\x38\x15\x06\xe6\xbe\x1c\x72\xea\xe8\x8a\x58\x0d\x23\x0e\x3f\x8a\xab\x6d\x60\xed" +
-			"\x96\x81\x17\x89\x68\x24\x4f\x4f\xf3\x25\x65\x90\x06\x64\x8f\x72\x73\x37\x65\xb5\x32\x39\x69\x9e" +
-			"\x2c\xa3\xb8\x15\x7a\x0f\xdf\x0c\x08\xed\xb4\xa4\x08\xa3\x89\xd1\x14\x2a\x6a\x66\x1b\xb8\xe7\xe8" +
-			"\xa8\x96\xa2\xa5\x73\x8b\x77\xad\x84\x06\x14\x93\xab\xa1\x38\x95\x0a\x3a\x15\xb4\x12\xe1\x11\xeb" +
-			"\x25\x22\x49\x28\xc0\x69\x0d\xe0\x43\x91\xe3\x9f\xe4\x56\x3c\x5c\xe4\xe1\x22\x0f\x17\xc9\x88\x5a" +
-			"\xf6\xca\xab\x6e\x26\xb5\x7f\xed\x9a\x85\x77\x52\x95\xb0\x2b\x93\x1b\x6d\x88\x25\x61\x76\xbd\x02" +
-			"\x7f\x65\x44\x4b\x2c\x33\x21\x11\xe0\x2a\x50\x46\x94\x96\x59\x8f\x65\xd7\xdd\x19\xf6\xd0\xca\x9c" +
-			"\xb5\x8c\x67\x4c\xca\x6a\xc0\x18\xb1\x32\x50\x2f\x62\x32\x8c\x8a\x97\x4b\xad\x2d\x5c\x64\x37\xd8" +
-			"\x28\x5a\x94\xfc\x57\xb1\x1c\x77\x80\xdf\x28\x1b\xd1\x75\x60\xcc\xe9\x07\x20\x34\xff\xf0\xba\xb1" +
-			"\x82\xa1\xb4\x4c\x42\x56\x21\x89\x07\x73\x73\x4e\xdc\xdc\xf4\x43\x14\x97\xcf\x3e\xa4\x6f\x25\xf9" +
-			"\x60\x80\x2b\x46\x0c\xe8\xff\xf0\x36\x35\x08\xb1\x09\xb7\xa9\xb5\x45\x16\xa4\xe7\xdc\x6a\x43\xea" +
-			"\x05\x84\x64\xe6\x72\xc8\x7d\x6c\x4b\x19\xdc\xf5\x7d\x6c\x43\x11\x6a\x23\x91\xde\xb7\x1a\xe0\x9b" +
-			"\x41\xf2\xb8\x73\x19\x74\xe7\x12\x8d\xb8\xf2\x22\xaf\x64\x98\xc8\x85\x8c\x24\xf8\x88\x07\x67\xa9" +
-			"\x57\x24\x61\x88\x39\x8f\xed\x66\x31\xde\x44\xd6\x5b\x24\x96\x71\xa6\xb3\xdf\x31\x0e\xd7\x49\xb4" +
-			"\xe0\x37\x0a\x67\x0f\x95\xdf\x60\x3c\x23\xad\x51\xd6\x3c\x72\x12\xb7\x9e\x93\xd8\x26\x0c\xe2\xb4" +
-			"\x67\xfb\x19\x53\x51\x56\x52\x32\x20\xa2\xfb\xf3\xed\x8a\x6f\xb2\xfd\x88\xe9\xd2\xbc\x7e\xc8\xf2" +
-			"\xfc\x45\x8a\x67\xa4\x0e\xb6\xe5\x33\x51\x36\x65\x35\x5c\x2a\x37\xd0\x71\xeb\x3f\xdb\xbf\x08\x8e" +
-			"\xa7\xa4\x91\xd3\x0f\x29\xdf\xfb\x15\x17\x2a\x55\xdd\x47\xa3\xa7\xcd\xff\xaf\x4f\xff\x06\x00\x00" +
-			"\xff\xff",
-		size: 15425,
+			"\xec\x5d\x4b\x8f\xdb\xb8\x0f\xbf\xe7\x53\x08\x6a\x8f\x49\x67\xfe\xaf\x16\xff\x5e\x16\x8b\x6d\x7b" +
+			"\x5b\xa0\x40\x17\xbd\x0c\xb2\x80\x6c\xd3\x89\x1a\x59\xf2\x4a\x72\x32\xc1\x20\xdf\x7d\xe1\x24\x93" +
+			"\xc7\x8e\x6d\x51\xf1\x73\x9a\xf4\x50\x60\x6c\x59\x94\x48\x91\xa2\x7e\x14\x99\xa7\x11\x21\x84\xd0" +
+			"\xb7\x3c\xa2\x1f\x09\x35\x59\x0a\x7a\x9e\x05\xef\xb8\xba\x4b\x98\xe4\x31\x18\xfb\xce\x84\x73\x48" +
+			"\xd8\xbb\x1f\x46\x49\x3a\xde\x37\xdf\x3d\xcb\x3f\x99\x5b\x9b\x7e\xbc\xbb\xcb\xdf\x4e\xf6\x2d\x95" +
+			"\x9e\xdd\x45\x9a\xc5\x76\x72\xff\xe1\x6e\xf7\xec\xcd\xf3\x97\x96\x5b\x01\xf9\x77\xbf\xef\xbb\x3f" +
+			"\xbc\x58\xa7\xf9\xf3\x07\xaa\x82\x1f\x10\x5a\x3a\x26\x54\x66\x42\xd0\xe9\xfe\x3d\x8b\x22\x6e\xb9" +
+			"\x92\x4c\x7c\xd5\x2a\x05\x6d\x39\x18\xfa\x91\xc4\x4c\x18\xd8\x37\x89\x20\xe6\x72\xdb\x28\x7f\xb3" +
+			"\x9b\x19\x21\x84\xd0\xb9\x52\x8b\xf3\x47\x67\x24\xcf\x9e\xee\x88\x69\xcd\xd6\xfb\x91\x9d\xbd\xd8" +
+			"\x0e\xe9\xec\xf1\xf4\xbc\x15\xe5\x16\x92\x97\xb4\x4e\xe9\x1d\x66\x58\x40\xd7\x39\xc9\xd3\x7f\x54" +
+			"\xc3\x5f\x19\xd7\x10\x15\x4e\x82\x10\x42\x68\xa8\x92\x84\xc9\x88\xbe\x78\x3b\x2d\xe8\x2e\x3d\xa5" +
+			"\xf9\x54\xdd\x61\x59\x83\xb3\x89\x1a\xab\xb9\x9c\xd1\xc2\x86\x9b\x71\x31\x01\x90\x4b\x5c\xe7\xa5" +
+			"\x5c\x74\x71\xb3\xbc\x6f\xf4\xe0\x09\x21\x64\xe3\x35\xad\x98\x71\x91\x69\xf8\xaa\x04\x0f\xd7\xd5" +
+			"\x13\x04\x99\x25\xa5\x12\x3d\xeb\x90\x8e\xab\xdb\xac\x98\x96\xae\x36\x7c\x26\x95\x86\xf2\x69\x4e" +
+			"\x47\xb8\xc9\x6f\x46\xc5\x7f\x6d\x46\x27\x6c\x29\x5b\x62\x74\x09\xda\x70\x25\x5f\xea\x68\x29\x33" +
+			"\xfe\x35\x2a\x1e\xe5\x89\x00\xe8\x82\xcb\xc8\xa3\x4b\x6a\x2c\x0b\x17\x85\x7a\x99\xa6\x82\x87\x2c" +
+			"\x5f\x4c\x45\xaf\x43\x95\xa4\x4a\x82\x2c\xd4\xe9\x94\x69\x96\x80\x05\x6d\x28\x62\xc8\x09\x58\x56" +
+			"\x6a\xa9\x8a\xd7\x7c\xb5\x19\xa0\x92\x25\x50\x6d\xb2\x1c\x7a\xbf\xeb\xa1\xd4\x22\xb8\xf4\xa5\x40" +
+			"\x23\x68\xac\x55\xf2\x6d\xcb\xec\x46\xbb\x7d\xde\x5c\x1a\xec\x32\xd0\x1c\xe2\x66\xbb\x8c\xc0\x84" +
+			"\x9a\xa7\xb6\x68\xbd\xd7\xea\x38\x64\x16\x66\x4a\xaf\x9b\xed\xb5\x4c\x35\xdd\xdb\xe8\x89\x5e\x6d" +
+			"\xc9\x55\x98\x6a\x99\x25\x01\x68\x3a\xc2\x59\xa0\xa2\x61\x26\xcc\x66\x9a\xdb\x8a\xc9\x3b\xed\x2a" +
+			"\x9d\xb1\xaa\x31\x06\x60\x2b\xdf\x33\x91\xce\x59\x9d\x29\x08\x1e\x82\x34\x0d\x2f\x60\xa3\x32\x1d" +
+			"\x22\xfa\xdc\x9b\x16\xbc\x5d\x1f\x8f\x5e\xda\xbf\x72\x27\xab\xd0\xa5\x1a\x98\xb3\xb4\xb5\x73\x25" +
+			"\xfb\xf7\x9e\x8d\x8d\xf9\x51\x95\x36\x95\xd4\x75\xa2\x22\x48\x41\x46\x06\x45\xc0\xe1\x67\x94\xb9" +
+			"\xc2\x0e\xb7\xd8\xc1\x23\xc4\x0a\xf0\x66\x06\xf1\x76\xca\x1c\xba\x41\x1a\x71\x37\x4b\xd6\xa3\xcf" +
+			"\x6a\x39\xca\x95\x6b\x67\x23\x2f\x7e\x55\x70\xe7\x68\x15\xb9\xf5\x23\xea\x64\x55\x4d\x96\xe1\x55" +
+			"\xba\xe0\x8b\x44\x59\xa0\xce\xc6\x53\x04\x75\x0f\xc1\xfd\x93\x3e\xb6\xbd\xb7\x2c\x91\x32\x3d\x19" +
+			"\x4f\x3c\x9c\xc1\x98\x2c\xf8\x84\x5c\xe0\x9d\x8c\x47\xa8\x90\x89\x6e\x46\x34\xaa\xd7\x62\xe3\x6b" +
+			"\x10\x2f\xda\xe6\xf7\xea\x36\x20\x24\xa5\x88\xc7\xc5\x63\x4f\xb5\x5a\xf2\xe8\x95\x8e\x5d\x30\x1b" +
+			"\x2b\x9d\xf8\x9e\x0b\xf1\xd6\xd5\x79\x04\x2c\x65\x9f\x9b\x8d\x4e\x76\x56\xb0\xd5\x61\x8e\x11\xde" +
+			"\x03\xce\x8d\xaa\x56\x87\x62\xa9\x08\x1e\x43\xb8\x0e\x0b\x0e\x9c\xdd\x89\x25\x60\x1a\xea\x1c\x78" +
+			"\x98\x10\x6a\x55\xe7\xc8\xb2\x04\x1d\x5c\xcf\xa2\x28\x60\x80\xd2\x11\xe8\xab\x66\x40\xba\x5b\xcb" +
+			"\xd7\xcc\x83\x1c\x11\x67\x16\x83\xbc\xfc\xc4\x4c\x28\xf5\x0e\x70\x66\xf1\x02\xf3\x88\x35\x93\xf8" +
+			"\xb5\x8a\x17\x17\x5a\x6c\x08\xf1\x39\xc4\xe8\x21\x4e\x2f\xb1\x96\x8b\xb7\x42\xcc\x47\x5e\x66\x36" +
+			"\xcd\xac\xc7\xd1\x1d\x75\x2a\xbc\x2c\x66\x43\x5c\x40\x53\x4d\x76\x92\x4b\xfc\xfa\x4d\x0b\x4c\x0f" +
+			"\xb8\x64\x9a\xc3\x00\xb9\xde\xd9\x99\xff\x92\x53\x37\x26\x68\x59\x5b\xde\xc4\xeb\xd8\x3d\x83\xc7" +
+			"\xf4\x2a\xce\x95\x85\x3b\x05\x8b\xd6\xbf\x29\x19\x15\xc4\xea\xfd\xac\x70\xcf\x9b\xe6\x30\x30\x41" +
+			"\x69\xba\xc7\x04\x33\x2d\xba\x27\x6a\x2c\xb3\xd9\xc0\x76\x03\x2e\x2d\xcc\xca\x22\x57\x1e\x8a\xe6" +
+			"\x98\x7a\xa0\xa2\x75\xf7\x0c\xcf\x2f\xf5\x7c\x65\x76\xde\x0f\xe5\xef\x4c\x64\xd0\x3d\x69\x2e\x0d" +
+			"\x84\x99\xf6\xa4\x1c\x28\x25\x80\xc9\x7a\xa4\x6d\x98\x76\x3f\x5f\x2b\x4c\x2f\x44\xbf\x33\xc1\xa3" +
+			"\x4f\x6c\xed\x49\x1d\xa5\x70\x2e\xf2\x8b\x2c\x00\x2d\xc1\x0e\xd6\xcb\xb9\x24\xb2\x51\x16\xa2\x44" +
+			"\x6e\x7c\x97\x6e\x40\x2f\x47\x30\x18\x37\x2b\x0f\xb2\x9a\x94\x0d\x69\x48\x06\x04\x84\x56\x0d\x28" +
+			"\xca\x11\xab\xd7\x10\xe0\x18\x8f\x1a\xf3\xf0\x1b\x35\x64\x21\xf4\xe0\x08\xad\x18\xb7\xdf\x20\x54" +
+			"\xae\x10\x7f\x3b\xc6\x33\xef\x44\x2f\x99\xe8\x6f\x04\x29\xcb\x0c\xb4\x48\xbe\x95\x53\x4f\xf1\x25" +
+			"\x64\xbf\x2d\xa6\x4d\x70\x2c\xd5\x30\x89\x20\x15\xca\xed\x68\xd2\xb7\xbb\xb8\x31\x7d\x73\x77\x72" +
+			"\xe7\xfa\x6e\x37\xc3\x8b\x80\x8d\x54\x19\xdb\x23\x75\x0d\x93\x4c\xf6\x3b\xf9\x1e\xe9\x2b\x39\xd9" +
+			"\x5f\x4f\x6e\x8b\x78\x0d\xb5\xe1\x09\x9b\x35\x7c\x25\x2e\x54\xd2\x32\x2e\x41\x0f\x58\x1b\x75\x26" +
+			"\x2d\x4f\xc0\xfb\x66\x94\xc3\x72\x3e\x87\x04\x69\xa4\xc2\x05\x68\x3a\xce\x57\x5f\x94\x30\x49\xa7" +
+			"\x17\x2d\x9d\xa5\x12\x59\xe2\x81\x47\x3e\x3c\x1f\xc8\xcf\xb3\x3b\x1a\x38\x95\x77\x00\x79\x4b\xb0" +
+			"\x2b\xa5\x17\x0d\xde\x56\x1b\xbb\x62\x15\x3f\x13\x67\x6b\x18\x01\xc3\x64\x14\xa8\xc7\x01\xeb\x2b" +
+			"\x48\x16\x08\x88\xf0\x6b\xc3\x09\x18\x34\xba\x12\x6b\x51\x8b\xb9\xb8\x16\x15\x5f\x69\x6e\x73\x41" +
+			"\xde\xd4\x0e\x63\x83\x06\xb0\x4d\x32\x19\xa9\xa4\xa3\x70\x18\x02\xbc\xf1\x05\x4e\x68\xb0\xb6\xd0" +
+			"\x23\xec\xdc\xca\xb2\x91\xca\xf2\x78\x9f\x2e\x85\x88\xee\xe0\x34\xe8\xd5\xc5\x65\x0a\x93\xcf\x30" +
+			"\x8a\x8e\x80\xe7\x0e\x6e\xdc\x0a\x82\xdc\xf7\xce\x99\x67\xc4\x36\x79\x8d\x50\x93\xd8\xb4\xc2\x9d" +
+			"\x23\x88\x03\x76\x45\xba\x4f\x03\x46\x90\xd4\x8f\xbe\x23\xb1\xad\x03\x9f\x4c\x16\x86\x60\x4c\x3e" +
+			"\xc2\xe7\xa3\xce\x38\xd7\xa2\x34\xb3\x0e\x56\x91\xda\xd8\x54\x2f\xb1\xb2\x39\xb0\x28\x4f\x39\xec" +
+			"\x16\xde\xee\xe7\x2a\xc5\x20\x63\x67\x73\x65\x6c\x0f\x71\x59\x03\xda\x99\x5a\xd4\x0a\xe5\x94\x19" +
+			"\xb3\x52\xba\x07\x08\x36\x4f\x26\xed\x9e\xaa\x55\x03\x37\x91\x2d\x6b\x95\xc9\x76\xc6\x62\x88\xae" +
+			"\x2c\xe2\x4e\xbd\x55\x4a\x84\x73\xc6\x65\x8f\xb7\xb7\x43\x16\xce\x1b\x86\xb6\x12\xae\xb5\xd2\xcd" +
+			"\xf6\x99\xb3\xea\xe7\x72\xe3\x4e\xa3\xab\xfb\xac\xcf\x63\x9e\xf3\xb4\x21\xff\xaf\x1f\x33\xec\xca" +
+			"\xd6\x6e\x6f\xe7\xe9\xc3\xcb\xd9\xde\x87\xec\x61\x73\x37\x73\xf6\xef\xff\xbd\x6f\xcb\xbb\x4f\x99" +
+			"\xb5\xa0\x73\x19\xd2\x3f\x1f\xee\x27\xff\x67\x93\xf8\xd7\xc9\x97\xe9\xd3\xfb\xff\x6e\xde\xd6\x8c" +
+			"\x56\xce\x21\x5c\x98\x2c\x79\x1d\x7e\x21\xd2\xc9\xbf\x9c\x5d\x64\x20\x89\x75\xf0\x68\x41\x9a\xc2" +
+			"\xd3\xb2\x63\x37\x72\x6d\x31\x5c\x86\x22\x8b\xe0\x9a\xf3\x12\x42\x25\x63\x3e\xab\x0a\x6e\x5d\x01" +
+			"\x13\x1c\xb1\x45\x24\x90\x87\x45\xe5\x02\x88\x95\x86\x5b\x96\x05\xd2\x92\x54\x39\x38\xb1\x05\x7d" +
+			"\x63\x64\xc3\x26\xb9\x40\x41\x9c\xe1\xf7\x9b\x8a\xdc\x54\xe4\x8a\x54\x04\x93\x96\x7e\x2c\x6c\x76" +
+			"\x8d\xa5\x15\x5f\xd6\x55\x23\x83\xad\x07\x74\x2c\x50\xd7\x1a\x89\xe5\xf3\x6d\xfd\xf2\x9a\x44\x31" +
+			"\xcb\x84\xad\x6a\x02\x49\x6a\x1b\x29\x8e\x58\x91\xd7\x4e\xca\x6b\x1a\x8e\x47\x97\x05\x72\x90\x83" +
+			"\xca\xb1\x59\x57\xe4\xd1\x42\x38\x77\xb5\x11\x5c\x2e\x9a\x9a\x5b\x75\x79\xbb\xda\x8b\x22\x87\x66" +
+			"\x3f\xcb\x65\xbb\x04\xbe\x70\x01\x03\xa8\x47\x7a\x49\xe7\x15\x26\xd4\x7f\xf3\x1a\x58\xa9\x2e\x5c" +
+			"\xa8\xdd\x2b\xd1\xa1\xc4\xe6\x22\x27\x40\x2e\x89\xcf\xa3\x51\x3c\xd2\x69\x8e\x70\x93\x48\x38\x24" +
+			"\x79\x81\x19\x30\x3d\x22\xe1\x95\x26\xd6\x6d\x5e\x69\x98\x69\x51\x79\x3c\x4f\xb2\xbc\xb6\xeb\x1c" +
+			"\xaa\xda\xcc\x54\x9d\x4a\x28\xd5\x97\xa4\xae\x01\x62\xe0\x7a\x9b\xd9\xc2\xaf\x9b\x0d\xab\x39\x34" +
+			"\x5c\xd6\x75\x57\x69\xa1\xe1\x38\x91\x8a\xf0\x91\xa7\xf1\xc8\x89\xbb\xde\xff\xf2\x70\x3f\xf9\x30" +
+			"\x7d\xfa\xcf\xb8\x10\x74\xdd\x14\xd7\x45\xb6\x9c\x89\xab\x5e\x2c\xf0\x68\x35\xbb\xe5\xbf\xbf\x5c" +
+			"\x1b\x1e\x71\xb6\x4a\x85\xf3\x96\x5b\x85\xb4\x7c\x94\xb2\x35\xba\x95\x8a\xeb\xaf\xc4\x97\x2a\xb4" +
+			"\xcf\x90\xf1\x17\xe1\x50\xe7\x28\xf4\xa6\xef\xeb\x00\xa0\x9c\x81\x6a\xc7\xc0\x87\x2d\xb8\x5b\xd5" +
+			"\xc4\x0b\xbb\x22\x3e\xf8\x15\xc1\x61\x58\x04\x99\x32\x3c\xe8\xdb\x2a\x18\xe7\xe4\xc6\x72\x3f\x96" +
+			"\x77\x72\x3a\x29\x2b\x33\x75\x43\x18\xcf\x2c\x77\xef\x08\xe3\xd2\x59\xac\xe3\x20\xb1\xc3\x8e\x74" +
+			"\x4c\x4b\x19\x1f\x6f\xb4\x8f\x0f\x3f\x06\xd0\x34\x4a\x57\x67\x7a\xe5\xc5\x3e\xfd\x37\xb0\x63\x19" +
+			"\x3a\x27\x36\x92\x19\x68\x0a\xe9\xcb\x91\xb2\x3f\xe2\xef\x4c\xb7\xc7\xa4\x96\xc1\x44\xcc\xef\x66" +
+			"\xd4\x26\xc2\x8c\x01\x6d\x5f\x91\xfb\x7b\x62\x46\xba\x2e\x57\x23\x95\xfc\xec\x44\xec\xdb\x21\x7d" +
+			"\x74\x55\xaf\xa1\xde\xc3\xbe\xaf\xc6\x3d\x68\xbf\x7b\x56\x7b\xb3\x8c\x6c\x7d\xb0\xe7\xb8\xe6\x87" +
+			"\x9d\x00\xd7\x1c\x7d\x23\x8d\x1c\x3d\x82\xba\xfe\x7c\x7b\xfe\xce\x68\xf7\xff\x66\xf4\xf7\x00",
+		size: 28558,
 		mode: 0644,
-		time: time.Unix(1610907043, 136070583),
+		time: time.Unix(1792362923, 406553266),
 	},
 	"cmd/hub/api/requests/aks-adapter-instance.json.template": &asset{
 		name: "aks-adapter-instance.json.template",
 		data: "" +
-			"\x9c\x8e\xc1\x4a\xf4\x30\x14\x85\xf7\x7d\x8a\x10\xfe\xe5\xfc\x79\x80\x01\xd7\xee\x44\x04\x57\x32" +
-			"\x8b\x3b\xed\xb1\x94\x26\x37\xe1\xde\x9b\x41\x1c\xfa\xee\x32\x6d\x05\x85\x82\xd6\x5d\x38\x5f\xce" +
-			"\x77\xee\xb5\x71\xce\x39\xcf\x94\xe0\x8f\xce\xff\xbb\xde\x5e\x93\x3f\x2c\xb1\x21\x95\x48\x36\xa3" +
-			"\xcf\x0c\x7c\x19\x24\x73\x02\xdb\xd7\xd8\xa8\x57\x7f\x74\x2f\x9e\x3a\x2a\x06\xb9\xa3\x51\xfd\x69" +
-			"\x85\x85\x84\x12\x0c\x32\x7f\x59\x36\xbf\xed\x76\xac\xa1\xcb\x89\x06\x5e\x7d\x33\xbd\x50\xac\xcb" +
-			"\xf6\x9c\x4d\x07\xb7\x51\x6d\x63\xae\x5d\x10\xf4\x43\xfe\x6b\x99\xde\xab\xe0\x09\x9a\xab\xb4\xb8" +
-			"\x97\x5c\xcb\xc3\x8d\xef\x95\x8d\xf5\x0c\x61\x18\x34\xd0\xa8\xa1\x8d\x55\x0d\xb2\xff\xa6\x9c\x4a" +
-			"\x66\xb0\x85\x81\x7b\x81\x6a\xa8\x12\x1f\x05\xaf\xc3\xdb\xa6\x8b\x4a\xd9\xa7\x53\xcd\xcf\x3f\x19" +
-			"\xf5\x77\xca\x16\x62\xff\x13\x31\xf5\x90\x00\xa6\x73\x44\xb7\xa9\x34\xa9\x58\x95\xa7\x66\x6a\x3e" +
-			"\x02\x00\x00\xff\xff",
+			"\x9c\x8e\x41\x4b\xc4\x30\x10\x85\xef\xfb\x2b\x42\xf0\x58\xf3\x03\x16\x3c\x7b\x13\x11\x3c\xc9\x1e" +
+			"\x66\xdb\x67\x29\x4d\x26\x61\x66\xb2\x88\x4b\xff\xbb\x6c\xbb\xa2\x42\x41\xeb\x2d\xbc\x2f\xef\x7b" +
+			"\x73\xde\x39\xe7\x9c\x67\x4a\xf0\x7b\xe7\x6f\xce\x97\xd7\xe4\x9b\x25\x36\xa4\x12\xc9\x66\xf4\x99" +
+			"\x81\x4f\x83\x64\x4e\x60\xfb\x1e\x1b\xf5\xea\xf7\xee\xc5\x53\x47\xc5\x20\x77\x34\xaa\x3f\x5c\x61" +
+			"\x21\xa1\x04\x83\xcc\x5f\x96\xcd\x1f\xbb\x1d\x6b\xe8\x72\xa2\x81\x7d\xf3\x45\x4f\x14\xeb\xb2\x3d" +
+			"\x67\x53\xe3\x56\xaa\x6d\xcc\xb5\x0b\x82\x7e\xc8\xff\x2d\xd3\x7b\x15\x3c\x41\x73\x95\x16\xf7\x92" +
+			"\x6b\x79\xb8\xf0\xad\xb2\xb1\x1e\x21\x0c\x83\x06\x1a\x35\xb4\xb1\xaa\x41\xb6\xdf\x94\x53\xc9\x0c" +
+			"\xb6\x30\x70\x2f\x50\x0d\x55\xe2\xa3\xe0\x75\x78\x5b\x75\x51\x29\xdb\x74\xaa\xf9\xf9\x37\xa3\xfe" +
+			"\x4d\xd9\x42\xec\x36\x11\x53\x0f\x09\x60\x3a\x46\x74\xab\x4a\x93\x8a\xab\xf2\xb0\x9b\x76\x1f\x03" +
+			"\x00",
 		size: 631,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/aks-adapter-template.json.template": &asset{
 		name: "aks-adapter-template.json.template",
 		data: "" +
-			"\x94\x92\x4d\x4b\x33\x31\x14\x85\xf7\xfd\x15\x21\xbc\xcb\x36\xf0\xee\xa4\xe0\xa2\x0b\x71\x21\x48" +
-			"\x51\x5c\x49\x17\xb7\xc9\xed\x10\xf2\xc9\xbd\x89\x54\xcb\xfc\x77\xc9\x7c\x88\x42\x69\x75\x35\x97" +
-			"\x39\xcf\x9c\x3b\x39\x27\xa7\x85\x10\x42\xc8\x08\x01\xe5\x5a\xc8\x7f\xa7\x36\xf5\x72\x39\xbe\x36" +
-			"\xc8\x9a\x6c\x2e\x36\xc5\xa6\x6e\x1e\x9e\xc5\xc6\x40\x2e\x48\x33\xc1\x05\xb4\x6b\x1a\x38\x5e\xc1" +
-			"\xa8\xad\xff\xcf\xaa\x4e\x21\xa7\x88\xb1\xf0\x5d\x84\xbd\x47\x23\xd7\xe2\x55\xba\x1b\x5e\x81\x63" +
-			"\xb9\x14\xb2\x58\xef\x9b\x99\x90\x85\x00\x0f\xd6\xb5\xd1\xe0\xb1\x3d\x34\x52\x59\x05\x88\xd0\x21" +
-			"\xc9\xdd\xe4\xf8\x86\xb4\xe7\xc1\xc5\x60\xf6\xe9\xbd\x81\x35\x4e\xf3\x0c\x15\xe8\x46\x66\xfa\xa1" +
-			"\xdb\xb6\x6d\x16\x33\x10\x04\x2c\x48\x03\x32\x9e\xff\x47\x06\x26\xb2\x32\x29\x80\x8d\x72\x10\xfb" +
-			"\xa5\x38\x43\x69\x9f\xaa\x51\x84\x5d\xcb\xe6\x2a\x07\x1f\x95\xf0\x09\x39\x55\xd2\x78\x4f\xa9\xe6" +
-			"\xc7\xa6\x5f\xf8\xce\xd5\x3d\x52\xc4\x82\xac\xc0\xb1\xd2\xbe\x72\x8b\xfd\xd2\xa6\x39\x6c\x65\x63" +
-			"\x47\xc8\xac\x2a\xf9\x2d\xe1\xc1\x1e\xa7\x3e\xc6\x04\xc1\xd7\x81\x87\x9c\xff\x66\xc7\x9c\x5e\xae" +
-			"\x39\xf2\xef\x2c\xbf\x77\xab\x70\xba\x1b\xe7\x2c\x0b\xd5\x39\xa6\xaf\x76\x11\x02\x6f\x91\x82\x65" +
-			"\xb6\x29\x0e\x35\xee\x16\xfd\xe2\x33\x00\x00\xff\xff",
+			"\x94\x92\x3b\x6b\x63\x31\x10\x85\x7b\xff\x0a\x21\xb6\xbc\x16\x6c\xb7\x18\xb6\x70\xb1\x6c\x11\x08" +
+			"\x26\x21\x55\x70\x31\x96\xc6\x17\xa1\x27\x33\x92\x71\x62\xfc\xdf\x83\xee\x23\x0f\x30\x76\x52\x69" +
+			"\xd0\xf9\x74\x46\x3a\xa3\xd3\x42\x08\x21\x64\x84\x80\x72\x25\xe4\xaf\x53\xab\xce\xb2\x1b\xb7\x0d" +
+			"\xb2\x26\x9b\x8b\x4d\xb1\xa9\xeb\xbb\x47\xb1\x36\x90\x0b\xd2\x4c\x70\x01\xed\x9a\x06\x8e\x97\x30" +
+			"\x6a\xab\xdf\xb3\xaa\x53\xc8\x29\x62\x2c\xfc\x2f\xc2\xce\xa3\x91\x2b\xf1\x2c\xdd\x1f\x5e\x82\x63" +
+			"\xd9\x09\x59\xac\xf7\xcd\x4c\xc8\x42\x80\x7b\xeb\x5a\x69\xf0\xd8\x16\x8d\x54\x96\x01\x22\xf4\x48" +
+			"\x72\x3b\x39\x1e\x90\x76\x3c\xb8\x18\xcc\x3e\xbd\x34\xb0\xc6\xa9\x9e\xa1\x02\xfd\xc8\x4c\x17\xfa" +
+			"\xdb\xba\xcd\x62\x06\x82\x80\x05\x69\x40\xc6\xf7\x7f\xc9\xc0\x44\x56\x26\x05\xb0\x51\x0e\xe2\xb9" +
+			"\x13\x17\x28\xed\x53\x35\x8a\xb0\x6f\xd9\xdc\xe4\xe0\xb5\x12\x3e\x20\xa7\x4a\x1a\xff\x53\xaa\xf9" +
+			"\xbe\xe9\x57\xce\xb9\xba\x43\x8a\x58\x90\x15\x38\x56\xda\x57\x6e\xb1\x5f\xeb\x34\x87\xad\x6c\xec" +
+			"\x09\x99\x55\x25\xbf\x21\xdc\xdb\xa3\xec\x3e\xf0\x03\xf8\x3a\xf0\x90\xf3\xcf\xec\x98\xd3\xd3\x2d" +
+			"\x47\xfe\x9e\xe5\xe7\xd9\x2a\x9c\xfe\xc6\x25\xcb\x42\x75\x8e\xe9\x7d\xba\x08\x81\x37\x48\xc1\x32" +
+			"\xdb\x14\x87\x31\x6e\x17\xe7\xc5\xdb\x00",
 		size: 715,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/eks-adapter-instance.json.template": &asset{
 		name: "eks-adapter-instance.json.template",
 		data: "" +
-			"\xa4\xcf\x41\x6a\xf3\x30\x10\x05\xe0\x7d\x4e\x21\xc4\xbf\xcc\xaf\x03\x04\xba\xea\x05\xba\xe9\xaa" +
-			"\x64\x31\xb1\x5e\x8d\x88\x34\x12\x33\xa3\x50\x08\xbe\x7b\xa9\x9d\x42\x5b\x4c\x5b\xd3\x9d\x79\x6f" +
-			"\xfc\x3d\x74\xdd\x39\xe7\x9c\x67\x2a\xf0\x07\xe7\xff\x5d\xdf\xbe\x26\xbf\x5f\x62\x43\x69\x99\x6c" +
-			"\xae\xde\x33\xf0\x25\x49\xe5\x02\xb6\x8f\xb1\xd1\xa8\xfe\xe0\x9e\x3c\x45\x6a\x06\xb9\xc3\x59\xfd" +
-			"\xf1\x56\x36\x12\x2a\x30\xc8\x7c\xb2\x6c\x7e\xda\x8d\xac\x21\xd6\x42\x89\x6f\xde\xdc\x5e\x28\xf7" +
-			"\x65\x7b\xce\xa6\xbd\x5b\xf9\x75\xc8\xb5\xc7\x20\x18\x53\xe5\xef\xee\xce\xfd\x04\x61\x18\x34\x50" +
-			"\x4b\x01\x1c\x5b\x4d\x6c\x9b\xf7\xbe\x38\x03\xdd\x43\xfe\xa4\xe0\xac\x61\xc8\x5d\x0d\xb2\xfd\xf1" +
-			"\xb5\xb4\xca\x60\x0b\x89\x47\x81\x6a\xe8\x92\x1f\x04\xcf\xe9\x65\xd5\xa2\xd6\xb6\x71\xaa\xf5\xf1" +
-			"\x27\x51\x7f\x47\x0e\x10\xfb\x5f\x88\x69\x84\x04\x30\x9d\x32\xe2\x1a\x69\xd2\xb1\x80\xc7\xdd\xb4" +
-			"\x7b\x0d\x00\x00\xff\xff",
+			"\xa4\x8f\xcd\x4a\xc4\x30\x14\x85\xf7\x7d\x8a\x10\x5c\xd6\x3c\xc0\x80\x2b\x5f\xc0\x8d\x2b\x99\xc5" +
+			"\x9d\xe6\x58\x42\x93\x9b\x70\x73\x33\x08\x43\xdf\x5d\x6c\x15\x7f\x28\x6a\x99\x5d\x38\xe7\xe4\xfb" +
+			"\xb8\x97\xce\x18\x63\x2c\x53\x82\x3d\x18\x7b\x73\x79\x7b\xcd\xb6\x5f\x63\x45\x2a\x91\x74\xa9\x3e" +
+			"\x32\xf0\x39\x48\xe6\x04\xd6\xaf\xb1\xd2\x58\xed\xc1\x3c\x59\xf2\x54\x14\x72\x87\xa9\xda\xe3\x7b" +
+			"\x59\x48\x28\x41\x21\xcb\x64\x75\x7e\xf3\x7a\xae\xce\xe7\x44\x81\x6d\xff\xd9\x9e\x29\xb6\xd5\xbd" +
+			"\x64\x73\x6f\x36\xbe\x0e\x31\x37\xef\x04\x63\xc8\xfc\xdb\x6e\x6a\x27\x08\x43\x51\x1d\x95\xe0\xc0" +
+			"\xbe\xe4\xc0\xba\xdb\xf7\x83\x33\xd0\x3d\xe4\x2a\x0a\xa6\xea\x86\xd8\xaa\x42\xf6\x1f\x9f\x53\xc9" +
+			"\x0c\x56\x17\x78\x14\xd4\xea\x9a\xc4\x07\xc1\x73\x78\xd9\x64\x51\x29\xfb\x70\xb5\xe6\xc7\xbf\x88" +
+			"\xf5\x7f\xc8\x01\xa2\xb7\x89\x98\x46\x88\x03\xd3\x29\xc2\x6f\x21\x55\x1a\x56\xe0\xb1\x9b\xbb\xd7" +
+			"\x01\x00",
 		size: 673,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/eks-adapter-template.json.template": &asset{
 		name: "eks-adapter-template.json.template",
 		data: "" +
-			"\x94\x92\xbf\x6a\x33\x31\x10\xc4\x7b\x3f\x85\x10\x5f\xe9\x3b\xf8\xba\x60\x48\x11\x82\xab\x34\x86" +
-			"\x90\x2a\xb8\x58\x9f\xc6\x46\x9c\x6e\x25\x76\x75\xc1\xc1\xf8\xdd\x83\xee\x8f\x49\xc0\x24\x76\xa5" +
-			"\x45\x33\xfb\x5b\xb1\xa3\xd3\xc2\x18\x63\x2c\x53\x07\xbb\x32\xf6\xdf\xa9\x54\x67\xbb\x1c\xaf\x1d" +
-			"\xb4\x11\x9f\xb2\x8f\x5c\xd4\xf5\xcb\xab\x79\x72\x94\x32\x64\x76\x68\xa6\xa6\x2d\x1a\x5a\xad\x68" +
-			"\xd4\x56\xff\x67\xb5\x89\x5d\x8a\x0c\xce\xba\x66\xda\x05\x38\xbb\x32\xef\xb6\x7d\xd0\x0a\xad\xda" +
-			"\xa5\xb1\xd9\x87\x50\x60\xc6\xe2\x98\x21\x4c\xa1\x72\x3c\x2a\x42\xd8\xfb\xb6\x94\x0e\xc7\x72\x34" +
-			"\x90\x5c\x75\xc4\x74\x80\xd8\xed\x34\xe1\x03\xb2\xd3\x81\xea\x90\x42\xfc\x2c\xc6\x9e\xa7\x7a\x36" +
-			"\x65\x3a\x8c\x9e\xe9\x81\x8f\x65\xfa\x2c\x26\x12\xea\x90\x21\x83\x65\xdc\xc7\x8f\x9d\x38\xd6\xda" +
-			"\xc5\x8e\x3c\xdb\x41\x3c\x2f\xcd\x15\x57\xdb\xef\x20\x8c\x0c\xad\x29\xf9\x1a\xec\x52\xf4\x9c\xef" +
-			"\x68\x69\xe8\x19\x72\x6b\x03\x5a\xad\x9b\xd0\x6b\x89\xe2\x97\x8e\x4b\x00\xb5\xe7\x83\x40\xb5\xee" +
-			"\x25\x6c\x04\x7b\x7f\x9c\x32\x1a\xb7\x48\xa1\x1f\xfc\x94\xd2\x7d\x38\xd5\xf8\xf6\x17\x51\x6f\x43" +
-			"\x7e\xcf\xb7\xc6\xf4\x5f\xae\x21\xb3\xf4\x98\x90\x97\x84\x41\x9d\x6e\x20\x9d\x57\xf5\x91\x87\x28" +
-			"\xb7\x8b\xf3\xe2\x2b\x00\x00\xff\xff",
+			"\x94\x92\x3f\x8b\x1b\x31\x10\xc5\x7b\x7f\x0a\x21\x52\xee\x2e\xa4\x0b\x86\x14\x21\xb8\x4a\x63\x08" +
+			"\xa9\x82\x8b\xf1\xea\xd9\x88\xd5\x8e\xc4\x8c\xd6\xf8\x30\xfe\xee\x87\xf6\x8f\xef\x0e\xcc\x9d\x5d" +
+			"\x69\xd0\xfb\xcd\x1b\x31\x4f\x97\x95\x31\xc6\x58\xa6\x1e\x76\x6d\xec\xb7\x4b\xa9\xae\xb6\x9a\xae" +
+			"\x1d\xb4\x15\x9f\xb2\x8f\x5c\xd4\xcd\x9f\xbf\xe6\x97\xa3\x94\x21\x0b\xa1\x99\xda\xae\x68\xe8\xb4" +
+			"\xa6\x49\x5b\x7f\x5f\xd4\x36\xf6\x29\x32\x38\xeb\x86\x69\x1f\xe0\xec\xda\xfc\xb7\xdd\x0f\xad\xd1" +
+			"\xa9\xad\x8c\xcd\x3e\x84\x62\x66\x2c\xce\x19\xc2\x14\x6a\xc7\x93\x22\x84\x83\xef\x4a\xe9\x70\x2e" +
+			"\x47\x0b\xc9\x75\x4f\x4c\x47\x88\xdd\xcd\x13\x4e\x90\xbd\x8e\xae\x0e\x29\xc4\x97\x02\x0e\x3c\xd7" +
+			"\x0b\x94\xe9\x38\x31\xf3\x03\x7f\x96\xe9\x8b\x98\x48\xa8\x47\x86\x8c\xc8\xb4\x8f\x0f\x3b\x71\xac" +
+			"\x8d\x8b\x3d\x79\xb6\xa3\x78\xad\xcc\x1d\xaa\x1b\xf6\x10\x46\x86\x36\x94\x7c\x03\x76\x29\x7a\xce" +
+			"\x4f\xb4\xb4\xf4\x1b\xf2\x68\x03\x3a\x6d\xda\x30\x68\x89\xe2\x93\x8e\x5b\x00\x8d\xe7\xa3\x40\xb5" +
+			"\x19\x24\x6c\x05\x07\x7f\xb6\xd5\x1b\x7e\xa2\x30\x8c\x3c\xa5\xf4\x9c\x9d\x6a\xfc\xf7\x95\xa3\x3e" +
+			"\x66\xf9\x3e\xdf\x06\xf3\x7f\xb9\x67\x99\x65\xc0\x6c\x79\x4b\x18\xd4\xeb\x16\xd2\x7b\x55\x1f\x79" +
+			"\x8c\x72\xb7\xba\xae\x5e\x07\x00",
 		size: 735,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/eks-cluster-instance.json.template": &asset{
 		name: "eks-cluster-instance.json.template",
 		data: "" +
-			"\xac\xd2\x3d\xae\xdb\x30\x0c\x07\xf0\x3d\xa7\x30\x84\x8e\xb1\x0e\x10\xa0\x53\x2f\x90\xa5\x4b\x8b" +
-			"\x0c\xb4\xc4\xb8\x82\x25\xd2\x20\x25\x37\x69\x90\xbb\x17\xb1\xd3\x8f\x07\x18\xef\x25\x81\x36\xe3" +
-			"\x4f\xf3\x47\x98\xf4\x65\xd3\x34\x4d\x63\x08\x12\x9a\x5d\x63\x3e\x5d\x6e\x4f\x57\xb3\x5d\xe2\x8c" +
-			"\x69\x8c\x90\xe7\xd2\x9f\x0c\x69\x0a\xc2\x94\x90\xf2\xff\x71\x86\x5e\xcd\xae\xf9\x6e\x6e\x0d\x47" +
-			"\x96\xf4\x19\x07\x35\x87\x7b\x75\x04\x81\x84\x19\x65\x7e\x67\x19\xfa\x66\xb0\x27\xb5\x9e\x13\x04" +
-			"\xba\x83\x73\x75\x82\x58\x96\xe1\x73\x76\xdd\x36\x2b\xad\x2e\x72\xf1\x56\xb0\x0f\xfc\x6a\x33\x4c" +
-			"\x10\x22\x74\x21\x86\x7c\xfe\xc6\x84\xfa\xbc\xc3\x69\x64\x42\xca\x76\x28\x1d\x0a\x61\x46\xb5\x3f" +
-			"\x59\x06\x14\xab\xe1\x17\x56\x05\x1d\x17\xca\x55\xc5\x04\xa7\x2f\xd5\x51\x1d\x39\xef\x25\xb8\xba" +
-			"\x1f\x3f\x71\x2c\x09\x2b\x2e\x15\x07\xb5\x2e\x16\xcd\x28\xf5\x40\xf0\xe9\x95\x5f\xf9\x2f\x17\xa8" +
-			"\x17\x54\xb5\x45\xe2\x5e\xf0\x18\x4e\xab\x16\x8c\xe3\x73\x9c\x2a\x7f\xfd\x48\xd4\xc7\x48\x70\xc9" +
-			"\x22\x41\x17\xd1\xaf\x49\x47\x88\x8a\x0f\x41\x0e\x25\xb7\x09\x08\x7a\x94\xf7\xc4\x2c\xe5\x41\x70" +
-			"\x39\x66\x0b\x25\xb3\x3a\x88\x95\xd8\x7f\x17\x6e\x3d\xe8\x8f\x8e\x41\x7c\x9d\x0d\xac\xca\xd2\x81" +
-			"\xb3\x43\xa0\x55\xdb\x08\x82\x6f\x99\xe2\xf9\x7e\xac\xc3\xe6\xba\xf9\x1d\x00\x00\xff\xff",
+			"\xac\xd1\xb1\x8e\xdb\x30\x0c\x06\xe0\x3d\x4f\x61\x08\x1d\x63\x3d\x40\x80\x4e\x7d\x81\x2c\x5d\x5a" +
+			"\x64\xa0\x25\xc6\x15\x2c\x91\x06\x29\xb9\x49\x83\xbc\x7b\x91\x38\x87\xdc\x01\xc6\x5d\x72\xa7\xcd" +
+			"\xf8\x69\x7e\x84\xc8\xd3\xaa\x69\x9a\xc6\x10\x24\x34\x9b\xc6\x7c\x3b\x5d\xbe\xce\x66\x3d\xc7\x19" +
+			"\xd3\x18\x21\x5f\x4b\x2f\x19\xd2\x14\x84\x29\x21\xe5\xd7\x71\x86\x5e\xcd\xa6\xf9\x6d\x2e\x0d\x7b" +
+			"\x96\xf4\x1d\x07\x35\xbb\x5b\x75\x04\x81\x84\x19\xe5\xfa\xcf\x3c\xf4\xcd\x60\x4f\x6a\x3d\x27\x08" +
+			"\x64\xd6\xf7\xea\x04\xb1\xcc\xc3\xaf\xd9\x79\xdd\x2c\xb4\xba\xc8\xc5\x5b\xc1\x3e\xf0\x67\x9b\x61" +
+			"\x82\x10\xa1\x0b\x31\xe4\xe3\x2f\x26\xd4\xe7\x1d\x4e\x23\x13\x52\xb6\x43\xe9\x50\x08\x33\xaa\xfd" +
+			"\xcb\x32\xa0\x58\x0d\xff\xb0\x2a\xe8\xb8\x50\xae\x2a\x26\x38\xfc\xa8\x8e\xea\xc8\x79\x2b\xc1\xd5" +
+			"\x7d\xfc\xc4\xb1\x24\xac\xb8\x54\x1c\xd4\xba\x58\x34\xa3\xd4\x03\xc1\xa7\x40\x5f\xe0\x02\xf5\x82" +
+			"\xaa\xb6\x48\xdc\x0a\xee\xc3\x61\xd1\x82\x71\x7c\x8e\x53\xe5\x9f\x1f\x89\xfa\x18\x09\x2e\x59\x24" +
+			"\xe8\x22\xfa\x25\x69\x0f\x51\xf1\x21\xc8\xa1\xe4\x36\x01\x41\x8f\xf2\x9e\x98\xa5\x3c\x08\xce\xc7" +
+			"\x6c\xa1\x64\x56\x07\xb1\x12\x7b\xbf\x70\xeb\x41\xff\x74\x0c\xe2\xeb\x6c\x60\x51\x96\x0e\x9c\x1d" +
+			"\x02\x2d\xda\x46\x10\x7c\xcb\x14\x8f\xb7\x63\xed\x56\xe7\xd5\xff\x01\x00",
 		size: 1484,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/eks-cluster-template.json.template": &asset{
 		name: "eks-cluster-template.json.template",
 		data: "" +
-			"\x94\x91\x41\x6b\xb4\x30\x10\x86\xef\xfb\x2b\x42\xf8\x8e\x2a\x7c\xb7\x22\xf4\x54\xf6\xd4\xcb\x42" +
-			"\xe9\xa9\xec\x61\xd6\x8c\x12\x4c\x26\x61\x26\x16\xcb\xe2\x7f\x2f\x31\xba\xb4\xb0\xd0\xf6\xe4\x30" +
-			"\xef\xc3\x33\xfa\x7a\x3d\x28\xa5\x94\x26\xf0\xa8\x5b\xa5\xff\x5d\xf3\xb4\xe8\xaa\xac\x0d\x4a\xc7" +
-			"\x36\x26\x1b\x28\xa7\xc7\xe7\x17\xf5\xe4\x26\x49\xc8\x3b\x21\x09\xba\x31\x67\x38\x4a\xfb\x7f\xdf" +
-			"\x76\xc1\xc7\x40\x48\x49\x8e\x04\x17\x87\x46\xb7\xea\xad\xb0\xf5\xf8\x20\x35\x8e\xa2\x2b\xa5\x93" +
-			"\x75\x2e\xab\x94\xc6\x39\x21\x13\xb8\xda\x50\x49\x18\xb0\xb7\x63\x1e\x0d\xce\xf9\xd1\x21\xa7\xda" +
-			"\x03\xc1\x80\xac\xcf\xdb\x9d\x77\xe4\x8b\xac\x6e\x83\xd1\x85\x8f\x0c\x4e\xb4\xcd\x3b\x94\x60\x28" +
-			"\x4c\x74\x90\xfa\xc0\xfe\x31\x9f\xdf\xd3\x08\x0c\x1e\x13\xf2\xca\x94\x3a\xbe\x55\x62\x48\x1a\x13" +
-			"\x3c\x58\xd2\x6b\xb8\x54\xea\x0e\x75\xfb\xe2\xc6\xd2\xc0\x28\xd2\x4c\xec\x4e\x8c\xbd\x9d\xb7\x52" +
-			"\xca\x0b\x83\x9b\x56\x1e\x62\xfc\x9b\x4e\x24\xbc\xfe\x64\x94\xdf\x29\xbf\x56\xd9\xe0\xf6\x83\xee" +
-			"\x28\x13\x4f\x58\x84\xb7\x2a\x11\xbc\x9c\x90\xbd\x15\xb1\x81\xd6\xca\xce\x87\xe5\xf0\x19\x00\x00" +
-			"\xff\xff",
+			"\x94\x91\x31\x6b\xf3\x30\x10\x86\xf7\xfc\x0a\x21\xbe\xd1\x36\x7c\x5b\x09\x74\x2a\x99\xba\x04\x4a" +
+			"\xa7\x92\xe1\x62\x9d\x8d\xb0\x74\x12\x77\x72\x70\x09\xfe\xef\x45\x96\x9d\xb6\x10\x68\x3b\xf9\xb8" +
+			"\xf7\xe1\x39\xfc\xea\xba\x53\x4a\x29\x4d\xe0\x51\xef\x95\xfe\x77\xcd\xd3\xac\xab\xb2\x36\x28\x2d" +
+			"\xdb\x98\x6c\xa0\x9c\x1e\x9e\x5f\xd4\x93\x1b\x25\x21\x6f\x84\x24\x68\x87\x9c\xe1\x20\xfb\xff\xdb" +
+			"\xb6\x0d\x3e\x06\x42\x4a\x72\x20\x38\x3b\x34\x7a\xaf\xde\x0a\x5b\x0f\x0f\x52\xe3\x20\xba\x52\x3a" +
+			"\x59\xe7\xb2\x4a\x69\x9c\x12\x32\x81\xab\x0d\x95\x84\x01\x3b\x3b\xe4\xd1\xe0\x94\x3f\x2d\x72\xaa" +
+			"\x3d\x10\xf4\xc8\xfa\xb4\xde\xb9\x20\x9f\x65\x71\x1b\x8c\x2e\xbc\x67\x70\xa4\x75\xde\xa0\x04\x7d" +
+			"\x61\xa2\x83\xd4\x05\xf6\x8f\xf9\xfc\x96\x46\x60\xf0\x98\x90\x17\xa6\xd4\xf1\xad\x12\x43\xd2\x98" +
+			"\xe0\xc1\x92\x5e\xc2\xb9\x52\x77\xa8\xdb\x1f\x37\x96\x7a\x46\x91\x66\x64\x77\x64\xec\xec\xa4\xab" +
+			"\x4f\xfc\x02\x6e\x5c\x78\x88\xf1\x6f\x3a\x91\xf0\xfa\x93\x51\x7e\xa7\xfc\x5a\x65\x83\xeb\x03\xdd" +
+			"\x51\x26\x1e\xb1\x08\x6f\x55\x22\x78\x39\x22\x7b\x2b\x62\x03\x2d\x95\x9d\x76\xf3\xee\x63\x00",
 		size: 582,
-		mode: 0644,
-		time: time.Unix(1603121367, 223489354),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/gke-adapter-instance.json.template": &asset{
 		name: "gke-adapter-instance.json.template",
 		data: "" +
-			"\x9c\x8e\xc1\x4a\xc4\x30\x14\x45\xf7\xf3\x15\x21\xb8\x1c\xf3\x01\x03\xfe\x83\x1b\x37\xca\x2c\x5e" +
-			"\xdb\x6b\x08\x4d\x5e\xc2\xcb\x4b\x51\x86\xfe\xbb\xd8\x56\x50\x28\x68\xdd\x85\x73\x73\xcf\x7d\xb7" +
-			"\x93\x31\xc6\x58\xa6\x04\x7b\x31\xf6\xee\xf6\xf9\x9a\xed\x79\xc5\x8a\x54\x22\xe9\x12\x7d\x31\xf0" +
-			"\x14\x24\x73\x02\xeb\x77\xac\xe4\xab\xbd\x98\x17\x4b\x03\x15\x85\x3c\xf8\x11\xf6\xba\x85\x85\x84" +
-			"\x12\x14\xb2\x7c\x59\x37\x7f\xec\x0e\x5c\xdd\x90\x13\x05\xde\x7c\x4b\x3a\x51\x6c\xeb\xf6\xc2\xe6" +
-			"\xb3\xd9\xa9\xf6\x31\xb7\xc1\x09\x7c\xc8\xff\x2d\xd3\x44\x21\x52\x17\x62\xd0\xf7\xe7\xcc\x38\xac" +
-			"\x19\x5b\x07\x61\x28\xaa\xf3\x23\x5c\x1f\x5b\x55\xc8\xf1\x6b\x72\x2a\x99\xc1\xea\x02\x7b\x41\xad" +
-			"\xae\x49\x7c\x14\xbc\x86\xb7\x5d\x17\x95\x72\x4c\x57\x6b\x7e\xfa\xcd\x58\xff\xa6\xec\x21\x7a\x9f" +
-			"\x88\xc9\x43\x1c\x98\xba\x88\x61\x57\xa9\xd2\xb0\x29\xaf\xa7\xf9\xf4\x11\x00\x00\xff\xff",
+			"\x9c\x8e\xc1\x4a\xf4\x30\x14\x85\xf7\xf3\x14\x21\xfc\xcb\xfe\x79\x80\x01\xdf\xc1\x8d\x1b\x65\x16" +
+			"\xb7\xed\x31\x84\x26\x37\xe1\xe6\xa6\x28\x43\xdf\x5d\x6c\x47\x54\x28\x68\xdd\x85\xef\xe4\x7c\xe7" +
+			"\x5e\x4f\xc6\x18\x63\x99\x12\xec\xd9\xd8\x7f\xd7\xf7\xd7\x62\xbb\x0d\x2b\x52\x89\xa4\x6b\xf4\xc1" +
+			"\xc0\x73\x90\xcc\x09\xac\x5f\xb1\x92\xaf\xf6\x6c\x9e\x2c\x8d\x54\x14\x72\xe7\x27\xd8\xcb\x2d\x2c" +
+			"\x24\x94\xa0\x90\xf5\xcb\xb6\xf9\x6d\x77\xe4\xea\xc6\x9c\x28\xb0\xed\x3e\xd3\x99\x62\xdb\xb6\x57" +
+			"\xb6\x74\x66\xa7\x3a\xc4\xdc\x46\x27\xf0\x21\xff\xb5\x4c\x33\x85\x48\x7d\x88\x41\x5f\x1f\x33\xe3" +
+			"\xb0\x66\x6a\x3d\x84\xa1\xa8\xce\x4f\x70\x43\x6c\x55\x21\xc7\xaf\xc9\xa9\x64\x06\xab\x0b\xec\x05" +
+			"\xb5\xba\x26\xf1\x5e\xf0\x1c\x5e\x76\x5d\x54\xca\x31\x5d\xad\xf9\xe1\x27\x63\xfd\x9d\x72\x80\xe8" +
+			"\xff\x44\x4c\x1e\xe2\xc0\xd4\x47\x8c\xbb\x4a\x95\x86\x9b\xf2\x72\x5a\x4e\x6f\x03\x00",
 		size: 625,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/gke-adapter-template.json.template": &asset{
 		name: "gke-adapter-template.json.template",
 		data: "" +
-			"\x94\x91\x4d\x4b\x03\x31\x10\x86\xef\xfd\x15\x21\x78\x6c\x03\xde\xa4\xe0\xc1\x43\xf1\xe0\xa5\x17" +
-			"\x4f\xd2\xc3\x74\x33\x0d\x21\x9f\xcc\x24\x52\x29\xfb\xdf\x25\xbb\x59\x51\x28\x55\x4f\x3b\xec\xfb" +
-			"\xf0\x24\x79\xe7\xb2\x12\x42\x08\x19\x21\xa0\xdc\x0a\x79\x77\x69\xd3\x28\xd7\xf3\x6f\x8d\x3c\x90" +
-			"\xcd\xc5\xa6\xd8\xd2\xe7\x97\x9d\x78\xd2\x90\x0b\xd2\x42\x70\x81\xc1\xb5\xcc\x38\xdc\xc0\x9c\x6d" +
-			"\xef\x97\x74\x48\x21\xa7\x88\xb1\xf0\x2e\xc2\xd1\xa3\x96\x5b\xf1\x26\xdd\x03\x6f\x8c\x43\xb9\x16" +
-			"\xb2\x58\xef\x9b\x4c\xc8\x42\x80\x27\xeb\xda\xa8\xf1\xdc\x3e\x03\x52\xd9\x04\x88\x60\x90\xe4\xa1" +
-			"\x1b\xdf\x91\x8e\x3c\x59\x34\x66\x9f\x3e\x1a\x58\x63\x9f\x17\xa8\x80\x99\x99\x7e\xa1\xc7\x76\xda" +
-			"\x12\x66\x20\x08\x58\x90\x26\x64\x7e\xff\x8f\x0e\x74\x64\xa5\x53\x00\x1b\xe5\x14\x8e\x6b\x71\x85" +
-			"\x1a\x7c\xaa\x5a\x11\x9a\xd6\xcd\x0d\xce\xd5\x23\x52\xc4\x82\xac\x8c\x43\x35\xf8\xca\xad\xbe\x5b" +
-			"\xe6\xa5\x34\x65\xa3\x21\x64\x56\x95\xfc\x9e\xf0\x64\xcf\xbd\xd7\xb9\x09\xf0\x75\xe2\x21\xe7\xff" +
-			"\xe9\x98\xd3\xeb\x6f\x46\xfe\x9b\xf2\xfb\x8e\x14\xf6\x1d\x5f\x53\x16\xaa\xd8\x95\x5f\x5b\x42\x08" +
-			"\xbc\x47\x0a\x96\xd9\xa6\x38\xad\xe3\xb0\x1a\x57\x9f\x01\x00\x00\xff\xff",
+			"\x94\x91\xbb\x6a\x03\x31\x10\x45\x7b\x7f\x85\x10\x29\xd7\x82\x74\xc1\x90\x22\x85\x49\x91\xc6\x4d" +
+			"\xaa\xe0\x62\xbc\x1a\x0b\xa1\x27\x33\x92\x71\x30\xfb\xef\x41\xfb\xc8\x03\x8c\x93\x54\x3b\xec\x3d" +
+			"\x1c\x49\x73\x2f\x2b\x21\x84\x90\x11\x02\xca\x8d\x90\x77\x97\x36\x0d\xb2\x9b\x7e\x6b\xe4\x9e\x6c" +
+			"\x2e\x36\xc5\x96\x3e\xbf\x6c\xc5\x93\x86\x5c\x90\x16\x82\x0b\xf4\xae\x65\xc6\xe1\x1a\xa6\x6c\x73" +
+			"\xbf\xa4\x7d\x0a\x39\x45\x8c\x85\xb7\x11\x0e\x1e\xb5\xdc\x88\x37\xe9\x1e\x78\x6d\x1c\xca\x4e\xc8" +
+			"\x62\xbd\x6f\x32\x21\x0b\x01\x1e\xad\x6b\xa3\xc6\x73\xfb\xf4\x48\x65\x1d\x20\x82\x41\x92\xfb\xd9" +
+			"\x78\x42\x3a\xf0\x68\xd1\x98\x7d\x7a\x6f\x60\x8d\xf3\xbc\x40\x05\xcc\xc4\xcc\x17\x7a\x6c\xa7\x2d" +
+			"\x61\x06\x82\x80\x05\x69\x44\xa6\xf7\xff\xd8\x81\x8e\xac\x74\x0a\x60\xa3\x1c\xc3\xa1\x13\x57\xa8" +
+			"\xde\xa7\xaa\x15\xa1\x69\xbb\xb9\xc1\xb9\x7a\x40\x8a\x58\x90\x95\x71\xa8\x7a\x5f\xb9\xad\xef\x96" +
+			"\x79\x59\x9a\xb2\xd1\x10\x32\xab\x4a\x7e\x47\x78\xb4\x67\xd9\x7d\xe1\x27\xf0\x75\xe4\x21\xe7\xff" +
+			"\xe9\x98\xd3\xeb\x6f\x46\xfe\x9b\xf2\x7b\x47\x0a\xe7\x8e\xaf\x29\x0b\x55\x9c\x95\x9f\x2d\x21\x04" +
+			"\xde\x21\x05\xcb\x6c\x53\x1c\xeb\xd8\xaf\x86\xd5\xc7\x00",
 		size: 659,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/hybrid-adapter-instance.json.template": &asset{
 		name: "hybrid-adapter-instance.json.template",
 		data: "" +
-			"\xac\xce\xc1\x4a\xf4\x30\x14\x05\xe0\x7d\x9f\x22\x84\x7f\x39\x7f\x1e\x60\xc0\x95\x1b\xc5\x8d\x1b" +
-			"\x57\x32\x8b\xdb\xe6\x58\x83\xc9\x4d\xb8\xb9\x1d\x1c\x86\xbe\xbb\xd8\x56\x50\x28\xe8\x30\xdd\x85" +
-			"\x73\x92\xef\xe4\xdc\x18\x63\x8c\x65\x4a\xb0\x7b\x63\xff\x9d\x3f\x4f\xa3\xdd\xcd\xb1\x22\x95\x48" +
-			"\x3a\x55\x5f\x19\xf8\x18\x24\x73\x02\xeb\xf7\x58\xa9\xaf\x76\x6f\x9e\x2d\x79\x2a\x0a\xb9\x79\x3d" +
-			"\xb5\x12\xbc\x3d\x2c\x7d\x21\xa1\x04\x85\x4c\xb7\xe6\xd9\x1f\xd3\x9e\xab\xf3\x39\x51\xe0\x85\x9c" +
-			"\xda\x23\xc5\x61\x9e\x9f\xb2\x71\x67\x56\x9e\xbe\x0d\x2d\x84\xa1\xa8\x8e\x4a\x70\x60\x5f\x72\x60" +
-			"\xbd\xd6\xe9\xe8\x16\x72\xbd\x12\x03\x58\xb7\x93\x1e\x70\xba\x18\xea\x72\x2a\x99\xc1\xea\x02\xf7" +
-			"\x82\x5a\x5d\x55\xd2\xd0\xdd\x97\xcd\xa8\xbb\x5c\x75\x03\x6c\x90\xf8\x28\x78\x09\xef\xab\x16\x95" +
-			"\x72\xe1\xdf\x6a\x7e\xfa\x4d\xac\x7f\x23\x3b\x88\xfe\x4f\xc4\xd4\x43\x1c\x98\xda\x08\xbf\x4a\xaa" +
-			"\x0c\x58\xc8\x43\x33\x36\x1f\x01\x00\x00\xff\xff",
+			"\xac\xce\x41\x4b\xc4\x30\x10\x05\xe0\xfb\xfe\x8a\x10\x3c\xd6\xfc\x80\x05\x4f\x5e\x14\x2f\x5e\x3c" +
+			"\xc9\x1e\xa6\xcd\xb3\x06\x93\x49\x98\x4c\x17\x97\xa5\xff\x5d\x6c\x57\x54\x28\xe8\xb2\xbd\x85\xf7" +
+			"\x92\xef\xe5\xb8\x31\xc6\x18\xcb\x94\x60\xb7\xc6\x5e\x1d\x3f\x4f\xa3\x6d\xe6\x58\x91\x4a\x24\x9d" +
+			"\xaa\xaf\x0c\xbc\x0f\x92\x39\x81\xf5\x67\xac\xd4\x57\xbb\x35\xcf\x96\x3c\x15\x85\xdc\xbc\x1e\x5a" +
+			"\x09\xde\xee\x4e\x7d\x21\xa1\x04\x85\x4c\xb7\xe6\xd9\x5f\xd3\x9e\xab\xf3\x39\x51\x60\xdb\x7c\xb7" +
+			"\x7b\x8a\xc3\x3c\x3f\x65\x63\x63\x16\x9e\xbe\x0d\x2d\x84\xa1\xa8\x8e\x4a\x70\x60\x5f\x72\x60\xbd" +
+			"\xd4\xe9\xe8\x16\x72\xb9\x12\x03\x58\xd7\x93\x1e\x70\x38\x1b\xea\x72\x2a\x99\xc1\xea\x02\xf7\x82" +
+			"\x5a\x5d\x55\xd2\xd0\xdd\x97\xd5\xa8\xbb\x5c\x75\x05\x6c\x90\xf8\x28\x78\x09\xef\x8b\x16\x95\x72" +
+			"\xe6\xdf\x6a\x7e\xfa\x4b\xac\xff\x23\x3b\x88\x5e\x27\x62\xea\x21\x0e\x4c\x6d\x84\x5f\x24\x55\x06" +
+			"\x9c\xc8\xdd\x66\xdc\x7c\x0c\x00",
 		size: 866,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/hybrid-adapter-template.json.template": &asset{
 		name: "hybrid-adapter-template.json.template",
 		data: "" +
-			"\x9c\x92\x4f\x8b\xdb\x30\x10\xc5\xef\xf9\x14\x42\xf4\x18\x1b\x7a\x2b\x81\x1e\xda\x50\x48\xe9\x25" +
-			"\x97\x9e\x96\x1c\xc6\xd6\x24\x11\x96\x46\x62\x66\xbc\x9b\x10\xf2\xdd\x17\xff\x0b\xbb\x10\x76\xd7" +
-			"\x39\xf9\xe1\x79\xbf\x27\xa1\x79\x97\x85\x31\xc6\x58\x82\x88\x76\x65\xec\xb7\x4b\xa7\xae\x76\x39" +
-			"\xfc\x76\x28\x35\xfb\xac\x3e\x51\x37\xdd\x9c\x2b\xf6\xce\xfc\x72\x90\x15\xd9\xec\x13\x9b\xdf\xc0" +
-			"\x58\x44\x54\x08\xe6\xc5\xeb\xd1\xac\x43\x6a\xdd\x84\x8b\x42\xdd\x74\xe0\xb1\x07\x0b\x18\xc0\xd5" +
-			"\xf7\xc9\x50\xa7\x98\x13\x21\xa9\xfc\x21\xa8\x02\x3a\xbb\x32\x4f\xb6\xf9\x21\xc5\x40\xd8\xa5\xb1" +
-			"\xea\x43\x40\xee\x15\x03\xee\x7d\xd3\x49\x87\xa7\xee\x53\x23\x6b\x11\x81\xe0\x80\x6c\x77\x63\xe8" +
-			"\x33\x72\x25\x7d\x90\xc3\x1c\xd2\xb9\x33\xb6\x34\xea\xc9\xa4\x70\x18\x3c\xe3\x9d\x7e\x8e\x07\x4e" +
-			"\xf3\x0c\x0c\x11\x15\xb9\x77\x0d\xaf\xf4\xee\xa5\x1c\x49\xe9\x52\x04\x4f\xb6\x1f\x5e\x97\xe6\x8e" +
-			"\xab\x69\x2b\x64\x42\x45\x29\x21\xfb\x12\xc9\xe5\xe4\x49\x67\x20\x35\xac\x91\x67\x01\xc1\x23\xe9" +
-			"\x43\xd0\x3f\x3c\x7f\xc4\xdc\xb6\x55\x7a\x3a\x30\x8a\x94\xa2\xa0\xbe\xfe\x9b\x1f\xa1\x36\x49\x74" +
-			"\x1e\xd7\x72\xd8\x32\xee\xfd\x69\xac\xcf\xb0\x6d\x08\x6d\xef\x87\x3c\xf7\x1a\x92\xfe\x7f\x96\x28" +
-			"\x5f\x8b\x7c\xdb\xc3\x12\xc7\x2a\xdf\x8b\x54\x6e\x71\x8c\xbc\x35\x11\x21\xca\x16\x39\x7a\x11\x9f" +
-			"\xa8\xef\xdb\x6e\x71\x5d\xbc\x06\x00\x00\xff\xff",
+			"\x9c\x92\xbb\x6a\xeb\x40\x10\x86\x7b\x3f\xc5\xb2\x9c\x52\x12\x9c\x2e\x18\x52\x24\x26\xe0\x90\xc6" +
+			"\x4d\xaa\xe0\x62\xa4\x1d\xdb\x8b\xf6\xc6\xcc\xc8\xb1\x31\x7e\xf7\xa0\x9b\x93\x80\x49\x22\x57\x1a" +
+			"\x34\xdf\xf7\x6b\x59\xfd\xa7\x99\x52\x4a\xe9\x00\x1e\xf5\x5c\xe9\x7f\xa7\x76\x3a\xeb\xac\x7f\x6d" +
+			"\x90\x2b\xb2\x49\x6c\x0c\xed\x76\x79\x2c\xc9\x1a\xf5\x60\x20\x09\x92\xda\x44\x52\x8f\x40\x98\x7b" +
+			"\x14\x70\xea\xdd\xca\x4e\x2d\x5c\x6c\xcc\xa8\xb3\x40\x55\xb7\xe2\xae\x13\x73\xe8\xc5\xf9\xff\x11" +
+			"\xa8\xa2\x4f\x31\x60\x10\x7e\x0a\x50\x3a\x34\x7a\xae\xde\x74\x7d\xc7\x79\x6f\xe8\x4c\x69\xb1\xce" +
+			"\x21\x75\x13\x01\x6e\x6c\xdd\x8e\x06\x0f\xed\xa3\x42\x92\xdc\x43\x80\x2d\x92\x5e\x0f\xa1\x7b\xa4" +
+			"\x92\xbb\x20\x83\xc9\xc5\x63\x0b\x36\x61\x98\x47\x48\x60\xdb\x33\xc3\x99\xee\x87\x0f\x8e\xfb\x04" +
+			"\x04\x1e\x05\xa9\xa3\xfa\x5b\xfa\x76\x53\x26\x70\x61\xa2\x07\x1b\x74\xb7\x3c\x67\xea\x0a\x55\x37" +
+			"\x25\x52\x40\x41\x2e\x20\xd9\x02\x83\x49\xd1\x06\x99\xa0\x54\xb0\x40\x9a\x24\x38\x8b\x41\x6e\x92" +
+			"\x5e\xf0\xf8\x93\x73\xf9\x5b\x85\x0d\x5b\x42\xe6\x82\x05\xc4\x56\xcf\xe9\x16\x6b\x19\x59\xa6\x79" +
+			"\x0d\xb9\x15\xe1\xc6\x1e\x74\xf6\x89\xef\xc1\x35\x1d\x0f\x69\xea\x31\x38\xbe\xfe\x96\xc8\x7f\x8b" +
+			"\xfc\xda\xc3\x02\x87\x2a\x5f\x8b\x14\x6a\x70\x88\xbc\x34\x11\xc1\xf3\x0a\xc9\x5b\x66\x1b\x43\xd7" +
+			"\xb7\xf5\xec\x3c\xfb\x18\x00",
 		size: 922,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/k8s-aws-adapter-instance.json.template": &asset{
 		name: "k8s-aws-adapter-instance.json.template",
 		data: "" +
-			"\xac\xcd\x31\x4b\x03\x41\x10\x05\xe0\xfe\x7e\xc5\xb2\x58\x26\x5b\x4b\xc0\xca\xd2\xc6\xc6\x4a\x52" +
-			"\x4c\xee\x9e\xc7\x92\xdd\xd9\x65\x66\x2e\x2a\xe1\xfe\xbb\x78\x77\x82\xc2\x81\x86\xd8\x2d\x6f\xde" +
-			"\x7e\xef\xdc\x38\xe7\x9c\x67\xca\xf0\x3b\xe7\x6f\xce\x9f\xaf\xd1\x6f\xe6\xd8\x90\x6b\x22\x9b\x4e" +
-			"\x5f\x19\xf8\x14\xa5\x70\x06\xdb\xf7\xd8\xa8\x57\xbf\x73\xcf\x9e\x3a\xaa\x06\xb9\x3b\xde\xea\x96" +
-			"\x5e\xd5\xef\x97\x42\x25\xa1\x0c\x83\x4c\xb5\x79\xf7\xc7\x76\xc7\x1a\xba\x92\x29\xf2\x62\x4e\xd7" +
-			"\x13\xa5\x61\xde\x9f\xb2\x71\xe3\x56\xbe\x1e\x87\x03\x84\x61\xd0\x40\x35\x06\x70\x57\x4b\x64\xbb" +
-			"\xd6\x69\xe9\x1e\x72\xbd\x92\x22\xd8\xfe\x4f\x7a\xc0\xfb\xc5\x50\x5b\x72\x2d\x0c\xb6\x10\xb9\x17" +
-			"\xa8\x86\x41\xd2\xa3\xe0\x25\xbe\xad\x5a\x54\xeb\x65\x9c\x6a\x79\xfa\x4d\xd4\xbf\x91\x2d\xc4\xb6" +
-			"\x99\x98\x7a\x48\x00\xd3\x21\xa1\x5b\x25\x4d\x06\x2c\xe4\xbe\x19\x9b\x8f\x00\x00\x00\xff\xff",
+			"\xac\xcd\x31\x4b\x03\x41\x10\x05\xe0\xfe\x7e\xc5\xb2\x58\x5e\xb6\x96\x80\x95\xa5\x8d\x8d\x95\xa4" +
+			"\x98\xdc\x3d\x8f\x25\xbb\xb3\xcb\xcc\x5c\x54\xc2\xfd\x77\xf1\x12\x51\xe1\x40\x43\xd2\x2d\xef\xcd" +
+			"\x7e\xef\xd0\x38\xe7\x9c\x67\xca\xf0\x6b\xe7\x6f\x0e\x9f\xaf\xc9\xb7\xc7\xd8\x90\x6b\x22\x9b\xab" +
+			"\xaf\x0c\xbc\x8f\x52\x38\x83\xed\x67\x6c\x34\xa8\x5f\xbb\x67\x4f\x3d\x55\x83\xdc\xed\x6e\x75\x45" +
+			"\xaf\xea\x37\xa7\x83\x4a\x42\x19\x06\x99\xcf\x8e\xbb\xbf\xb6\x7b\xd6\xd0\x97\x4c\x91\x7d\xfb\xdd" +
+			"\xee\x29\x8d\x73\xed\xe7\x6c\x6a\xdd\xc2\xd7\xdd\xb8\x85\x30\x0c\x1a\xa8\xc6\x00\xee\x6b\x89\x6c" +
+			"\x97\x3a\x1d\xdd\x43\x2e\x57\x52\x04\xdb\xf5\xa4\x07\xbc\x9f\x0d\x75\x25\xd7\xc2\x60\x0b\x91\x07" +
+			"\x81\x6a\x18\x25\x3d\x0a\x5e\xe2\xdb\xa2\x45\xb5\x9e\xc7\xa9\x96\xa7\xbf\x44\xfd\x1f\xd9\x41\x6c" +
+			"\x95\x89\x69\x80\x04\x30\x6d\x13\xfa\x45\xd2\x64\xc4\x89\xdc\x34\x53\xf3\x31\x00",
 		size: 715,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/k8s-aws-adapter-template.json.template": &asset{
 		name: "k8s-aws-adapter-template.json.template",
 		data: "" +
-			"\x9c\x92\x31\x6b\xc3\x30\x10\x85\xf7\xfc\x0a\x21\x0a\x5d\x12\x43\xb7\x10\xe8\x10\x4a\xa7\x2c\x81" +
-			"\x50\x3a\x94\x0c\x17\xeb\x62\x84\xa5\x93\xb8\x93\xdb\x84\x90\xff\x5e\x64\xcb\xa1\x85\xd0\x36\x9d" +
-			"\x7c\xf8\x7d\xef\xf9\xb8\xe7\xd3\x44\x29\xa5\x34\x81\x47\xbd\x50\xfa\xee\x94\xa7\xb3\x9e\x0e\xaf" +
-			"\x0d\x4a\xcd\x36\x26\x1b\x28\xab\xcb\xc6\x3a\xdc\x24\xa8\xdb\x7b\x51\xab\xf9\x46\x05\x52\xcb\xd7" +
-			"\x8d\x02\x03\x31\x21\x8f\x2e\xc9\x44\xe6\xdb\xb9\xcc\x8a\xb6\x78\x18\xd5\x3a\xf8\x18\x08\x29\xc9" +
-			"\x33\xc1\xce\xa1\xd1\x0b\xf5\x36\xa0\x1f\xa2\xa7\x4a\x27\xeb\x5c\x0e\x53\x1a\x0f\x09\x99\xc0\xcd" +
-			"\x0c\x0d\x0a\x03\xee\x6d\x9b\x47\x83\x87\xfc\xa8\x91\xd3\xcc\x03\x41\x83\xac\xb7\xe5\x0b\xef\xc8" +
-			"\x3b\xe9\x53\x0d\x46\x17\x8e\x19\xec\xa8\xcc\x23\x94\xa0\x19\x98\xb2\xe0\xe3\xb8\xc1\x08\x44\x60" +
-			"\xf0\x98\x90\x7b\x6c\xb8\xd3\xb7\x5b\x19\x92\xca\x04\x0f\x96\x74\x2f\x9e\xa7\xea\x0a\xd5\x76\x3b" +
-			"\x64\xc2\x84\x52\x41\xb4\x55\x0d\x4f\xc8\xe9\x16\x83\xb3\x48\xe9\x5f\xa6\x15\x1e\x7f\xf2\x5c\x8a" +
-			"\xa8\x2c\x35\x8c\x22\x55\xc7\x6e\xcd\xb8\xb7\x87\xd2\xd5\x70\x4d\x70\x5d\xcf\x43\x8c\xb7\xc5\x89" +
-			"\x84\x97\xdf\x12\xe5\x6f\x91\x5f\x7b\xae\xb0\xfc\x37\xd7\x22\x13\x77\x58\x22\x2f\x4d\x23\x78\x59" +
-			"\x23\x7b\x2b\x62\x03\xf5\x75\x6e\x27\xe7\xc9\x67\x00\x00\x00\xff\xff",
+			"\x9c\x92\x4f\x6b\xc2\x40\x10\xc5\xef\x7e\x8a\x65\x29\xf4\x12\x03\xbd\x89\xd0\x83\x94\x9e\xbc\x08" +
+			"\x52\x7a\x28\x1e\xc6\xec\x18\x96\xec\xce\x2e\x33\x1b\xab\x88\xdf\xbd\x6c\xfe\xd8\x16\xa4\xad\x3d" +
+			"\x65\xc8\xfb\xbd\x97\x61\x5e\x4e\x13\xa5\x94\xd2\x04\x1e\xf5\x5c\xe9\xbb\x53\x9e\xce\xba\xe8\x5f" +
+			"\x1b\x94\x8a\x6d\x4c\x36\x50\x56\x17\xb5\x75\xb8\x4e\x50\x35\xf7\xa2\x96\xb3\xb5\x0a\xa4\x16\xaf" +
+			"\x6b\x05\x06\x62\x42\x1e\x5d\x92\x89\xcc\x37\x33\x99\x0e\xda\xfc\x61\x54\xab\xe0\x63\x20\xa4\x24" +
+			"\xcf\x04\x5b\x87\x46\xcf\xd5\x5b\x8f\xbe\x8b\x2e\x94\x4e\xd6\xb9\x1c\xa6\x34\x1e\x12\x32\x81\x9b" +
+			"\x1a\xea\x15\x06\xdc\xd9\x26\x8f\x06\x0f\xf9\x51\x21\xa7\xa9\x07\x82\x1a\x59\x6f\x86\x2f\xec\x91" +
+			"\xb7\xd2\xa5\x1a\x8c\x2e\x1c\x33\xd8\xd2\x30\x8f\x50\x82\xba\x67\x86\x05\x1f\xc7\x0d\x46\x20\x02" +
+			"\x83\xc7\x84\xdc\x61\xfd\x9d\xbe\xdd\xca\x90\x94\x26\x78\xb0\xa4\x3b\xf1\x5c\xa8\x2b\x54\xd3\x6e" +
+			"\x91\x09\x13\x4a\x09\xd1\x96\x15\x3c\x21\xa7\x5b\x0c\xce\x22\xa5\x7f\x99\x96\x78\xfc\xc9\x73\x29" +
+			"\xa2\xb4\x54\x33\x8a\x94\x2d\xbb\x15\xe3\xce\x1e\x74\xf1\x89\xef\xc1\xb5\x1d\x0f\x31\xde\x16\x27" +
+			"\x12\x5e\x7e\x4b\x94\xbf\x45\x7e\xed\xb9\xc4\xe1\xbf\xb9\x16\x99\xb8\xc5\x21\xf2\xd2\x34\x82\x97" +
+			"\x15\xb2\xb7\x22\x36\x50\x57\xe7\x66\x72\x9e\x7c\x0c\x00",
 		size: 763,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/metal-adapter-instance.json.template": &asset{
 		name: "metal-adapter-instance.json.template",
 		data: "" +
-			"\xac\xce\x41\x4b\xc4\x30\x10\x05\xe0\x7b\x7f\x45\x08\x1e\xd7\xfc\x80\x05\x4f\x5e\x14\x2f\x5e\x3c" +
-			"\xc9\x1e\x66\x9b\x67\x09\x26\x93\x30\x99\x2e\xca\xd2\xff\x2e\xb6\x15\x14\x0a\xee\xd2\xde\xc2\x7b" +
-			"\xc9\xf7\x72\x6e\x8c\x31\xc6\x32\x25\xd8\xbd\xb1\x37\xe7\xef\xd3\x60\x77\x53\xac\x48\x25\x92\x8e" +
-			"\xd5\x4f\x06\x3e\x05\xc9\x9c\xc0\xfa\x3b\x56\xea\xaa\xdd\x9b\x57\x4b\x9e\x8a\x42\xee\x12\x94\xa2" +
-			"\x3d\xcc\x75\x21\xa1\x04\x85\x8c\x97\xa6\xd5\x3f\xcb\x9e\xab\xf3\x39\x51\xe0\x59\x1c\xdb\x13\xc5" +
-			"\x7e\x5a\x1f\xb3\x61\x67\x16\x9e\xbe\xf7\x47\x08\x43\x51\x1d\x95\xe0\xc0\xbe\xe4\xc0\xba\xd6\x69" +
-			"\xe9\x1e\xb2\x5e\x89\x01\xac\xdb\x49\x4f\xf8\xbc\x1a\x6a\x73\x2a\x99\xc1\xea\x02\x77\x82\x5a\x5d" +
-			"\x55\xd2\xd0\x3e\x96\xcd\xa8\x87\x5c\x75\x03\xac\x97\xf8\x2c\x78\x0b\x1f\x8b\x16\x95\x72\xe5\xdf" +
-			"\x6a\x7e\xf9\x4f\xac\x97\x91\x2d\x44\x6f\x13\x31\x75\x10\x07\xa6\x63\x84\x5f\x24\x55\x7a\xcc\xe4" +
-			"\xa1\x19\x9a\xaf\x00\x00\x00\xff\xff",
+			"\xac\xce\x41\x4b\xc4\x30\x10\x05\xe0\x7b\x7f\x45\x08\x1e\x6b\x7e\xc0\x82\x27\x2f\x8a\x17\x2f\x9e" +
+			"\x64\x0f\xb3\xcd\xb3\x04\x93\x49\x98\x4c\x17\x65\xe9\x7f\x17\xdb\x15\x15\x0a\xba\xb4\xb7\xf0\x5e" +
+			"\xf2\xbd\x9c\x1a\x63\x8c\xb1\x4c\x09\x76\x67\xec\xd5\xe9\xf3\x34\xda\x76\x8e\x15\xa9\x44\xd2\xa9" +
+			"\xfa\xca\xc0\xc7\x20\x99\x13\x58\x7f\xc6\x4a\x7d\xb5\x3b\xf3\x6c\xc9\x53\x51\xc8\x4d\x82\x52\xb4" +
+			"\xfb\x73\x5d\x48\x28\x41\x21\xd3\xa5\x79\xf5\xd7\xb2\xe7\xea\x7c\x4e\x14\xd8\xb6\xdf\xed\x91\xe2" +
+			"\x30\xaf\x4f\xd9\xd8\x9a\x85\xa7\xaf\xc3\x01\xc2\x50\x54\x47\x25\x38\xb0\x2f\x39\xb0\xae\x75\x3a" +
+			"\xba\x85\xac\x57\x62\x00\xeb\x76\xd2\x03\xde\x2f\x86\xba\x9c\x4a\x66\xb0\xba\xc0\xbd\xa0\x56\x57" +
+			"\x95\x34\x74\xf7\x65\x33\xea\x2e\x57\xdd\x00\x1b\x24\x3e\x0a\x5e\xc2\xdb\xa2\x45\xa5\x5c\xf8\xb7" +
+			"\x9a\x9f\xfe\x12\xeb\xff\xc8\x0e\xa2\xd7\x89\x98\x7a\x88\x03\xd3\x21\xc2\x2f\x92\x2a\x03\xce\xe4" +
+			"\xbe\x19\x9b\x8f\x01\x00",
 		size: 865,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/metal-adapter-template.json.template": &asset{
 		name: "metal-adapter-template.json.template",
 		data: "" +
-			"\x9c\x92\x41\x4b\x03\x31\x10\x85\xef\xfd\x15\x21\x78\x6c\x17\xbc\x49\xc1\x83\x8a\xa0\x78\xe9\xc5" +
-			"\x93\xf4\x30\xdd\x4c\x4b\xd8\x64\x12\x66\x66\xa5\xa5\xf4\xbf\xcb\x6e\x76\x8b\x42\x51\xb7\xa7\x1d" +
-			"\xf6\xbd\xf7\x25\x64\xde\x71\x66\x8c\x31\x96\x20\xa2\x5d\x1a\x7b\x73\xec\xa6\x93\x9d\x97\xdf\x0e" +
-			"\xa5\x66\x9f\xd5\x27\xea\xd4\x47\x60\x5c\x44\x54\x08\xe6\xc1\x41\x56\xe4\xd1\x28\x0a\x75\xd3\x59" +
-			"\x7a\x75\x01\x45\x5d\xde\x8e\x7a\x9d\x62\x4e\x84\xa4\xf2\x4c\xb0\x09\xe8\xec\xd2\x7c\xd8\xe6\x4e" +
-			"\x0a\xce\xce\x8d\x55\x1f\x42\x07\x34\x56\x19\x70\xeb\x9b\x6e\x74\xb8\xef\x3e\x35\xb2\x2e\x22\x10" +
-			"\xec\x90\xed\x7a\x60\x7e\x22\x6f\xa4\xe7\x38\xcc\x21\x1d\x3a\x63\x4b\xc3\x3c\x9a\x14\x76\xc5\x33" +
-			"\x5c\xe9\xbe\x9c\x37\xca\x19\x18\x22\x2a\x72\x6f\x2a\x8f\xf1\xe3\x41\x1c\x49\xe5\x52\x04\x4f\xb6" +
-			"\x17\x4f\x73\x73\xc1\xd5\xb4\x1b\x64\x42\x45\xa9\x20\xfb\x0a\xc9\xe5\xe4\x49\x27\x44\x6a\x78\x42" +
-			"\x9e\x14\x08\x1e\x49\xaf\x0a\xbd\xe1\xe1\xb7\xcc\x79\x57\x95\xa7\x1d\xa3\x48\x25\x0a\xea\xeb\xd7" +
-			"\x7c\x4d\xea\x25\x89\x4e\xcb\xb5\x1c\x56\x8c\x5b\xbf\x1f\xca\x53\x96\x0d\xa1\xed\xfd\x90\xa7\x5e" +
-			"\x43\xd2\xfb\x5f\x44\xf9\x1f\xf2\x7b\x0d\x2b\x1c\x8a\x7c\x09\xa9\xdc\xe2\x80\x3c\x17\x11\x21\xca" +
-			"\x0a\x39\x7a\x11\x9f\xa8\xef\xdb\x7a\x76\x9a\x7d\x05\x00\x00\xff\xff",
+			"\x9c\x92\x4d\x4b\xc3\x40\x10\x86\xef\xfd\x15\xcb\xe2\x31\x0d\x78\x93\x82\x07\x15\x41\xf1\xd2\x8b" +
+			"\x27\xe9\x61\x9a\x9d\x96\x25\xbb\xb3\xcb\xcc\xa4\xb4\x94\xfe\x77\xc9\x57\x55\x28\x6a\x7a\xca\x90" +
+			"\xf7\x79\x9f\x2c\x9b\x39\xce\x8c\x31\xc6\x12\x44\xb4\x0b\x63\x6f\x8e\xed\x74\xb2\x45\xff\xda\xa1" +
+			"\x54\xec\xb3\xfa\x44\x6d\xfa\x08\x8c\xf3\x88\x0a\xc1\x3c\x38\xc8\x8a\x3c\x82\xa2\x50\xd5\x2d\xd2" +
+			"\xa5\x73\xe8\xd3\xc5\xed\x98\x57\x29\xe6\x44\x48\x2a\xcf\x04\xeb\x80\xce\x2e\xcc\x87\xad\xef\xa4" +
+			"\xd7\xd9\xc2\x58\xf5\x21\xb4\x42\x63\x95\x01\x37\xbe\x6e\x47\x87\xfb\xf6\x51\x21\xeb\x3c\x02\xc1" +
+			"\x16\xd9\xae\x06\xe7\x0e\x79\x2d\x9d\xc7\x61\x0e\xe9\xd0\x82\x0d\x0d\xf3\x08\x29\x6c\x7b\x66\x38" +
+			"\xd2\x7d\xff\xbd\x31\xce\xc0\x10\x51\x91\x3b\xa8\xbf\x8c\x1f\x17\xe2\x48\x4a\x97\x22\x78\xb2\x5d" +
+			"\x78\x2a\xcc\x05\xaa\x6e\xd6\xc8\x84\x8a\x52\x42\xf6\x25\x92\xcb\xc9\x93\x4e\xa8\x54\xf0\x84\x3c" +
+			"\xa9\x10\x3c\x92\x5e\x55\x7a\xc3\xc3\x6f\x9d\xf3\xbf\x2a\x3d\x6d\x19\x45\x4a\x51\x50\x5f\xbd\xe6" +
+			"\x6b\x5a\x2f\x49\x74\x5a\xaf\xe1\xb0\x64\xdc\xf8\xbd\x2d\xbe\xf0\x1d\x84\xa6\xe3\x21\x4f\x3d\x86" +
+			"\xa4\xf7\xbf\x8c\xf2\x3f\xe5\xf7\x35\x2c\x71\x58\xe4\x4b\x4a\xe5\x06\x07\xe5\x79\x11\x11\xa2\x2c" +
+			"\x91\xa3\x17\xf1\x89\xba\x7d\x5b\xcd\x4e\xb3\xcf\x01\x00",
 		size: 897,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/openshift-adapter-instance.json.template": &asset{
 		name: "openshift-adapter-instance.json.template",
 		data: "" +
-			"\xa4\xcf\xc1\x6a\xc3\x30\x0c\x06\xe0\x7b\x9e\xc2\x98\x1d\x3b\x3f\x40\x61\xa7\xbd\xc0\x2e\x3b\x8d" +
-			"\x1e\xd4\xe4\x6f\x66\x1a\xcb\x42\x52\xca\xa0\xe4\xdd\xc7\x92\x0c\x36\x08\x6c\xa5\x37\xf3\x4b\xfe" +
-			"\x7e\xfb\xda\x84\x10\x42\x64\x2a\x88\xfb\x10\x1f\xae\x5f\xa7\x29\xee\x96\xd8\x51\x64\x20\x9f\x47" +
-			"\xdf\x19\xf8\x92\xb5\x72\x01\xfb\xcf\xd8\xa9\xb7\xb8\x0f\x6f\x91\x3a\x12\x87\x3e\x55\x01\xdb\x7b" +
-			"\x3e\x79\x3c\xac\x2b\x42\x4a\x05\x0e\x9d\x17\x97\xe6\x5f\xed\x1d\x5b\xea\x6a\xa1\xcc\xab\x3a\x4f" +
-			"\x2f\x34\x8c\xcb\x0b\xe6\x6c\xda\x85\x8d\xab\xe7\xf1\x08\x65\x38\x2c\x91\xe4\x04\xee\xa4\x66\xf6" +
-			"\x7b\x9d\x96\x9e\xa1\x77\x2b\x5e\xcf\xb8\xfd\x4b\x6d\x2d\x52\x19\xec\x29\x73\xaf\x30\x4b\xa3\x0e" +
-			"\x2f\x8a\x53\xfe\xd8\xb4\x48\xe4\x36\xce\xac\xbe\xfe\x25\xda\xff\xc8\x16\xea\x8f\x85\x98\x7a\x68" +
-			"\x02\xd3\x71\x40\xb7\x49\xba\x8e\x58\xc9\x43\x33\x35\x9f\x01\x00\x00\xff\xff",
+			"\xa4\xcf\xc1\x6a\xc3\x30\x0c\x06\xe0\x7b\x9e\xc2\x98\x1d\x33\x3f\x40\x61\xa7\xbd\xc0\x2e\x3b\x8d" +
+			"\x1e\xd4\xe4\x6f\x66\x1a\xcb\x42\x52\xca\xa0\xe4\xdd\xc7\xd2\x8e\x6d\x10\x58\x4b\x6f\xe6\xff\xe5" +
+			"\x4f\xe8\xd4\x84\x10\x42\x64\x2a\x88\x9b\x10\x1f\x4e\x5f\xaf\x39\xb6\xe7\xd8\x51\x64\x24\x5f\xaa" +
+			"\xef\x0c\x7c\xcc\x5a\xb9\x80\xfd\x77\xec\x34\x58\xdc\x84\xb7\x48\x3d\x89\x43\x9f\xaa\x80\xed\x3d" +
+			"\xef\x3d\x6e\x2f\x23\x42\x4a\x05\x0e\x5d\x06\xcf\x9b\xff\x6c\xef\xd9\x52\x5f\x0b\x65\x8e\xed\x4f" +
+			"\x7b\xa4\x71\x5a\xea\xb8\x64\x73\x1b\x56\xbe\x1e\xa6\x1d\x94\xe1\xb0\x44\x92\x13\xb8\x97\x9a\xd9" +
+			"\xef\x75\x3a\x7a\x86\xde\xad\x78\x3d\xe0\xf6\x93\xba\x5a\xa4\x32\xd8\x53\xe6\x41\x61\x96\x26\x1d" +
+			"\x5f\x14\xfb\xfc\xb1\x6a\x91\xc8\x6d\x9c\x59\x7d\xfd\x4f\xb4\xeb\xc8\x0e\xea\x8f\x85\x98\x06\x68" +
+			"\x02\xd3\x6e\x44\xbf\x4a\xba\x4e\xb8\x90\xdb\x66\x6e\x3e\x07\x00",
 		size: 639,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/api/requests/openshift-adapter-template.json.template": &asset{
 		name: "openshift-adapter-template.json.template",
 		data: "" +
-			"\x94\x92\x4f\x6b\xe3\x30\x10\xc5\xef\xf9\x14\x42\xec\x31\x31\xec\x6d\x09\xec\x61\x59\x7a\x6e\xa0" +
-			"\xf4\x54\x72\x98\x58\x2f\xa9\xb0\x3d\x12\x33\xe3\x92\x12\xf2\xdd\x8b\xfc\x27\x6d\x21\xb4\xcd\xc9" +
-			"\x83\xf5\x9b\x9f\xc5\x7b\x3e\x2d\x9c\x73\xce\x33\x75\xf0\x6b\xe7\x7f\x9d\xca\x74\xf6\xcb\xf1\x75" +
-			"\x80\xd6\x12\xb3\xc5\xc4\xe5\xf4\x3e\x83\x1f\x9e\xe3\xde\xdc\xbf\x40\xd9\x20\x33\xa7\x46\x75\x53" +
-			"\x88\x94\xc1\x5a\x88\x15\x8d\xc4\xfa\xf7\xcc\xd4\xa9\xcb\x89\xc1\xa6\x77\x4c\xbb\x16\xc1\xaf\xdd" +
-			"\x93\x6f\xfe\xe8\xea\xb2\xe4\x97\xce\x5b\x6c\xdb\x22\x76\xde\x84\xb0\x8f\x4d\x19\x03\x8e\xe5\x51" +
-			"\x43\x6c\xd5\x11\xd3\x01\xe2\xb7\x93\xf7\x05\xb2\xd3\xc1\x15\x90\xdb\xf4\x5a\xc0\x9e\xa7\x79\x86" +
-			"\x8c\x0e\x23\x33\x5d\xeb\xef\xfb\x37\x67\x24\x93\x50\x07\x83\x0c\xe0\x98\xcb\xa7\x6c\x02\x6b\x15" +
-			"\x52\x47\x91\xfd\x70\x78\x5e\xba\x2b\x54\xd3\xef\x20\x0c\x83\x56\x94\x63\x05\x0e\x39\x45\xb6\x1b" +
-			"\x56\x6a\xfa\x0f\xb9\x65\xc1\x52\x83\x2f\xef\x74\x89\xbe\x8a\x7c\x10\xa8\x56\xbd\xb4\x1b\xc1\x3e" +
-			"\x1e\xa7\x76\xc6\x24\xa9\xed\x07\x9e\x72\xbe\x4d\xa7\x9a\x1e\xbf\x33\xea\xcf\x94\x1f\x3b\xae\x30" +
-			"\xfd\x29\xd7\x94\x26\x3d\x26\xe5\xa5\x65\x50\xa7\x1b\x48\x17\x55\x63\xe2\xa1\xc8\xed\xe2\xbc\x78" +
-			"\x0b\x00\x00\xff\xff",
+			"\x94\x92\xcf\x6a\x23\x31\x0c\x87\xef\x79\x0a\x63\xf6\x38\x19\xd8\xdb\x12\xd8\xc3\xb2\xf4\xdc\x40" +
+			"\xe9\xa9\xe4\xa0\x8c\x7f\x49\xcd\x8c\x65\x23\x79\x42\x4a\xc8\xbb\x17\xcf\x9f\xa4\x85\xd0\x36\x27" +
+			"\x0b\xeb\xd3\x67\x21\xf9\xb4\x30\xc6\x18\xcb\x14\x60\x57\xc6\xfe\x3a\x95\xe8\x6c\xab\xf1\xda\x41" +
+			"\x1b\xf1\x29\xfb\xc8\x25\xfb\x98\xc0\x4f\xaf\x7e\x97\xcd\x3f\x47\x29\x43\x66\x4e\x33\x35\x6d\x21" +
+			"\x62\x02\x6b\x21\x96\x34\x12\xab\xdf\x33\xd3\xc4\x90\x22\x83\xb3\x3e\x30\x6d\x3b\x38\xbb\x32\x2f" +
+			"\xb6\xfd\xa3\xcb\x4b\x91\xad\x8c\xcd\xbe\xeb\x8a\xd8\xd8\x2c\x84\x9d\x6f\x4b\xe8\x70\x2c\x47\x03" +
+			"\xc9\xcb\x40\x4c\x7b\x88\xdd\x4c\xde\x03\x64\xab\x83\xcb\x21\x75\xf1\xad\x80\x3d\x4f\xf1\x0c\x65" +
+			"\xda\x8f\xcc\xd4\xd6\xdf\xeb\x9b\x33\x92\x48\x28\x20\x43\x06\x70\x9c\xcb\xa7\xd9\x38\xd6\xda\xc5" +
+			"\x40\x9e\xed\x90\x3c\x57\xe6\x06\xd5\xf6\x5b\x08\x23\x43\x6b\x4a\xbe\x06\xbb\x14\x3d\xe7\x3b\x4a" +
+			"\x1a\xfa\x0f\xb9\xa7\x20\xc7\x16\x5f\xf6\x74\x19\x7d\xed\x79\x2f\x50\xad\x7b\xe9\xd6\x82\x9d\x3f" +
+			"\xda\xea\x8a\x1f\xa8\xeb\x07\x9e\x52\xba\x4f\xa7\x1a\x9f\xbf\x33\xea\xcf\x94\x1f\x77\x5c\x63\xfa" +
+			"\x29\xb7\x94\x59\x7a\x4c\xca\xcb\x96\x41\x41\xd7\x90\xe0\x55\x7d\xe4\x61\x91\x9b\xc5\x79\xf1\x3e" +
+			"\x00",
 		size: 741,
-		mode: 0644,
-		time: time.Unix(1603121367, 227494072),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/initialize/hub.yaml.template": &asset{
 		name: "hub.yaml.template",
 		data: "" +
-			"\x7c\x90\xc1\x6e\x33\x21\x0c\x84\xef\x7e\x0a\x1f\xfe\x63\x60\x95\x2b\xaf\x12\xe5\x40\xc0\x2b\xf1" +
-			"\x87\xc5\xc8\x98\xad\xa2\xaa\xef\x5e\xb1\x9b\xa6\x4d\x54\x75\x2e\x88\x19\xbe\x01\x6c\x8c\x81\x95" +
-			"\xa4\x25\x2e\x0e\x8f\x70\x4d\x25\x3a\x6c\xea\xc3\x15\x16\x52\xef\x00\xb1\xf8\x85\x1c\xfe\x7b\xaf" +
-			"\xc2\xff\x29\xe8\x87\x3b\x02\xe2\x45\x12\xcd\x3f\x5d\x40\x8c\xd4\x82\xa4\xaa\x5b\x97\xb5\x16\x10" +
-			"\x1b\x77\x09\x34\x5a\x86\x62\x12\x87\x76\x02\x08\xbc\x54\x2e\x54\xb4\x8d\xc8\xdc\xaf\x98\x93\x34" +
-			"\x35\x8f\x6c\x83\x7e\x2b\xb0\xd3\x37\x3f\xbd\x42\x90\xd3\x4c\xe1\x16\xf2\x06\xad\x24\x97\xe6\xf0" +
-			"\x14\xa9\x66\xbe\x1d\x70\x5f\x8d\x52\xd3\x03\xf6\xb2\x6f\xcf\x80\xc8\x12\x49\x1c\x9e\x5e\xea\xce" +
-			"\x00\xdc\xb5\xf6\x3f\x1f\xea\x62\x69\x36\xf2\xe2\x53\x01\xa8\x5e\xfc\x42\x4a\xf2\x44\x84\xcc\x3d" +
-			"\x6e\x9f\x78\xce\x87\xbe\xce\x54\xe1\x35\x45\x92\xbb\x3d\xe6\x39\xfb\x9e\xd5\xa1\x7f\x6b\x0f\x73" +
-			"\xf5\xb9\xd3\x6e\x7d\x06\x00\x00\xff\xff",
+			"\x7c\x8e\x31\x6e\x03\x21\x10\x45\xfb\x39\xc5\x2f\x52\x1a\x2c\xb7\x5c\xc5\x72\x81\x61\x56\x22\x66" +
+			"\x01\x0d\xc3\x46\x56\x94\xbb\x47\xd9\xb5\xec\xd8\x8a\x42\x83\x78\x9f\xf7\x67\x8c\x31\xb4\xb0\xf4" +
+			"\x54\x8b\xc3\x81\x2e\xa9\x44\x87\xae\x3e\x5c\x68\x66\xf5\x8e\x80\xe2\x67\x76\x78\xfb\x6c\x52\xdf" +
+			"\x39\xe8\x97\x3b\x10\x70\x96\xc4\xd3\x6f\x4a\x40\xe4\x1e\x24\x35\x5d\xbb\xac\xb5\x04\xf4\x3a\x24" +
+			"\xb0\x23\xac\x27\x26\x71\xb0\x7b\xa2\x50\xe7\x56\x0b\x17\xed\x3f\x91\xb9\x8d\x98\x92\x74\x35\xf7" +
+			"\x6c\x95\xfe\x2a\xb0\xfb\x87\xbf\x7f\x95\x28\xa7\x89\xc3\x35\xe4\x55\x5a\x58\xce\xdd\xe1\x18\xb9" +
+			"\xe5\x7a\xdd\x61\xbb\x8d\x72\xd7\x1d\x46\xd9\x9e\x27\x02\xaa\x44\x16\x87\xe3\x4b\xdd\x89\xa8\x0e" +
+			"\x6d\xe3\xdf\x45\x5d\x2c\xdd\xc6\x3a\xfb\x54\x88\x9a\x17\x3f\xb3\xb2\x3c\x19\x21\xd7\x11\x09\x00" +
+			"\x9e\x73\xe0\xf1\xa7\x49\x5d\x52\x64\xb9\x61\x20\xf2\xe4\x47\x56\x07\xff\xd1\xef\x70\xf1\x79\xf0" +
+			"\x86\xbe\x07\x00",
 		size: 444,
-		mode: 0644,
-		time: time.Unix(1603121367, 235503506),
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
 	},
 	"cmd/hub/initialize/hub-component.yaml.template": &asset{
 		name: "hub-component.yaml.template",
@@ -481,10 +544,394 @@ var _bindata = map[string]*asset{
 			"\xcf\x9e\x67\x06\xcf\xc5\xf8\x14\x5d\xe0\x01\x88\xb8\xb3\xf8\xf2\xb6\x5d\x6f\x76\x1f\xbb\xf5\xf6" +
 			"\xf5\xc1\xd9\x57\x77\xdf\x60\x00\xa5\x98\x5b\xa7\x23\xd3\x31\xb4\x63\xd1\xe7\x16\x4f\xe6\x26\x2e" +
 			"\x00\x52\xd5\x5c\xf5\x1f\xba\xfb\x26\xc6\xe5\x30\xe4\xa7\xdb\xad\xdf\x37\x48\xec\x73\x0a\xac\x43" +
-			"\xbf\x73\x6d\x25\x8b\x5f\xaa\xd9\xae\x56\xb3\xe0\xf2\xf2\xf7\xb6\x57\xf8\x09\x00\x00\xff\xff",
+			"\xbf\x73\x6d\x25\x8b\x5f\xaa\xd9\xae\x56\xb3\xe0\xf2\xf2\xf7\xb6\x57\xf8\x19\x00",
 		size: 449,
+		mode: 0664,
+		time: time.Unix(1625161916, 0),
+	},
+	"cmd/hub/initialize/archetypes/helm/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xb3\x52\xf0\x48\xcd\xc9\x55\x48\xce\x48\x2c\x2a\x51" +
+			"\x48\x49\x2d\xc8\xc9\xaf\x4c\x4d\x51\x48\xaa\x54\xf0\x28\x4d\x52\x70\xf6\xf1\x54\x48\xad\x28\x49" +
+			"\xcd\x2b\xce\xcc\xcf\xe3\x02\x0c\x00",
+		size: 54,
+		mode: 0644,
+		time: time.Unix(1792363638, 261563063),
+	},
+	"cmd/hub/initialize/archetypes/helm/chart/Chart.yaml": &asset{
+		name: "Chart.yaml",
+		data: "" +
+			"\x4a\x2c\xc8\x0c\x4b\x2d\x2a\xce\xcc\xcf\xb3\x52\x28\x33\xe2\xca\x4b\xcc\x4d\xb5\x52\x88\x8e\x56" +
+			"\xd0\xf3\x4b\xcc\x4d\x55\x88\x8d\xe5\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\x01\xab\x88\x8e\x56\x28\x2c" +
+			"\xcd\x2f\x49\x55\xd0\x73\x2a\xca\x4c\x4d\x03\xc9\x97\x54\x16\xa4\x5a\x29\x24\x16\x14\xe4\x64\x26" +
+			"\x27\x82\x54\x71\x95\xc1\xcc\x33\xd0\x33\xd4\x33\xe0\x02\x0c\x00",
+		size: 98,
+		mode: 0644,
+		time: time.Unix(1792363638, 261661359),
+	},
+	"cmd/hub/initialize/archetypes/helm/chart/templates/configmap.yaml": &asset{
+		name: "configmap.yaml",
+		data: "" +
+			"\x2c\xcc\x21\x0f\xc2\x30\x10\xc5\x71\xdf\x4f\xf1\xc4\x24\x34\xc1\x36\x41\xa1\x41\x20\xe6\x2f\xec" +
+			"\x58\x9a\xad\xd7\xb1\x76\x4b\x48\xe9\x77\x27\x57\x70\x4f\xfc\xdf\x8f\x16\xdf\xf3\x9a\x7c\x14\x87" +
+			"\xfd\x64\x26\x2f\x83\xc3\x25\xca\xd3\x8f\x57\x5a\x4c\xe0\x4c\x03\x65\x72\x06\x10\x0a\xec\x50\x0a" +
+			"\xec\x9d\x67\xa6\xc4\xf6\x46\x81\x51\xab\xf9\x15\xa5\x1c\xb1\x92\x8c\x8c\x6e\xe2\xf7\x01\xdd\x4e" +
+			"\xf3\xc6\x70\x67\xd8\x5e\x57\xb2\x8f\xe6\xea\x03\xea\x68\x86\x5a\x9b\xf9\x8f\x3f\x78\x6d\x31\x37" +
+			"\x54\x39\x96\x41\xe7\x77\x00",
+		size: 165,
+		mode: 0644,
+		time: time.Unix(1792363638, 261709995),
+	},
+	"cmd/hub/initialize/archetypes/helm/chart/values.yaml": &asset{
+		name: "values.yaml",
+		data: "" +
+			"\x4a\xce\xcf\x4b\xcb\x4c\xb7\x52\xa8\xae\xe5\x02\x0c\x00",
+		size: 11,
+		mode: 0644,
+		time: time.Unix(1792363638, 261682822),
+	},
+	"cmd/hub/initialize/archetypes/helm/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x8c\x91\xc1\x4e\xf3\x30\x10\x84\xef\xfb\x14\xa3\xde\xed\x5f\xff\xd5\xb7\x52\x45\xea\x81\x96\x0a" +
+			"\x10\x97\xa8\x42\x26\xdd\x82\x45\x6c\x07\xdb\x89\xc4\xdb\x23\xd7\x22\x09\x15\x8a\xb8\x6d\xbe\x9d" +
+			"\x9d\xd9\xac\x85\x10\x34\x70\x88\xc6\x3b\x85\xff\xf4\x6e\xdc\x49\xa1\xf1\xb6\xf3\x8e\x5d\x22\xcb" +
+			"\x49\x2b\x02\x9c\xb6\xac\x50\xd7\x90\x7b\x6d\x19\xc7\x23\x01\x2f\xc1\xf0\xf9\x02\x3f\x7a\x9f\x18" +
+			"\xf2\x26\x83\xd2\x8b\xbe\x0f\x0d\xe7\x49\xe0\x64\x82\x82\xfc\x47\xd4\xe9\xa0\x2d\x27\x0e\x51\x51" +
+			"\x5d\x0b\x04\xed\x5e\x19\xf2\x30\xe2\x32\x2b\x7e\x4d\x03\xd8\x0d\x05\x56\x6e\xc8\x2c\x5b\x98\x33" +
+			"\xe4\x93\x6e\xfb\x51\x34\xe4\x8f\xf9\x52\x63\x37\xcb\xd9\x9d\xae\xca\x29\x6e\xfc\x69\x39\x0b\x96" +
+			"\xb9\x17\x3b\xdd\xf0\xb4\xc2\x7e\xbd\xab\x1e\x0e\xeb\x4d\x75\x15\x38\x5b\x76\xd9\xb3\x79\xd3\x21" +
+			"\x4d\x7e\xdb\xea\x76\xf7\xbc\xd9\xae\xef\x1f\xe7\x86\x45\x44\x89\x6d\xd7\xea\xc4\x51\x11\x70\x36" +
+			"\x6d\x29\x00\x81\xd5\x45\x18\xe5\xa7\xb6\xad\xfc\x96\xad\x88\x7c\x9f\xba\x3e\xfd\x3c\xf1\x5d\x61" +
+			"\xcb\xf7\x5d\x7a\xcf\x3f\x1e\xf6\x6b\x00",
+		size: 590,
+		mode: 0644,
+		time: time.Unix(1792363638, 261596629),
+	},
+	"cmd/hub/initialize/archetypes/helm/values.yaml.template": &asset{
+		name: "values.yaml.template",
+		data: "" +
+			"\x44\xcc\x31\x0e\xc2\x30\x10\x44\xd1\x3e\xa7\x18\x09\xda\x84\x3e\x27\xa0\x42\x54\x34\xd6\x16\x1b" +
+			"\x3c\x89\x2c\xd9\xeb\x68\x21\x85\x85\xb8\x3b\x0a\x14\x94\xbf\x78\xff\x00\xa7\x45\x3a\x23\xa6\x86" +
+			"\xf3\x36\x61\xf6\x5a\xb0\xaa\x6b\xe1\x93\xfe\xc0\xc4\xb9\x3a\x11\xb9\xe6\xda\xa0\x16\xb1\xd9\x2f" +
+			"\xba\x7b\xb5\x39\x2d\x63\x17\x42\x0f\x57\x5b\x88\xe1\xfa\x87\x22\x1d\x10\x02\x86\x9b\x3a\x44\x46" +
+			"\x1c\x5f\x7b\x5d\xb4\x10\x22\xa7\xa6\x25\xf7\xc9\x72\x32\xbe\xbf\x07\x5a\xdc\xcd\x67\x00",
+		size: 146,
+		mode: 0644,
+		time: time.Unix(1792363638, 261618447),
+	},
+	"cmd/hub/initialize/archetypes/kustomize/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xb3\x52\xf0\x2e\x2d\x2e\xc9\xcf\xcd\xac\x4a\x55\xc8" +
+			"\x2f\x4b\x2d\xca\x49\xac\x54\x48\x49\x2d\xc8\xc9\xaf\x4c\x4d\x51\x48\xaa\x54\xf0\x28\x4d\x52\x70" +
+			"\xf6\xf1\x54\x48\xad\x28\x49\xcd\x2b\xce\xcc\xcf\xe3\x02\x0c\x00",
+		size: 61,
+		mode: 0644,
+		time: time.Unix(1792363638, 261964407),
+	},
+	"cmd/hub/initialize/archetypes/kustomize/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x8c\x90\x4d\x4f\xf3\x30\x10\x84\xef\xfb\x2b\x46\x3d\xbe\x92\xfd\x8a\xab\x6f\x05\xe5\x48\xa9\x84" +
+			"\xc4\x25\xea\xc1\x24\x1b\x64\x11\x7f\xe0\x8f\xfc\x7e\x64\x2c\xdc\x52\xa1\x8a\xdb\xe6\x99\xdd\xcc" +
+			"\x78\x84\x10\xb4\x71\x4c\xc6\x3b\x85\x3b\x7a\x37\x6e\x56\x98\xbc\x0d\xde\xb1\xcb\x64\x39\x6b\x45" +
+			"\x80\xd3\x96\x15\xc6\x11\xf2\xa0\x2d\xe3\x74\x22\xe0\x35\x1a\x5e\xbe\xe0\x47\xf1\x99\x21\xef\x2b" +
+			"\x68\x5a\xf2\x25\x4e\x5c\x2f\x81\xd9\x44\x05\xf9\x9f\x28\xe8\xa8\x2d\x67\x8e\x49\xd1\x38\x0a\x44" +
+			"\xed\xde\x18\xf2\xd8\x71\xbb\x15\xbf\xba\x01\xec\xb6\x06\x07\xb7\x55\x56\x7f\x61\x16\xc8\x17\xbd" +
+			"\x96\xbe\xb4\xd5\x8f\xcb\x50\x5d\xad\xeb\xec\xe6\xab\xf1\x6c\xd7\x1f\x2d\x2f\x8c\x65\xd5\x52\xd0" +
+			"\x13\x9f\x23\x1c\xf6\x8f\xc3\xf3\x71\xff\x30\x5c\x19\xf6\xb0\x94\xd9\x86\x55\x67\x4e\x8a\x80\xc5" +
+			"\xac\x6d\x00\x04\x76\xff\xe4\xb7\xb8\x23\xf2\x25\x87\x92\x7f\xb6\xf1\xd4\xd8\xed\x2a\x6e\x55\xff" +
+			"\xc7\x0e\x3e\x07\x00",
+		size: 505,
+		mode: 0644,
+		time: time.Unix(1792363643, 534244594),
+	},
+	"cmd/hub/initialize/archetypes/kustomize/kustomization.yaml.template": &asset{
+		name: "kustomization.yaml.template",
+		data: "" +
+			"\x4c\xcf\x31\x6b\x03\x31\x0c\x05\xe0\xdd\xbf\xe2\x41\xb3\xc6\x25\x5b\x31\x74\x6e\xa1\xb4\x74\xca" +
+			"\x72\xdc\xa0\x3b\x2b\xc1\xe4\x2c\x19\xd9\x57\x48\x43\xfe\x7b\xb9\x26\x24\x19\x85\xbe\xf7\x84\x9e" +
+			"\x60\x2c\x91\x8d\x23\x86\x23\xde\xe7\x01\x3b\xd3\x8c\x42\x46\x99\x1b\x5b\xc5\xc0\x3b\x35\x46\xe4" +
+			"\x32\xe9\x11\x24\x11\xb3\x5c\x06\x47\x25\x6d\xd9\x6a\x52\x09\x38\xcc\xb5\x69\x4e\xbf\xec\x47\x95" +
+			"\x5d\xda\xfb\xc3\x4b\xf5\x49\x9f\x7f\x36\x03\x37\xda\xb8\x43\x92\x18\xf0\x71\x55\xd4\x92\x8a\x13" +
+			"\xca\x5c\x0b\x8d\x1c\xb0\x3a\x8d\x9a\x8b\x0a\x4b\xf3\x5d\x07\xff\x45\x99\xd1\xf7\xfe\x46\xce\xce" +
+			"\xb8\xea\x6c\x23\xd7\xe0\x80\x35\x6e\x1b\x7f\xa4\x3c\xb9\xcb\xd5\x4f\x2a\x6f\x2c\x6c\xd4\xd4\xee" +
+			"\x2c\xe0\xa1\xd2\x01\xc0\x94\x1a\x1b\x4d\x35\xb8\xae\x5b\xc3\x48\xf6\x0c\xff\x7d\x7f\xfa\xca\x96" +
+			"\x82\x25\xba\x25\x43\xdf\xbf\xae\x4e\x0f\x3d\xe7\xff\x28\x4b\x5c\xf0\xdf\x00",
+		size: 327,
+		mode: 0644,
+		time: time.Unix(1792363638, 262041455),
+	},
+	"cmd/hub/initialize/archetypes/kustomize/namespace.yaml.template": &asset{
+		name: "namespace.yaml.template",
+		data: "" +
+			"\x34\xcb\xb1\xaa\xc2\x40\x10\x85\xe1\x7e\x9f\xe2\xc0\xbd\x75\xc0\x76\x9f\xc0\xca\xd2\x26\xa4\x98" +
+			"\x64\x4f\x20\xe8\xcc\x2c\xb3\x1b\x21\x88\xef\x2e\x22\x96\x3f\xfc\xdf\x1f\x82\x56\x18\x2c\x98\x0f" +
+			"\x9c\xf7\x19\x6b\xb8\xa2\x4a\x88\xb2\x33\x1a\x66\xae\x1e\x44\x61\xbd\xfb\x01\xb1\x82\xdd\xbe\x91" +
+			"\xa4\x6e\x57\x46\xdb\xdc\x32\x1e\xa7\x74\xdb\xac\x64\x5c\x44\xd9\xaa\x2c\x4c\xca\x2e\x45\xba\xe4" +
+			"\x04\x98\x28\x33\xfe\x9f\x8b\x6b\x75\xa3\xf5\x61\x1c\x31\x7c\x5e\x4c\xd3\x60\x3f\xf3\x4a\xef\x01" +
+			"\x00",
+		size: 145,
+		mode: 0644,
+		time: time.Unix(1792363643, 532813021),
+	},
+	"cmd/hub/initialize/archetypes/makefile/Makefile": &asset{
+		name: "Makefile",
+		data: "" +
+			"\x6c\x90\xc1\x4a\xc4\x30\x14\x45\xd7\xbe\xaf\xb8\x0c\x2e\x74\x31\xf9\x80\xc0\x80\x03\x33\xea\x62" +
+			"\x98\x76\x61\x05\x09\x41\x42\xf3\xac\xc5\xf6\xb5\xa4\x8d\xe0\xdf\x4b\x4d\xb5\x55\x5c\x85\x9b\xe4" +
+			"\x9c\xe4\x3e\x75\x38\xde\xee\x8b\xd3\xc3\xf3\x5d\xb6\x3f\x41\xef\xe0\xb9\x6f\xba\x0f\xa2\x6d\x2d" +
+			"\x65\x13\x3d\xa3\xec\xe4\xa5\xae\x54\xfb\x46\x94\xce\x34\x5d\xdc\x70\xf9\xda\x61\x73\xf8\xca\xb5" +
+			"\x54\x30\x06\xea\xec\x5a\x86\xb5\x1b\x32\x66\x8b\xe0\xa4\x62\xa8\xdc\x05\xd7\xf2\xc8\x61\x80\xb5" +
+			"\x3f\x1c\xd6\xf7\xb1\xc3\xe5\xd5\x94\x8f\xf2\x0e\x6b\xaf\x13\xcf\xe2\x17\x62\x5e\x90\xc5\xb1\x8f" +
+			"\xe3\xa0\xd7\x2f\xcc\x7b\x6b\xfd\x24\x7b\x74\x21\xb9\x53\x68\xe2\xf2\xb5\xdf\x6a\x8a\xf2\xb7\x56" +
+			"\x21\xfe\xff\x62\xa4\xf2\xfb\xec\xfc\xa4\xe7\x21\xe1\x1b\xa5\xcf\x01\x00",
+		size: 326,
+		mode: 0644,
+		time: time.Unix(1792363638, 261375865),
+	},
+	"cmd/hub/initialize/archetypes/makefile/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xb3\x52\xf0\x4d\xcc\x4e\x4d\xcb\xcc\x49\x55\x28\xcf" +
+			"\x2c\xc9\x50\x48\x49\x2d\xc8\xc9\xaf\x54\x48\xcc\x4b\x51\x28\xcd\x83\x72\x4a\x12\x8b\xd2\x53\x4b" +
+			"\x8a\xb9\x00\x03\x00",
+		size: 55,
 		mode: 0644,
-		time: time.Unix(1603121367, 235503506),
+		time: time.Unix(1792363638, 261288642),
+	},
+	"cmd/hub/initialize/archetypes/makefile/config.mk.template": &asset{
+		name: "config.mk.template",
+		data: "" +
+			"\x44\xcb\x31\x0a\xc2\x40\x10\x85\xe1\x3e\xa7\x78\xa0\xad\x39\x80\x60\x29\x58\x89\xfd\x30\xc5\x2c" +
+			"\xf3\x62\xe3\xce\x86\xd1\x08\x41\xbc\xbb\xac\x16\x96\x3f\xfc\xdf\x06\xc9\x70\x26\x1d\x65\xc5\x69" +
+			"\x29\x98\xb2\x55\xcc\x96\x56\xf9\x60\xde\x51\x38\xb5\x24\x9c\xf3\xad\xad\xb0\x70\x2c\xf1\x8b\x41" +
+			"\x64\x87\xb4\xb8\x12\xe3\xe5\x0f\x54\x07\x11\x8c\xc7\x78\x42\x15\xfb\x03\xb6\xaf\xde\x67\xab\x84" +
+			"\xea\xfb\xab\x18\xde\xbf\xcf\x00",
+		size: 126,
+		mode: 0644,
+		time: time.Unix(1792363638, 261352775),
+	},
+	"cmd/hub/initialize/archetypes/makefile/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x7c\x90\x31\x4f\x04\x21\x10\x85\xfb\xf9\x15\x2f\x57\x9a\x80\xb1\xa5\x34\xb1\x55\x0b\xb3\xcd\xe6" +
+			"\x8a\xf1\x76\x30\xc4\x05\xd6\x59\xd8\xdf\x6f\x90\xb8\xd1\xcb\x65\xbb\xe1\x7b\xf3\xe0\x0b\xc6\x18" +
+			"\xda\x44\xd7\x90\x93\xc3\x03\x7d\x86\x34\x39\x5c\x72\x5c\x72\x92\x54\x28\x4a\x61\x47\x40\xe2\x28" +
+			"\x0e\xe3\x08\xfb\xcc\x51\x70\x3e\x13\xf0\xae\x41\xfc\x0f\xfc\xaa\xb9\x08\xec\x63\x03\x3d\x5b\x73" +
+			"\xd5\x8b\xb4\x26\x30\x05\x75\xb0\xf7\x44\x0b\x2b\x47\x29\xa2\xab\xa3\x71\x34\x50\x4e\x1f\x02\xfb" +
+			"\xba\xe3\xde\x35\x37\x5f\x03\x24\x6d\x1d\x3e\xa5\xad\xb1\x76\x45\xf0\xb0\x03\xcf\x75\x5f\xda\xda" +
+			"\xe1\xaf\xd4\x9e\xb6\x75\x49\xd3\xd5\x48\x45\xe2\x32\x73\x91\xd5\x11\xe0\xc3\xdc\x07\xc0\xe0\x74" +
+			"\x67\x7f\xc3\x13\x51\xae\x65\xa9\xe5\xbf\xf9\x4b\x67\xc7\xda\x47\xdf\x04\x78\xcd\xf1\xcd\x0f\xac" +
+			"\xbd\x38\xb0\x5e\x09\x7e\x0f\x00",
+		size: 417,
+		mode: 0644,
+		time: time.Unix(1792363638, 261323373),
+	},
+	"cmd/hub/initialize/archetypes/shell/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xb3\x52\x08\xce\x48\xcd\xc9\x51\x80\x08\x14\x2b\xa4" +
+			"\xa4\x16\xe4\xe4\x57\xea\x15\x67\x28\x24\xe6\xa5\x28\x94\xe6\xc1\xf9\x5c\x80\x01\x00",
+		size: 53,
+		mode: 0644,
+		time: time.Unix(1792363638, 260554347),
+	},
+	"cmd/hub/initialize/archetypes/shell/config.env.template": &asset{
+		name: "config.env.template",
+		data: "" +
+			"\x44\xcb\x31\x0a\xc2\x40\x10\x85\xe1\x3e\xa7\x78\xa0\xad\xf1\x04\x96\x82\x95\xd8\x2f\x53\xcc\x32" +
+			"\x2f\x5a\xec\xce\x86\x89\x11\x82\x78\x77\x89\x16\x29\x7f\xf8\xbf\x1d\x82\x6e\x0c\x1a\xf2\x82\xcb" +
+			"\x9c\x31\x44\xab\x18\x35\xb4\xf2\xc9\x98\x90\x39\xb4\x20\x8c\x63\x69\x0b\xd4\x0d\xb3\xff\xa3\x4b" +
+			"\xe9\x80\x50\xbf\x13\xfd\x6d\x03\x22\x5d\x4a\xe8\xcf\xfe\x82\xc8\x69\xff\x5e\xe3\xaa\x95\x10\x39" +
+			"\x4e\x0f\x96\xf2\xf9\x41\xba\xad\xeb\x77\x00",
+		size: 129,
+		mode: 0644,
+		time: time.Unix(1792363638, 260954155),
+	},
+	"cmd/hub/initialize/archetypes/shell/deploy.sh": &asset{
+		name: "deploy.sh",
+		data: "" +
+			"\x4c\x8e\x3d\xcb\x83\x30\x14\x85\xf7\xfc\x8a\xf3\xea\xbb\x1a\xf7\x42\x37\xbb\xd6\x0e\xa5\x4b\xb8" +
+			"\x43\xaa\xb7\x2a\xe8\x55\xe2\x07\x94\xd2\xff\x5e\x34\x82\x59\x02\x27\x9c\xe7\x3e\x27\xfe\x4b\x9f" +
+			"\x8d\xa4\x63\x8d\x84\x95\xd2\xd0\x69\xd1\xcb\xab\xa9\x34\xcb\xa2\x14\x17\x75\x8f\x28\xe3\xa1\xed" +
+			"\xdf\x8d\x54\x30\x06\xfa\x6a\x3b\x06\x51\xa4\x8c\x49\xe0\xac\x54\x0c\x7d\xb3\xce\x76\x3c\xb1\x1b" +
+			"\x41\xb4\x53\x08\xdb\x38\xe3\xff\xb3\xe6\x8b\x2c\x20\xfa\x7a\x9a\xa5\x5c\xfb\x2a\xc6\x3d\xcf\x72" +
+			"\x94\x9b\x27\xc4\xfc\x82\xed\x41\x3e\x4f\xc3\x3c\x8d\xa7\xd0\xbb\xff\x1d\xd2\x95\x7d\x58\xe7\x8d" +
+			"\x3e\xb4\xf3\x31\x77\x17\x6e\x47\x7f\x03\x00",
+		size: 250,
+		mode: 0755,
+		time: time.Unix(1792363638, 261044079),
+	},
+	"cmd/hub/initialize/archetypes/shell/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x7c\x90\x31\x4f\x04\x21\x10\x85\xfb\xf9\x15\x2f\x57\x9a\x80\xb1\xa5\x34\xb1\x55\x0b\xb3\xcd\xe6" +
+			"\x8a\xf1\x76\x30\xc4\x05\xd6\x59\xd8\xdf\x6f\x90\xb8\xd1\xcb\x65\xbb\xe1\x7b\xf3\xe0\x0b\xc6\x18" +
+			"\xda\x44\xd7\x90\x93\xc3\x03\x7d\x86\x34\x39\x5c\x72\x5c\x72\x92\x54\x28\x4a\x61\x47\x40\xe2\x28" +
+			"\x0e\xe3\x08\xfb\xcc\x51\x70\x3e\x13\xf0\xae\x41\xfc\x0f\xfc\xaa\xb9\x08\xec\x63\x03\x3d\x5b\x73" +
+			"\xd5\x8b\xb4\x26\x30\x05\x75\xb0\xf7\x44\x0b\x2b\x47\x29\xa2\xab\xa3\x71\x34\x50\x4e\x1f\x02\xfb" +
+			"\xba\xe3\xde\x35\x37\x5f\x03\x24\x6d\x1d\x3e\xa5\xad\xb1\x76\x45\xf0\xb0\x03\xcf\x75\x5f\xda\xda" +
+			"\xe1\xaf\xd4\x9e\xb6\x75\x49\xd3\xd5\x48\x45\xe2\x32\x73\x91\xd5\x11\xe0\xc3\xdc\x07\xc0\xe0\x74" +
+			"\x67\x7f\xc3\x13\x51\xae\x65\xa9\xe5\xbf\xf9\x4b\x67\xc7\xda\x47\xdf\x04\x78\xcd\xf1\xcd\x0f\xac" +
+			"\xbd\x38\xb0\x5e\x09\x7e\x0f\x00",
+		size: 417,
+		mode: 0644,
+		time: time.Unix(1792363638, 260829191),
+	},
+	"cmd/hub/initialize/archetypes/shell/undeploy.sh": &asset{
+		name: "undeploy.sh",
+		data: "" +
+			"\x52\x56\xd4\x4f\xca\xcc\xd3\x2f\xce\x50\xd0\x4d\xe5\xe2\xd2\x53\xd0\xd3\x4f\xce\xcf\x4b\xcb\x4c" +
+			"\xd7\x4b\xcd\x2b\xe3\xe2\x4a\x4d\xce\xc8\x57\x50\x0a\xcd\x4b\x49\x2d\xc8\xc9\xaf\xcc\xcc\x4b\x57" +
+			"\x88\x8e\x56\xd0\xf3\x4b\xcc\x4d\x55\x88\x8d\x55\xe2\xe2\x52\x56\x08\xf1\x77\xf1\x57\x28\x85\x2a" +
+			"\x40\x96\xe5\x02\x0c\x00",
+		size: 90,
+		mode: 0755,
+		time: time.Unix(1792363638, 261169190),
+	},
+	"cmd/hub/initialize/archetypes/skaffold/Dockerfile": &asset{
+		name: "Dockerfile",
+		data: "" +
+			"\x72\x0b\xf2\xf7\x55\x48\xcc\x29\xc8\xcc\x4b\xb5\x32\xd6\x33\x34\xe2\x72\xf6\x75\x51\x88\x56\x2a" +
+			"\xce\x50\xd2\x51\x50\xd2\x4d\x06\x91\xe5\x19\x99\x39\xa9\x0a\x25\x45\xa5\xa9\xd6\x0a\x29\xf9\x0a" +
+			"\xc5\x39\xa9\xa9\x05\x0a\xc6\x66\x06\x06\x20\x6e\x5e\xaa\x52\x2c\x17\x60\x00",
+		size: 69,
+		mode: 0644,
+		time: time.Unix(1792363638, 262852923),
+	},
+	"cmd/hub/initialize/archetypes/skaffold/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x04\xc0\xb1\x11\x80\x30\x08\x05\xd0\xde\x29\x98\xc3\xda\xce\xd2\x09\x62\xf2\x39\x31\x11\x72\x80" +
+			"\xe7\xfa\xbe\x86\xa8\x2e\x33\xc5\x74\xa5\xa3\x17\x66\x1b\x8d\xa6\xdb\x8d\x9a\xf4\x49\x5e\xb4\x59" +
+			"\xed\x70\x96\x01\x2a\xda\x68\x7f\x4f\xb8\x22\x11\xf4\x14\x15\x46\x64\x2c\xff\x00",
+		size: 71,
+		mode: 0644,
+		time: time.Unix(1792363638, 262770089),
+	},
+	"cmd/hub/initialize/archetypes/skaffold/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x8c\x90\xbd\x4e\x03\x31\x10\x84\xfb\x7d\x8a\x51\x4a\x24\x3b\xa2\x43\xee\x40\x8a\x10\x05\x3f\x15" +
+			"\xcd\x29\x85\x49\xf6\x22\x2b\xe7\xf5\xe1\x9f\x7b\x7e\x64\x2c\x9c\x10\xa1\x88\x6e\xfd\xed\xac\x67" +
+			"\x77\x94\x52\xb4\x70\x4c\x2e\x88\xc1\x2d\x1d\x9d\xec\x0d\x76\xc1\xcf\x41\x58\x32\x79\xce\xd6\x10" +
+			"\x20\xd6\xb3\xc1\x30\x40\xbf\x58\xcf\xd8\x6e\x09\xf8\x88\x8e\xc7\x6f\xf8\x59\x42\x66\xe8\x87\x0a" +
+			"\x5a\x2f\x85\x12\x77\x5c\x27\x81\xbd\x8b\x06\x7a\x4d\x34\xdb\x68\x3d\x67\x8e\xc9\xd0\x30\x28\x44" +
+			"\x2b\x07\x86\x7e\xeb\xb8\xcd\xaa\x3f\xdd\x00\x96\xa5\xc1\x8d\x2c\x95\xd5\x2f\xdc\x08\xfd\x6e\xa7" +
+			"\xd2\x45\x4b\x7d\x9c\x2f\xd5\xbb\x55\xce\xb2\xbf\x28\x4f\x76\xfd\x68\x7d\x66\xac\x9d\xb7\x07\x3e" +
+			"\xd9\x3f\x3d\xdf\x3f\x6e\x2e\x8c\xfa\x92\x94\xd9\xcf\x93\xcd\x9c\x0c\x01\xa3\x9b\x5a\x01\x28\xac" +
+			"\x8e\x77\x69\x7d\xa3\x7f\x04\x2b\xa2\x50\xf2\x5c\xf2\xef\x24\x5e\x1b\xbb\x1e\xc3\xb5\xd8\xff\x79" +
+			"\xff\xd7\x00",
+		size: 501,
+		mode: 0644,
+		time: time.Unix(1792363638, 262810508),
+	},
+	"cmd/hub/initialize/archetypes/skaffold/k8s/deployment.yaml.template": &asset{
+		name: "deployment.yaml.template",
+		data: "" +
+			"\x74\x90\xbd\x4e\xc3\x30\x14\x85\x77\x3f\xc5\x91\x60\x6d\x2b\x56\xcf\x20\x31\x20\xc4\xc4\x12\x75" +
+			"\xb8\x89\x4f\x4b\x84\x7d\x6d\x6c\x27\x52\x85\x78\x77\xe4\x96\x9f\x06\x95\x33\x25\x3e\xf7\xbb\x9f" +
+			"\xe5\x2b\x64\xaa\x63\xa6\x43\x7f\xc0\xfd\xd4\x63\x97\x63\x40\x92\x2c\x81\x95\xb9\xa0\xe7\x2e\x66" +
+			"\xc2\x31\xf9\x78\x80\xa8\xc3\xa4\xa7\x1f\x23\x69\x7c\x66\x2e\x63\x54\x0b\x49\xa9\x6c\xe6\x1b\xf3" +
+			"\x3a\xaa\xb3\xb8\x3d\x0e\x04\x6a\x35\x81\x55\x9c\x54\xb1\x06\x50\x09\xb4\xe8\x3a\xac\x1f\x25\x10" +
+			"\xdb\xad\x29\x89\x43\x6b\x0a\x3d\x87\x1a\x73\xfb\x06\x82\xd4\xe1\xe5\x41\x7a\xfa\x72\x3a\x40\xdb" +
+			"\xbf\x24\x81\xca\x90\xbc\x54\x7e\x31\x67\x9e\x16\xbf\xc0\x2f\x2e\x00\xbe\xf5\x2d\x43\xd4\x2a\xa3" +
+			"\x32\x9f\x41\xab\x0b\x57\xc6\x4f\xc6\x20\xfb\x7f\x4b\xea\x6c\x4d\xd7\xad\x90\x45\xf7\xc4\xfa\xe9" +
+			"\xf7\x49\x17\x73\x4b\xc9\x9d\xce\x7f\x6b\x60\x16\x3f\xd1\xe2\xfa\xfd\xcc\xb4\x79\x9b\x62\xe5\xc7" +
+			"\xd1\x40\x75\x0d\xfa\x1c\x00",
+		size: 460,
+		mode: 0644,
+		time: time.Unix(1792363638, 262892609),
+	},
+	"cmd/hub/initialize/archetypes/skaffold/skaffold.yaml": &asset{
+		name: "skaffold.yaml",
+		data: "" +
+			"\x5c\xcb\xbd\x0a\xc2\x30\x14\xc5\xf1\x3d\x4f\x71\x67\xc1\x56\x9d\x24\xab\xbb\xa3\x4b\xe9\x70\xda" +
+			"\xdc\x94\x4b\xbe\x4a\x73\x2b\xf4\xed\x25\x88\x8b\xe3\xe1\xfc\xfe\x58\xe5\xc5\x5b\x95\x92\x2d\xd5" +
+			"\x00\xef\x4b\x74\xfd\xfb\x36\xb1\xe2\x7a\x31\x41\xb2\xb3\xf4\x28\xd9\xcb\x62\x12\x2b\x1c\x14\xd6" +
+			"\x10\x65\x24\xb6\x34\x0c\xd4\x3d\x91\x98\xc6\xd1\x4c\xbb\x44\xd7\x2e\x6c\x2a\x1e\xb3\xd6\x36\x88" +
+			"\xce\x24\x09\xcb\x1f\x76\xbc\xc6\x72\x34\x10\xf6\x89\x67\x8d\x5f\x9b\x90\xc5\x73\xfd\xa5\x2d\x0e" +
+			"\xf7\xda\x9f\xba\x03\x29\x9a\xcf\x00",
+		size: 171,
+		mode: 0644,
+		time: time.Unix(1792363638, 262832847),
+	},
+	"cmd/hub/initialize/archetypes/terraform/archetype.yaml": &asset{
+		name: "archetype.yaml",
+		data: "" +
+			"\x4a\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\xb3\x52\x08\x49\x2d\x2a\x4a\x4c\xcb\x2f\xca\x55\xc8" +
+			"\xcd\x4f\x29\xcd\x49\x55\x48\x49\x2d\xc8\xc9\xaf\x4c\x4d\x51\x48\xaa\x54\xf0\x28\x4d\x52\x70\xf6" +
+			"\xf1\x54\x48\xad\x28\x49\xcd\x2b\xce\xcc\xcf\xe3\x02\x0c\x00",
+		size: 60,
+		mode: 0644,
+		time: time.Unix(1792363638, 262354455),
+	},
+	"cmd/hub/initialize/archetypes/terraform/hub-component.yaml": &asset{
+		name: "hub-component.yaml",
+		data: "" +
+			"\x7c\x90\xb1\x4e\xc4\x30\x10\x44\xfb\xfd\x8a\xd1\xf5\x36\xa2\x75\x89\x44\x0b\x14\x28\x4d\x74\xc5" +
+			"\x72\x59\x23\x8b\xd8\x0e\x9b\x4d\xbe\x1f\x19\x8b\x08\x4e\xe8\xba\xf5\xdb\x19\x7b\xc6\xce\x39\xda" +
+			"\x45\xd7\x54\x4b\xc0\x3d\x7d\xa4\x32\x05\x5c\x6a\x5e\x6a\x91\x62\x94\xc5\x38\x10\x50\x38\x4b\xc0" +
+			"\x38\xc2\x3f\x71\x16\x9c\xcf\x04\xbc\x69\x92\xf8\x0d\x3f\xb7\x6a\x02\xff\xd0\x40\xdf\xad\x75\xd3" +
+			"\x8b\x34\x27\x30\x25\x0d\xf0\x77\x44\x0b\x2b\x67\x31\xd1\x35\xd0\x38\x3a\x28\x97\x77\x81\x7f\x39" +
+			"\x70\xf7\xba\x7f\x5f\x03\xa4\xec\x1d\x3e\x96\xbd\xb1\x76\x45\x8a\xf0\x03\xcf\xdb\x21\xda\xdb\xe1" +
+			"\x77\xa8\x63\xdb\xe4\x52\xa6\xab\x91\x4c\xf2\x32\xb3\xc9\x1a\x08\x88\x69\xee\x03\xe0\x70\x32\x51" +
+			"\xe5\x58\x35\x7b\x8b\x3b\xeb\xea\x7f\xb4\x27\xa2\xba\xd9\xb2\xd9\xdf\x22\xcf\x9d\xdd\x6e\x71\xeb" +
+			"\xd7\x80\xa8\x35\xbf\xc6\x81\xb5\x1b\x07\xd6\xab\xbc\x5f\x03\x00",
+		size: 432,
+		mode: 0644,
+		time: time.Unix(1792363638, 262406599),
+	},
+	"cmd/hub/initialize/archetypes/terraform/main.tf": &asset{
+		name: "main.tf",
+		data: "" +
+			"\x2a\x49\x2d\x2a\x4a\x4c\xcb\x2f\xca\x55\xa8\xe6\x52\x50\x28\x4a\x2d\x2c\xcd\x2c\x4a\x4d\x89\x2f" +
+			"\x4b\x2d\x2a\xce\xcc\xcf\x53\xb0\x55\x50\xb2\xb3\x55\x30\xd0\x33\x34\x52\xe2\xaa\xe5\xe2\x52\x56" +
+			"\x08\xf1\x77\xf1\x57\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x56\xc8\x4f\x53\x88\x8e\x56\xd0\xf3\x4b" +
+			"\xcc\x4d\x55\x88\x8d\xe5\xca\xc9\x4f\x4e\xcc\x29\x06\x1b\x95\x07\x12\xb2\x55\x50\x42\x92\x06\x19" +
+			"\x01\x18\x00",
+		size: 112,
+		mode: 0644,
+		time: time.Unix(1792363638, 262474145),
+	},
+	"cmd/hub/initialize/archetypes/terraform/outputs.tf": &asset{
+		name: "outputs.tf",
+		data: "" +
+			"\x34\xcd\x31\x0a\xc2\x40\x18\x44\xe1\xfe\x3f\xc5\x63\xd9\x52\x73\x00\x21\x67\xb0\xb3\x19\xa6\x58" +
+			"\x70\x95\x05\x49\x24\x66\x6d\xc4\xbb\x4b\x14\xcb\xe1\x63\x78\xd2\x9e\xa5\x4c\xd7\x4a\x6e\x3b\xf2" +
+			"\xcc\x61\x64\x38\xf6\xf5\xde\xd7\x07\xb6\x44\xbb\x90\x1b\x76\x84\x44\x9d\xce\xd8\xf3\xd7\x49\x12" +
+			"\xc3\xa9\x2c\xd8\x89\x57\xc0\xb3\xdc\x7a\x65\xfc\xc3\x36\xec\x14\xef\xd8\x2a\xbf\x6b\x7c\x06\x00" +
+			"",
+		size: 113,
+		mode: 0644,
+		time: time.Unix(1792363703, 511963552),
+	},
+	"cmd/hub/initialize/archetypes/terraform/terraform.tfvars.template": &asset{
+		name: "terraform.tfvars.template",
+		data: "" +
+			"\x44\xcb\xa1\x0e\xc2\x50\x0c\x85\x61\xbf\xa7\x38\x09\x58\xc6\x13\xe0\x51\x04\x85\x69\x2a\x7a\xe9" +
+			"\x19\x88\xdd\xde\xa5\x30\xb1\x10\xde\x9d\x0c\x04\xf2\x4f\xfe\x6f\x83\x64\x38\x93\x8e\xb2\xe0\x38" +
+			"\x17\x0c\xd9\x2a\x26\x4b\xab\x7c\x32\x1f\x28\x1c\x5a\x12\xce\x69\x6c\x0b\x2c\x1c\x73\xfc\xa2\x13" +
+			"\xd9\x21\x2d\x6e\x44\x7f\xfe\x03\xd5\x4e\x04\xfd\xc5\x12\xaa\x38\x60\xfb\x5a\xf3\x64\x95\x50\xdd" +
+			"\xdf\xaf\xe3\xfb\x0b\x19\xbe\xae\x9f\x01\x00",
+		size: 129,
+		mode: 0644,
+		time: time.Unix(1792363638, 262433295),
+	},
+	"cmd/hub/initialize/archetypes/terraform/variables.tf": &asset{
+		name: "variables.tf",
+		data: "" +
+			"\x2c\xce\x41\x0a\xc2\x40\x0c\x85\xe1\x7d\x4e\xf1\x28\xb3\xd4\x1e\x40\x98\x2b\x88\x2b\x37\x21\x8b" +
+			"\x68\x63\x09\xd8\x71\xc8\x0c\x82\x88\x77\x97\xd2\xbe\xfd\xf7\xf8\x99\x8f\x08\x2d\xb3\x21\xf9\x01" +
+			"\xa9\xe2\x94\x31\x5e\x34\x74\xb1\x6e\xd1\x20\xc2\x0c\x7f\x20\x39\x44\x88\x98\x61\x65\x82\xc8\x5b" +
+			"\xc3\xf5\xf6\x34\x0c\xcc\x18\xaf\x1a\x10\x19\xf0\x25\xa0\x7f\xaa\x61\x5f\x46\xeb\xe1\x65\x26\x60" +
+			"\xb2\x76\x0f\xaf\xdd\x5f\x05\x79\x53\x67\x5d\x6c\x65\xf4\xa3\x35\x63\x3b\xa6\xff\x00",
+		size: 146,
+		mode: 0644,
+		time: time.Unix(1792363703, 511792904),
 	},
 }
 
@@ -630,6 +1077,56 @@ var _bintree = bintree{
 				},
 			},
 			"initialize": bintree{
+				"archetypes": bintree{
+					"helm": bintree{
+						"archetype.yaml": bintree{},
+						"chart": bintree{
+							"Chart.yaml": bintree{},
+							"templates": bintree{
+								"configmap.yaml": bintree{},
+							},
+							"values.yaml": bintree{},
+						},
+						"hub-component.yaml":   bintree{},
+						"values.yaml.template": bintree{},
+					},
+					"kustomize": bintree{
+						"archetype.yaml":              bintree{},
+						"hub-component.yaml":          bintree{},
+						"kustomization.yaml.template": bintree{},
+						"namespace.yaml.template":     bintree{},
+					},
+					"makefile": bintree{
+						"Makefile":           bintree{},
+						"archetype.yaml":     bintree{},
+						"config.mk.template": bintree{},
+						"hub-component.yaml": bintree{},
+					},
+					"shell": bintree{
+						"archetype.yaml":      bintree{},
+						"config.env.template": bintree{},
+						"deploy.sh":           bintree{},
+						"hub-component.yaml":  bintree{},
+						"undeploy.sh":         bintree{},
+					},
+					"skaffold": bintree{
+						"Dockerfile":         bintree{},
+						"archetype.yaml":     bintree{},
+						"hub-component.yaml": bintree{},
+						"k8s": bintree{
+							"deployment.yaml.template": bintree{},
+						},
+						"skaffold.yaml": bintree{},
+					},
+					"terraform": bintree{
+						"archetype.yaml":            bintree{},
+						"hub-component.yaml":        bintree{},
+						"main.tf":                   bintree{},
+						"outputs.tf":                bintree{},
+						"terraform.tfvars.template": bintree{},
+						"variables.tf":              bintree{},
+					},
+				},
 				"hub-component.yaml.template": bintree{},
 				"hub.yaml.template":           bintree{},
 			},
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/compose"
+)
+
+var (
+	addManifest string
+	addSource   string
+	addGitRef   string
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add component <name> --source <git:remote[#subDir] | dir:path> [-m hub.yaml]",
+	Short: "Add component to stack manifest",
+}
+
+var addComponentCmd = &cobra.Command{
+	Use:   "component <name> --source <git:remote[#subDir] | dir:path> [-m hub.yaml] [--ref ref]",
+	Short: "Add component to stack manifest",
+	Long: `Add component to stack manifest: read component manifest from source directory or Git
+repository (moved into components base directory once the component is added; --dry clone
+is discarded), add component reference, insert
+component into lifecycle order after providers of its requirements, and copy component
+parameters with defaults into stack parameters.
+
+The result is checked as by elaborate. Stack manifest YAML comments and flow style are preserved,
+indentation is normalized; use --dry to review the change first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return addComponent(args)
+	},
+}
+
+func addComponent(args []string) error {
+	if len(args) != 1 {
+		return errors.New("Add component command has one argument - component name")
+	}
+	if addSource == "" {
+		return errors.New("Component --source must be specified")
+	}
+
+	compose.AddComponent(addManifest, args[0], addSource, addGitRef, componentsBaseDir, dryRun)
+
+	return nil
+}
+
+func init() {
+	addComponentCmd.Flags().StringVarP(&addManifest, "manifest", "m", "hub.yaml",
+		"Path to stack manifest file")
+	addComponentCmd.Flags().StringVarP(&addSource, "source", "", "",
+		"Component source: git:<remote>[#subDir] or dir:<path relative to stack manifest>")
+	addComponentCmd.Flags().StringVarP(&addGitRef, "ref", "", "",
+		"Git ref (branch or tag) of git: source")
+	addComponentCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
+		"Path to component sources base directory (default to manifest dir)")
+	addComponentCmd.Flags().BoolVarP(&dryRun, "dry", "y", false,
+		"Print stack manifest diff instead of writing the file")
+	addCmd.AddCommand(addComponentCmd)
+	RootCmd.AddCommand(addCmd)
+}
@@ -71,16 +71,23 @@ func backupCreate(args []string, pipe io.WriteCloser) error {
 	setOsEnvForNestedCli(manifests, stateManifests, componentsBaseDir)
 
 	request := &lifecycle.Request{
-		Verb:              "backup",
-		DryRun:            dryRun,
-		ManifestFilenames: manifests,
-		StateFilenames:    stateManifests,
-		Components:        components,
-		OsEnvironmentMode: osEnvironmentMode,
-		ComponentsBaseDir: componentsBaseDir,
-		Environment:       hubEnvironment,
-		StackInstance:     hubStackInstance,
-		Application:       hubApplication,
+		Verb:               "backup",
+		DryRun:             dryRun,
+		ManifestFilenames:  manifests,
+		StateFilenames:     stateManifests,
+		Components:         components,
+		OsEnvironmentMode:  osEnvironmentMode,
+		SandboxMode:        sandboxMode,
+		LogDir:             logDir,
+		UploadLogs:         uploadLogs,
+		Simulate:           simulate || simulationFixtures != "",
+		SimulationFixtures: simulationFixtures,
+		Events:             eventsFormat,
+		EventsOutput:       eventsOutput,
+		ComponentsBaseDir:  componentsBaseDir,
+		Environment:        hubEnvironment,
+		StackInstance:      hubStackInstance,
+		Application:        hubApplication,
 	}
 
 	lifecycle.BackupCreate(request, bundleFiles, backupBundleInJson, backupAllowPartial, pipe)
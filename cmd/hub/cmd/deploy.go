@@ -35,7 +35,24 @@ var (
 var deployCmd = &cobra.Command{
 	Use:   "deploy hub.yaml.elaborate",
 	Short: "Deploy stack",
-	Long:  `Deploy stack instance by supplying a fully populated Hub Manifest.`,
+	Long: `Deploy stack instance by supplying a fully populated Hub Manifest.
+
+With --simulate, components implementations, hooks, requirements, and ready conditions
+are replaced by stubs. Components outputs captured via fromTfVar: are set from output
+value: or to simulated-<var> placeholder. Simulation fixtures file overrides that:
+
+	components:
+	  db:
+	    outputs:
+	      db_host: db.internal
+	    fail:
+	      undeploy: disk busy
+	  app:
+	    notReady: ["dns:app.example.com"]
+	time: 2021-01-01T00:00:00Z
+	operationId: 00000000-0000-0000-0000-000000000000
+
+Fixed time: and operationId: make state and backup bundle reproducible.`,
 	Annotations: map[string]string{
 		"usage-metering": "tags",
 	},
@@ -132,6 +149,14 @@ func lifecycleRequest(args []string, verb string) (*lifecycle.Request, error) {
 		LimitComponent:             limitComponent,
 		GuessComponent:             guessComponent,
 		OsEnvironmentMode:          osEnvironmentMode,
+		SandboxMode:                sandboxMode,
+		LogDir:                     logDir,
+		UploadLogs:                 uploadLogs,
+		RenderedDir:                renderedDir,
+		Simulate:                   simulate || simulationFixtures != "",
+		SimulationFixtures:         simulationFixtures,
+		Events:                     eventsFormat,
+		EventsOutput:               eventsOutput,
 		EnvironmentOverrides:       environmentOverrides,
 		ComponentsBaseDir:          componentsBaseDir,
 		GitOutputs:                 gitOutputs,
@@ -174,6 +199,10 @@ func initDeployUndeployFlags(cmd *cobra.Command, verb string) {
 		"Sync Stack Instance state to SuperHub (--hub-stack-instance must be set)")
 	cmd.Flags().BoolVarP(&hubSyncSkipParametersAndOplog, "hub-sync-skip-parameters-and-oplog", "", false,
 		"Sync skip syncing Stack Instance parameters and operation log")
+	if verb == "deploy" {
+		cmd.Flags().StringVarP(&renderedDir, "rendered-dir", "", "",
+			"Directory to keep a copy of rendered templates of the last 5 operations per component, see render --diff (default $HUB_RENDERED_DIR or .hub/rendered next to the manifest), '-' to disable; rendered templates may contain secrets, the directory is git-ignored")
+	}
 	initCommonLifecycleFlags(cmd, verb)
 	initCommonApiFlags(cmd)
 }
@@ -185,10 +214,24 @@ func initCommonLifecycleFlags(cmd *cobra.Command, verb string) {
 		fmt.Sprintf("Invoke %[1]s-test verb instead of %[1]s", verb))
 	cmd.Flags().StringVarP(&osEnvironmentMode, "os-environment", "", "no-tfvars",
 		"OS environment mode for child process, one of: everything, no-tfvars, strict")
+	cmd.Flags().StringVarP(&sandboxMode, "sandbox", "", "false",
+		"Run components in Linux sandbox (bubblewrap), one of: false, true, auto (if available); see lifecycle.sandbox")
+	cmd.Flags().StringVarP(&logDir, "log-dir", "", "",
+		"Directory to write component log files to (default $HUB_LOG_DIR or .hub/logs next to the manifest), '-' to disable")
+	cmd.Flags().BoolVarP(&uploadLogs, "upload-logs", "", false,
+		"Upload component log files to remote state location, next to the state file")
+	cmd.Flags().StringVarP(&eventsFormat, "events", "", "",
+		"Emit lifecycle events, one per line, in specified format: json")
+	cmd.Flags().StringVarP(&eventsOutput, "events-output", "", "-",
+		"Events destination: - for stdout (component output goes to stderr), fd:N for a file descriptor, or a file path")
 	cmd.Flags().BoolVarP(&config.SwitchKubeconfigContext, "switch-kube-context", "", false,
 		"Switch current Kubeconfig context to new context. Use kubectl --context=domain.name instead")
 	cmd.Flags().StringVarP(&enabledClouds, "clouds", "", "",
 		"A list of enabled clouds: \"aws,azure,gcp\" (default to autodetect from environment)")
+	cmd.Flags().BoolVarP(&simulate, "simulate", "", false,
+		"Replace component implementations, hooks, requirements, and ready conditions with stubs; templates, parameters, outputs, and local state are processed as usual; notifications and metrics are skipped, operation logs and rendered templates are kept only if --log-dir and --rendered-dir are set; remote state and --hub-sync are refused")
+	cmd.Flags().StringVarP(&simulationFixtures, "simulate-fixtures", "", "",
+		"Path to simulation fixtures file with components raw outputs, failures, and failed ready conditions (implies --simulate)")
 }
 
 func initCommonApiFlags(cmd *cobra.Command) {
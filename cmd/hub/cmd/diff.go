@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/compose"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff old.elaborate new.elaborate",
+	Short: "Show semantic changes between elaborated manifests",
+	Long: `Compare two hub.yaml.elaborate files and report changes in components, their order and
+dependencies, parameters (value, kind, env), outputs, requires and provides, lifecycle verbs,
+and templates setup.
+
+Secret parameter values are masked.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return diff(args)
+	},
+}
+
+func diff(args []string) error {
+	if len(args) != 2 {
+		return errors.New("Diff command has two arguments - paths to old and new elaborated manifests")
+	}
+	compose.Diff(util.SplitPaths(args[0]), util.SplitPaths(args[1]), jsonFormat)
+	return nil
+}
+
+func init() {
+	diffCmd.Flags().BoolVarP(&jsonFormat, "json", "j", false,
+		"JSON output")
+	RootCmd.AddCommand(diffCmd)
+}
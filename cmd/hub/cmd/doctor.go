@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/lifecycle"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [hub.yaml.elaborate]",
+	Short: "Diagnose environment and stack prerequisites",
+	Long: `Check everything the stack needs before deploy: binaries and their versions,
+cloud credentials, Hub CLI extensions, Kubeconfig context, state file access,
+and encryption key.
+
+Without elaborate manifest, common tools are checked and failures are reported as warnings.
+Exit code is 1 when any check failed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doctor(args)
+	},
+}
+
+func doctor(args []string) error {
+	if len(args) > 1 {
+		return errors.New("Doctor command has one optional argument - path to elaborate manifest file")
+	}
+	clouds := util.SplitPaths(strings.ToLower(enabledClouds))
+	if !util.ContainsAll(supportedClouds, clouds) {
+		return fmt.Errorf("Unsupported cloud specified (--clouds): %s; supported clouds are: %s",
+			strings.Join(clouds, ", "), strings.Join(supportedClouds, ", "))
+	}
+	request := &lifecycle.DoctorRequest{
+		StateFilenames:    util.SplitPaths(stateManifestExplicit),
+		ComponentsBaseDir: componentsBaseDir,
+		EnabledClouds:     clouds,
+		Extensions:        knownExtensions,
+	}
+	if len(args) > 0 {
+		request.ManifestFilenames = util.SplitPaths(args[0])
+	}
+
+	config.AggWarnings = false
+	checks := lifecycle.Doctor(request)
+	lifecycle.PrintDoctorReport(checks, jsonFormat)
+	if lifecycle.DoctorFailed(checks) {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&stateManifestExplicit, "state", "s", "",
+		"Path to state file(s) to check access to, for example hub.yaml.state,s3://bucket/hub.yaml.state")
+	doctorCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
+		"Path to component sources base directory (default to manifest dir)")
+	doctorCmd.Flags().StringVarP(&enabledClouds, "clouds", "", "",
+		"A list of enabled clouds: \"aws,azure,gcp\" (default to autodetect from environment)")
+	doctorCmd.Flags().BoolVarP(&jsonFormat, "json", "j", false,
+		"JSON output")
+	RootCmd.AddCommand(doctorCmd)
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"errors"
 	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/agilestacks/hub/cmd/hub/compose"
+	"github.com/agilestacks/hub/cmd/hub/lifecycle"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -14,6 +16,9 @@ var (
 	elaborateOutput                  string
 	elaboratePlatformProvides        string
 	elaborateUseStateStackParameters bool
+	elaborateCheckTemplates          bool
+	elaborateInferOrder              bool
+	elaborateWriteOrder              bool
 )
 
 var elaborateCmd = &cobra.Command{
@@ -21,7 +26,12 @@ var elaborateCmd = &cobra.Command{
 	Short: "Assemble hub.yaml.elaborate",
 	Long: `Assemble a complete Stack or Application deployment manifest by joining stack and components manifests.
 Parameters are injected from parameters manifest(s) and optionally are read from state file.
-The resulted hub.yaml.elaborate can be used with deploy command.`,
+The resulted hub.yaml.elaborate can be used with deploy command.
+
+With --infer-order, lifecycle order and components depends are inferred: providers go
+before components that require them, components referred as ${component:output} go
+before (and are added to depends of) the referring components. Dependency cycles are
+reported with full path. Existing order is preserved where possible.`,
 	Annotations: map[string]string{
 		"usage-metering": "tags",
 	},
@@ -48,7 +58,15 @@ func elaborate(args []string, pipe io.WriteCloser) error {
 	stateManifests := util.SplitPaths(stateManifestExplicit)
 	compose.Elaborate(manifest, parameters, environmentOverrides, elaboratePlatformProvides,
 		stateManifests, elaborateUseStateStackParameters, elaborateManifests, componentsBaseDir,
-		pipe)
+		elaborateInferOrder || elaborateWriteOrder, elaborateWriteOrder, pipe)
+
+	if elaborateCheckTemplates {
+		if pipe != nil {
+			util.Warn("Templates are not checked when elaborate output is piped")
+		} else if lifecycle.CheckTemplates(elaborateManifests, componentsBaseDir, nil) > 0 {
+			os.Exit(1)
+		}
+	}
 
 	return nil
 }
@@ -60,11 +78,19 @@ func init() {
 		"Set Platform stack provides: -p tiller,etcd,...")
 	elaborateCmd.Flags().StringVarP(&elaborateOutput, "output", "o", "hub.yaml.elaborate",
 		"Set output filename")
-	elaborateCmd.Flags().StringVarP(&componentsBaseDir, "baseDir", "b", "",
+	elaborateCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
 		"Path to component sources base directory (default to manifest dir)")
+	elaborateCmd.Flags().StringVarP(&componentsBaseDir, "baseDir", "", "", "")
+	elaborateCmd.Flags().MarkDeprecated("baseDir", "use --base-dir")
 	elaborateCmd.Flags().StringVarP(&stateManifestExplicit, "state", "s", "",
 		"Path to state file(s) to load Platform stack outputs as input parameters, for example hub.yaml.state,s3://bucket/hub.yaml.state")
 	elaborateCmd.Flags().BoolVarP(&elaborateUseStateStackParameters, "state-stack-parameters", "", true,
 		"Also use stack parameters (from state) to load input parameters, otherwise only stack outputs are used")
+	elaborateCmd.Flags().BoolVarP(&elaborateCheckTemplates, "check-templates", "", false,
+		"Statically check components templates against the elaborated manifest (see render --check)")
+	elaborateCmd.Flags().BoolVarP(&elaborateInferOrder, "infer-order", "", false,
+		"Infer lifecycle order and components depends from requires / provides, ${component:output} references, and explicit depends")
+	elaborateCmd.Flags().BoolVarP(&elaborateWriteOrder, "write-order", "", false,
+		"Infer lifecycle order and also rewrite source hub.yaml with inferred order and depends")
 	RootCmd.AddCommand(elaborateCmd)
 }
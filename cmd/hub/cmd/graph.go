@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/compose"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var (
+	graphOutput    string
+	graphInMermaid bool
+	graphInJson    bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph hub.yaml.elaborate [-s hub.yaml.state] [--mermaid | --json] [-o output]",
+	Short: "Export stack dependency graph",
+	Long: `Export stack components dependency graph as Graphviz DOT (default), Mermaid, or JSON.
+Edges are components depends, requires to provides (including platform provides), and
+parameters referring to other component outputs via ${component:output}.
+When state is given, components are colored by deployment status.
+
+	hub graph hub.yaml.elaborate -s hub.yaml.state | dot -Tsvg > stack.svg`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return graph(args)
+	},
+}
+
+func graph(args []string) error {
+	if len(args) != 1 {
+		return errors.New("Graph command has one argument - path to Stack Elaborate file")
+	}
+
+	format := "dot"
+	if graphInMermaid {
+		format = "mermaid"
+	} else if graphInJson {
+		format = "json"
+	}
+
+	compose.Graph(util.SplitPaths(args[0]), util.SplitPaths(stateManifestExplicit), format, graphOutput)
+
+	return nil
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&stateManifestExplicit, "state", "s", "",
+		"Path to state file(s) to color components by status")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "",
+		"Set output filename (default to stdout)")
+	graphCmd.Flags().BoolVarP(&graphInMermaid, "mermaid", "", false,
+		"Mermaid output")
+	graphCmd.Flags().BoolVarP(&graphInJson, "json", "", false,
+		"JSON output")
+	RootCmd.AddCommand(graphCmd)
+}
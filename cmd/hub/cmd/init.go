@@ -8,6 +8,11 @@ import (
 	"github.com/agilestacks/hub/cmd/hub/initialize"
 )
 
+var (
+	scaffoldRequest    initialize.ScaffoldRequest
+	scaffoldArchetypes bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init <stack | component> [-f] [dir]",
 	Short: "Init stack or component manifest",
@@ -24,10 +29,23 @@ var initStackCmd = &cobra.Command{
 }
 
 var initComponentCmd = &cobra.Command{
-	Use:   "component [dir]",
-	Short: "Init component manifest",
-	Long:  `Create component manifest with initial values provided.`,
+	Use:   "component [dir] [-a archetype] [--name name] [-p 'param=value,...'] [--outputs 'output,...']",
+	Short: "Init component manifest or generate component from archetype",
+	Long: `Create component manifest with initial values provided.
+
+With --archetype, generate a working component with deploy and undeploy implementation,
+templates, and sample outputs. Built-in archetypes match implementations Hub CLI can probe:
+shell, makefile, helm, kustomize, terraform, skaffold (see --list). Archetype could also be
+a directory or a Git URL with optional #subdir; archetype files are Go templates with [[ ]]
+delimiters rendered with .Name, .Brief, .Parameters (.Name, .Env, .Var, .Value), and
+.Outputs (.Name, .Var, .Brief, .Value).
+
+Name, brief, parameters, and outputs are prompted for when not set and stdin is a terminal.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if scaffoldArchetypes {
+			initialize.PrintArchetypes()
+			return nil
+		}
 		return initializeDir(args, false)
 	},
 }
@@ -44,6 +62,8 @@ func initializeDir(args []string, stack bool) error {
 
 	if stack {
 		initialize.InitStack(dir)
+	} else if scaffoldRequest.Archetype != "" {
+		initialize.ScaffoldComponent(dir, scaffoldRequest)
 	} else {
 		initialize.InitComponent(dir)
 	}
@@ -52,6 +72,18 @@ func initializeDir(args []string, stack bool) error {
 }
 
 func init() {
+	initComponentCmd.Flags().StringVarP(&scaffoldRequest.Archetype, "archetype", "a", "",
+		"Generate component from archetype: built-in name, directory, or Git URL")
+	initComponentCmd.Flags().BoolVarP(&scaffoldArchetypes, "list", "l", false,
+		"List built-in archetypes")
+	initComponentCmd.Flags().StringVarP(&scaffoldRequest.Name, "name", "", "",
+		"Component name (default to directory name)")
+	initComponentCmd.Flags().StringVarP(&scaffoldRequest.Brief, "brief", "", "",
+		"Component brief description")
+	initComponentCmd.Flags().StringVarP(&scaffoldRequest.Parameters, "parameters", "p", "",
+		"Component parameters: -p 'dns.domain,component.app.replicas=1'")
+	initComponentCmd.Flags().StringVarP(&scaffoldRequest.Outputs, "outputs", "", "",
+		"Component outputs: --outputs 'endpoint=http://app,component.app.token'")
 	initCmd.AddCommand(initStackCmd)
 	initCmd.AddCommand(initComponentCmd)
 	RootCmd.AddCommand(initCmd)
@@ -1,15 +1,23 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/agilestacks/hub/cmd/hub/api"
+	"github.com/agilestacks/hub/cmd/hub/lifecycle"
+	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
 var (
 	logsExitOnCompletedOperation bool
+	logsLocal                    bool
+	logsList                     bool
+	logsShowSecrets              bool
+	logsDir                      string
+	logsState                    string
 )
 
 var logsCmd = &cobra.Command{
@@ -34,6 +42,50 @@ completion on any entity.
 	},
 }
 
+var stackLogsCmd = &cobra.Command{
+	Use:   "logs [operation-id] [component]",
+	Short: "Show lifecycle operation logs",
+	Long: `Show component log files written by deploy, undeploy, and backup.
+
+With --local the logs are read from log directory (--log-dir, $HUB_LOG_DIR, or location
+recorded in state file, default .hub/logs). Operation defaults to the latest one; an unique
+prefix of operation Id is accepted. Component limits the output to component's logs.
+
+Values of secret parameters and outputs found in state file, and values assigned to
+secret-looking names (password, token, key, etc.) are redacted unless --show-secrets is set.
+
+Without --local the command tails SuperHub logs, see hub api logs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if logsLocal {
+			return localLogs(args)
+		}
+		return logs(args)
+	},
+}
+
+func localLogs(args []string) error {
+	if len(args) > 2 {
+		return errors.New("Logs --local command has two optional arguments - operation Id and component name")
+	}
+	if logsList && len(args) > 0 {
+		return errors.New("Logs --local --list command has no arguments")
+	}
+	request := &lifecycle.LogsRequest{
+		LogDir:         logsDir,
+		StateFilenames: util.SplitPaths(logsState),
+		List:           logsList,
+		ShowSecrets:    logsShowSecrets,
+	}
+	if len(args) > 0 {
+		request.OperationId = args[0]
+	}
+	if len(args) > 1 {
+		request.Component = args[1]
+	}
+	lifecycle.ShowLogs(request)
+	return nil
+}
+
 func logs(args []string) error {
 	selectors := args
 	os.Exit(api.Logs(selectors, logsExitOnCompletedOperation))
@@ -45,4 +97,18 @@ func init() {
 	logsCmd.Flags().BoolVarP(&logsExitOnCompletedOperation, "exit-on-completed-operation", "w", false,
 		"Exit after current lifecycle operation completes (with success or failure)")
 	apiCmd.AddCommand(logsCmd)
+
+	stackLogsCmd.Flags().BoolVarP(&logsLocal, "local", "", false,
+		"Show local log files instead of SuperHub logs")
+	stackLogsCmd.Flags().BoolVarP(&logsList, "list", "", false,
+		"List operations that have logs (with --local)")
+	stackLogsCmd.Flags().StringVarP(&logsDir, "log-dir", "", "",
+		"Log files directory (with --local)")
+	stackLogsCmd.Flags().StringVarP(&logsState, "state", "s", "hub.yaml.state",
+		"Path to state file(s) to find operations and secrets to redact (with --local)")
+	stackLogsCmd.Flags().BoolVarP(&logsShowSecrets, "show-secrets", "", false,
+		"Do not redact secrets (with --local)")
+	stackLogsCmd.Flags().BoolVarP(&logsExitOnCompletedOperation, "exit-on-completed-operation", "w", false,
+		"Exit after current lifecycle operation completes (without --local)")
+	RootCmd.AddCommand(stackLogsCmd)
 }
@@ -7,6 +7,7 @@ import (
 )
 
 func maybeMeterCommand(cmd *cobra.Command) {
+	metrics.CommandUsage(cmd)
 	for cmd2 := cmd; cmd2 != nil; cmd2 = cmd2.Parent() {
 		if ann := cmd.Annotations; ann != nil {
 			if metering, exist := ann["usage-metering"]; exist {
@@ -36,8 +36,10 @@ func pull(args []string) error {
 }
 
 func init() {
-	pullCmd.Flags().StringVarP(&componentsBaseDir, "baseDir", "b", "",
+	pullCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
 		"Path to base directory to clone sources into (default to manifest dir)")
+	pullCmd.Flags().StringVarP(&componentsBaseDir, "baseDir", "", "", "")
+	pullCmd.Flags().MarkDeprecated("baseDir", "use --base-dir")
 	pullCmd.Flags().BoolVarP(&optimizeGitRemotes, "optimize-git-remotes", "", true,
 		"Optimize Git remote with local clone (same remote repository is encountered more than once)")
 	pullCmd.Flags().BoolVarP(&reset, "reset", "r", false,
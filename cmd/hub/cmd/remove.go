@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/compose"
+)
+
+var removeManifest string
+
+var removeCmd = &cobra.Command{
+	Use:   "remove component <name> [-m hub.yaml]",
+	Short: "Remove component from stack manifest",
+}
+
+var removeComponentCmd = &cobra.Command{
+	Use:   "component <name> [-m hub.yaml]",
+	Short: "Remove component from stack manifest",
+	Long: `Remove component from stack manifest: component reference, lifecycle order, depends
+of other components, and stack parameters added with the component. Component sources
+are left on disk.
+
+Stack manifest YAML comments and flow style are preserved, indentation is normalized;
+use --dry to review the change first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeComponent(args)
+	},
+}
+
+func removeComponent(args []string) error {
+	if len(args) != 1 {
+		return errors.New("Remove component command has one argument - component name")
+	}
+
+	compose.RemoveComponent(removeManifest, args[0], componentsBaseDir, dryRun)
+
+	return nil
+}
+
+func init() {
+	removeComponentCmd.Flags().StringVarP(&removeManifest, "manifest", "m", "hub.yaml",
+		"Path to stack manifest file")
+	removeComponentCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
+		"Path to component sources base directory (default to manifest dir)")
+	removeComponentCmd.Flags().BoolVarP(&dryRun, "dry", "y", false,
+		"Print stack manifest diff instead of writing the file")
+	removeCmd.AddCommand(removeComponentCmd)
+	RootCmd.AddCommand(removeCmd)
+}
@@ -16,18 +16,44 @@ import (
 var (
 	templateKind         string
 	additionalParameters string
+	renderCheck          bool
+	renderDiff           bool
+	renderScratchDir     string
 )
 
 var renderCmd = &cobra.Command{
-	Use:   "render <template glob> ... [-a 'additional.parameter1=value,...']",
+	Use:   "render <template glob> ... [-a 'additional.parameter1=value,...'] | render --check | --diff [-c component]",
 	Short: "Render component templates",
-	Long:  `Render component templates with additional parameters during lifecycle operation.`,
+	Long: `Render component templates with additional parameters during lifecycle operation.
+
+Templates of _mustache and go kind share partials from templates.partials directories:
+{{> file}} in _mustache, {{template "file" .}} or {{include "file" .}} in go. Curly and mustache
+kinds have no partials. During deploy, outputs of components listed in depends are bound next to
+parameters; go templates see outputs of json, yaml, and base64 kind decoded.
+
+With --check, templates selected by each component's templates: setup are statically checked
+against component parameters and outputs of components it depends on: unknown substitutions,
+unsupported encodings, Go template fields, and CEL conditions are reported with file:line
+positions. No output files are written. Exit code is 1 if problems are found.
+
+With --diff, templates of each (or -c) component are rendered with current parameters and state outputs
+into a scratch directory, then compared to a copy of files rendered by the last successful deploy
+(kept under .hub/rendered/<operation id>/ for the last 5 operations of each component, see deploy --rendered-dir).
+Unified diff is printed. Rendered templates may contain secrets; the cache directory is git-ignored.
+
+With --scratch-dir, templates are rendered into <dir>/<component>/ instead of in place.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return render(args)
 	},
 }
 
 func render(args []string) error {
+	if renderCheck {
+		return renderCheckTemplates(args)
+	}
+	if renderDiff {
+		return renderDiffTemplates(args)
+	}
 	if len(args) == 0 {
 		return errors.New("Render command has one or more arguments - templates globs/paths")
 	}
@@ -58,8 +84,57 @@ func render(args []string) error {
 	config.AggWarnings = false
 
 	lifecycle.Render(manifests, stateManifests, componentName,
-		templateKind, additionalParameters, args)
+		templateKind, additionalParameters, args, renderScratchDir)
+
+	return nil
+}
+
+func renderCheckTemplates(args []string) error {
+	if len(args) > 0 {
+		return errors.New("Render --check has no arguments - templates are selected by components manifests")
+	}
+	if elaborateManifest == "" {
+		elaborateManifest = os.Getenv(envVarNameElaborate)
+		if elaborateManifest == "" {
+			return fmt.Errorf("Specify -m or set %s environment variable to hub.yaml.elaborate filename(s)", envVarNameElaborate)
+		}
+	}
+	if componentsBaseDir == "" {
+		componentsBaseDir = os.Getenv(envVarNameComponentsBaseDir)
+	}
+	components := []string{}
+	if componentName != "" {
+		components = append(components, componentName)
+	}
+
+	if lifecycle.CheckTemplates(util.SplitPaths(elaborateManifest), componentsBaseDir, components) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func renderDiffTemplates(args []string) error {
+	if len(args) > 0 {
+		return errors.New("Render --diff has no arguments - templates are selected by components manifests")
+	}
+	if elaborateManifest == "" {
+		elaborateManifest = os.Getenv(envVarNameElaborate)
+		if elaborateManifest == "" {
+			return fmt.Errorf("Specify -m or set %s environment variable to hub.yaml.elaborate filename(s)", envVarNameElaborate)
+		}
+	}
+	if stateManifestExplicit == "" {
+		stateManifestExplicit = os.Getenv(envVarNameState)
+		if stateManifestExplicit == "" {
+			return fmt.Errorf("Specify -s or set %s environment variable to hub.yaml.state filename(s)", envVarNameState)
+		}
+	}
+	if componentsBaseDir == "" {
+		componentsBaseDir = os.Getenv(envVarNameComponentsBaseDir)
+	}
 
+	lifecycle.RenderDiff(util.SplitPaths(elaborateManifest), util.SplitPaths(stateManifestExplicit),
+		componentsBaseDir, renderedDir, util.SplitPaths(componentName))
 	return nil
 }
 
@@ -74,5 +149,16 @@ func init() {
 		"`curly`, mustache, go")
 	renderCmd.Flags().StringVarP(&additionalParameters, "additional-parameters", "a", "",
 		"Set additional parameters: -a 'component.password=qwerty,...'")
+	renderCmd.Flags().BoolVarP(&renderCheck, "check", "", false,
+		"Statically check components templates instead of rendering")
+	renderCmd.Flags().BoolVarP(&renderDiff, "diff", "", false,
+		"Show diff of templates rendered with current parameters against the last successful deploy")
+	renderCmd.Flags().StringVarP(&renderedDir, "rendered-dir", "", "",
+		"Rendered templates cache directory for --diff (default $HUB_RENDERED_DIR or .hub/rendered next to the manifest); it may contain secrets")
+	renderCmd.Flags().StringVarP(&renderScratchDir, "scratch-dir", "", "",
+		"Render templates into <dir>/<component>/ instead of in place")
+	renderCmd.Flags().StringVarP(&componentsBaseDir, "base-dir", "b", "",
+		fmt.Sprintf("Path to component sources base directory for --check and --diff (default from %s environment variable or manifest dir)",
+			envVarNameComponentsBaseDir))
 	RootCmd.AddCommand(renderCmd)
 }
@@ -14,6 +14,9 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/lifecycle"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -35,6 +38,8 @@ var RootCmd = &cobra.Command{
 	},
 
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		tracing.Flush()
+		metrics.Flush()
 		util.PrintAllWarnings()
 	},
 }
@@ -83,6 +88,17 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&config.Compressed, "compressed", true, "Write gzip compressed files")
 	RootCmd.PersistentFlags().StringVar(&config.EncryptionMode, "encrypted", "if-key-set",
 		"Write encrypted files if HUB_CRYPTO_PASSWORD, HUB_CRYPTO_AWS_KMS_KEY_ARN, HUB_CRYPTO_AZURE_KEYVAULT_KEY_ID is set. true / false")
+
+	otelEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if otelEndpoint == "" {
+		otelEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	RootCmd.PersistentFlags().StringVar(&config.OtelEndpoint, "otel-endpoint", otelEndpoint,
+		"OpenTelemetry OTLP/HTTP endpoint to export trace spans to, OTEL_EXPORTER_OTLP_ENDPOINT")
+	RootCmd.PersistentFlags().StringVar(&config.OtelFile, "otel-file", os.Getenv("HUB_OTEL_FILE"),
+		"File to append OTLP JSON trace spans to, HUB_OTEL_FILE")
+	RootCmd.PersistentFlags().StringVar(&config.MetricsSinks, "metrics-sinks", os.Getenv("HUB_METRICS_SINKS"),
+		"Operational metrics sinks: pushgateway+http://host:9091, statsd://host:8125, file:///path; HUB_METRICS_SINKS")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -135,6 +151,15 @@ func initConfig() {
 			config.ApiTimeout = timeout
 		}
 	}
+	if endpoint := viper.GetString("otel-endpoint"); endpoint != "" && config.OtelEndpoint == "" {
+		config.OtelEndpoint = endpoint
+	}
+	if sinks := viper.GetStringSlice("metrics-sinks"); len(sinks) > 0 && config.MetricsSinks == "" {
+		config.MetricsSinks = strings.Join(sinks, ",")
+	}
+	if err := viper.UnmarshalKey("notifications", &lifecycle.ConfigNotifications); err != nil {
+		util.Warn("Unable to parse `notifications` in %s: %v", viper.ConfigFileUsed(), err)
+	}
 	if tty := viper.GetString("tty"); tty != "" {
 		config.TtyMode = tty
 	}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/lifecycle"
+)
+
+var (
+	testComponentTests   string
+	testComponentJunit   string
+	testComponentWorkDir string
+	testComponentOnly    string
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test component <dir>",
+	Short: "Test component in isolation",
+}
+
+var testComponentCmd = &cobra.Command{
+	Use:   "component <dir> [-t hub-component.test.yaml] [--junit report.xml]",
+	Short: "Test component in isolation",
+	Long: `Test component by deploying it as a synthetic single-component stack.
+
+For each test in hub-component.test.yaml, the stack is elaborated with test parameters,
+then deploy-test (if implemented), deploy, and undeploy steps are run via nested Hub CLI.
+Step exit code, component captured outputs, and rendered templates are checked:
+
+	version: 1
+	kind: test
+	tests:
+	- name: defaults
+	  platformProvides: [kubernetes]
+	  parameters:
+	  - name: dns.domain
+	    value: test.example.com
+	  simulate: true         # see deploy --simulate
+	  fixtures:              # simulation fixtures, implies simulate
+	    components:
+	      app:
+	        outputs:
+	          endpoint: app.test.example.com
+	  steps: [deploy, undeploy]
+	  expect:
+	    deploy:
+	      exitCode: 0
+	      outputs:
+	        endpoint: app.test.example.com
+	      templates:
+	        values.yaml:
+	          contains: ["domain: test.example.com"]
+	          matches: ["replicas: [0-9]+"]
+
+Use --junit to write JUnit XML report for CI. Exit code is 1 if any step failed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return testComponent(args)
+	},
+}
+
+func testComponent(args []string) error {
+	if len(args) != 1 {
+		return errors.New("Test component command has one argument - path to component directory")
+	}
+
+	failures := lifecycle.TestComponent(args[0], testComponentTests, testComponentJunit,
+		testComponentWorkDir, testComponentOnly)
+	if failures > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	testComponentCmd.Flags().StringVarP(&testComponentTests, "tests", "t", "",
+		"Path to component tests file (default to hub-component.test.yaml in component dir)")
+	testComponentCmd.Flags().StringVarP(&testComponentJunit, "junit", "", "",
+		"Write JUnit XML report to the file, '-' for stdout")
+	testComponentCmd.Flags().StringVarP(&testComponentWorkDir, "work-dir", "", "",
+		"Directory to keep test stacks, state, logs, and rendered templates (default to temporary directory that is removed)")
+	testComponentCmd.Flags().StringVarP(&testComponentOnly, "run", "", "",
+		"Run only test with the name")
+	testCmd.AddCommand(testComponentCmd)
+	RootCmd.AddCommand(testCmd)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/toolchain"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var (
+	toolsManifestFile string
+	toolsMirror       string
+	toolsOffline      bool
+	toolsForce        bool
+	toolsSaveToMirror bool
+)
+
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Manage stack toolchain",
+	Long: `Manage pinned tools declared in stack manifest toolchain section:
+
+toolchain:
+  tools:
+  - name: terraform
+    version: 0.12.29
+    url: https://releases.hashicorp.com/terraform/${version}/terraform_${version}_${os}_${arch}.zip
+    checksums:
+      linux_amd64: 872245d9c6302b24dc0d98a1e010aef1e4ef60865a2d1f60102c8ad03e9d5a1d
+
+Tools are installed into per-stack cache (default .hub/toolchain next to the manifest)
+and prepended to component PATH during lifecycle operations.`,
+}
+
+var toolsInstallCmd = &cobra.Command{
+	Use:   "install [tool ...]",
+	Short: "Install stack toolchain",
+	Long: `Download pinned, checksum-verified tool binaries into per-stack cache.
+
+Artifacts are looked up in mirror directory first, as <mirror>/<name>/<version>/<artifact>
+or <mirror>/<artifact>. Mirror is set by --mirror, HUB_TOOLCHAIN_MIRROR, or toolchain.mirror.
+With --offline no download is attempted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return toolsInstall(args)
+	},
+}
+
+var toolsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show stack toolchain status",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return toolsList(args)
+	},
+}
+
+func toolsManifest() (*manifest.Manifest, string) {
+	filenames := util.SplitPaths(toolsManifestFile)
+	stackManifest, _, _, err := manifest.ParseManifest(filenames)
+	if err != nil {
+		log.Fatalf("Unable to parse %s: %v", toolsManifestFile, err)
+	}
+	return stackManifest, util.Basedir(filenames)
+}
+
+func toolsInstall(args []string) error {
+	stackManifest, baseDir := toolsManifest()
+	config.AggWarnings = false
+	request := &toolchain.InstallRequest{
+		Mirror:       toolsMirror,
+		Offline:      toolsOffline,
+		Force:        toolsForce,
+		SaveToMirror: toolsSaveToMirror,
+		Tools:        args,
+	}
+	err := toolchain.Install(stackManifest.Toolchain, baseDir, request)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	return nil
+}
+
+func toolsList(args []string) error {
+	if len(args) > 0 {
+		return errors.New("Tools List command has no arguments")
+	}
+	stackManifest, baseDir := toolsManifest()
+	toolchain.List(stackManifest.Toolchain, baseDir)
+	return nil
+}
+
+func init() {
+	toolsCmd.PersistentFlags().StringVarP(&toolsManifestFile, "manifest", "m", "hub.yaml",
+		"Path to stack manifest with toolchain section, hub.yaml or hub.yaml.elaborate")
+	toolsInstallCmd.Flags().StringVarP(&toolsMirror, "mirror", "", "",
+		"Local mirror directory with pre-downloaded artifacts")
+	toolsInstallCmd.Flags().BoolVarP(&toolsOffline, "offline", "", false,
+		"Do not download, use mirror only")
+	toolsInstallCmd.Flags().BoolVarP(&toolsForce, "reinstall", "", false,
+		"Reinstall tools already present in cache")
+	toolsInstallCmd.Flags().BoolVarP(&toolsSaveToMirror, "save-to-mirror", "", false,
+		"Save downloaded artifacts to mirror directory to populate it for offline use")
+	toolsCmd.AddCommand(toolsInstallCmd)
+	toolsCmd.AddCommand(toolsListCmd)
+	RootCmd.AddCommand(toolsCmd)
+}
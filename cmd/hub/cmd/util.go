@@ -78,6 +78,16 @@ Edit $HOME/.hub-cache.yaml to change settings:
 
 Set 'disabled: true' to skip usage metrics reporting.
 Set 'host: ""' to send the counter but not the UUID.
+
+Operational metrics - command usage, component deploy duration, failures, retries,
+state write latency - are sent to sinks configured by --metrics-sinks, HUB_METRICS_SINKS,
+'metrics-sinks' in $HOME/.hub-config, or 'sinks' list under 'metrics' in $HOME/.hub-cache.yaml:
+
+  metrics:
+    sinks:
+    - pushgateway+http://pushgateway:9091?job=hub
+    - statsd://localhost:8125?prefix=hub
+    - file:///var/log/hub-metrics.prom
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return putMetrics(args, metricTags)
@@ -30,6 +30,14 @@ var (
 	environmentOverrides  string
 	dryRun                bool
 	osEnvironmentMode     string
+	sandboxMode           string
+	logDir                string
+	uploadLogs            bool
+	renderedDir           string
+	simulate              bool
+	simulationFixtures    string
+	eventsFormat          string
+	eventsOutput          string
 	outputFiles           string
 	waitAndTailDeployLogs bool
 	showSecrets           bool
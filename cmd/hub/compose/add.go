@@ -0,0 +1,722 @@
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/git"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+// stackEdit is a stack manifest loaded for in-place modification;
+// first YAML document is edited as a node tree to preserve keys order, comments, and style, the rest is kept verbatim
+type stackEdit struct {
+	filename          string
+	original          string
+	stack             *manifest.Manifest
+	root              *yaml.Node
+	document          *yaml.Node
+	rest              []string
+	stackBaseDir      string
+	componentsBaseDir string
+}
+
+// AddComponent adds component sourced from `git:<remote>[#subDir]` or `dir:<path>` to stack manifest,
+// inserts it into lifecycle order after providers of its requirements, and copies component parameters
+// that have defaults into stack parameters
+func AddComponent(manifestFilename, componentName, source, ref, componentsBaseDir string, dryRun bool) {
+	edit := loadStackEdit(manifestFilename, componentsBaseDir)
+
+	if manifest.ComponentRefByName(edit.stack.Components, componentName) != nil {
+		log.Fatalf("Component `%s` already exist in %s", componentName, manifestFilename)
+	}
+	componentRef := componentRefFromSource(componentName, source, ref)
+	sourceRef := componentRef
+	install, remove := func() {}, func() {}
+	if componentRef.Source.Git.Remote != "" {
+		sourceRef.Source.Git.LocalDir, install, remove = cloneComponentSource(&componentRef, edit.componentsBaseDir)
+	}
+	fatalf := func(format string, v ...interface{}) {
+		remove()
+		log.Fatalf(format, v...)
+	}
+	componentManifest := parseComponentManifest(&sourceRef, edit.stackBaseDir, edit.componentsBaseDir)
+	if componentManifest == nil {
+		fatalf("Unable to add component `%s`", componentName)
+	}
+	others := parseStackComponentsManifests(edit.stack.Components, edit.stackBaseDir, edit.componentsBaseDir)
+
+	components := yamlList(edit.document, "components")
+	components.Content = append(components.Content, componentRefYaml(&componentRef))
+
+	order := addToOrder(componentName, componentManifest, edit.stack, others)
+	lifecycle := yamlMapping(edit.document, "lifecycle")
+	yamlSet(lifecycle, "order", yamlStrings(yamlGet(lifecycle, "order"), order))
+
+	added := make([]string, 0)
+	parameters := yamlList(edit.document, "parameters")
+	stackParameters := manifest.FlattenParameters(edit.stack.Parameters, edit.stack.Meta.Name)
+	for _, parameter := range manifest.FlattenParameters(componentManifest.Parameters, componentName) {
+		if !util.Empty(parameter.Value) || stackParameterExist(stackParameters, parameter.Name, componentName) {
+			continue
+		}
+		if util.Empty(parameter.Default) {
+			if parameter.FromEnv == "" {
+				util.Warn("Component `%s` parameter `%s` has no default; set value in %s",
+					componentName, parameter.Name, manifestFilename)
+			}
+			continue
+		}
+		value, err := yamlValue(parameter.Default)
+		if err != nil {
+			fatalf("Unable to add component `%s` parameter `%s`: %v", componentName, parameter.Name, err)
+		}
+		entry := &yaml.Node{Kind: yaml.MappingNode}
+		yamlSet(entry, "name", yamlScalar(parameter.Name))
+		yamlSet(entry, "value", value)
+		parameters.Content = append(parameters.Content, entry)
+		added = append(added, parameter.Name)
+	}
+	yamlSetOrRemove(edit.document, "parameters", parameters)
+
+	text, err := edit.render()
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if dryRun {
+		remove()
+	} else {
+		install()
+	}
+	edit.write(text, dryRun)
+	if config.Verbose {
+		log.Printf("Added component `%s` to %s; lifecycle order: %s", componentName, manifestFilename,
+			strings.Join(order, ", "))
+		if len(added) > 0 {
+			log.Printf("Added parameters: %s", strings.Join(added, ", "))
+		}
+	}
+}
+
+// RemoveComponent removes component from stack manifest: component reference, lifecycle order,
+// depends of other components, and stack parameters that are still at component defaults and not used
+// by remaining components
+func RemoveComponent(manifestFilename, componentName, componentsBaseDir string, dryRun bool) {
+	edit := loadStackEdit(manifestFilename, componentsBaseDir)
+
+	manifest.CheckComponentsExist(edit.stack.Components, componentName)
+	componentRef := manifest.ComponentRefByName(edit.stack.Components, componentName)
+	componentManifest := parseComponentManifest(componentRef, edit.stackBaseDir, edit.componentsBaseDir)
+
+	remaining := make([]manifest.ComponentRef, 0, len(edit.stack.Components))
+	for _, component := range edit.stack.Components {
+		if component.Name != componentName {
+			remaining = append(remaining, component)
+		}
+	}
+	others := parseStackComponentsManifests(remaining, edit.stackBaseDir, edit.componentsBaseDir)
+
+	if componentManifest != nil {
+		for _, provide := range componentManifest.Provides {
+			for _, other := range others {
+				if other != nil && util.Contains(manifest.RequirementNames(other.Requires), provide) &&
+					len(providersOf(provide, remaining, others)) == 0 {
+					util.Warn("Component `%s` requires `%s` provided by `%s` only",
+						other.Meta.Name, provide, componentName)
+				}
+			}
+		}
+	}
+
+	components := yamlList(edit.document, "components")
+	kept := make([]*yaml.Node, 0, len(components.Content))
+	for _, ref := range components.Content {
+		name := yamlString(yamlGet(ref, "name"))
+		if name == componentName {
+			continue
+		}
+		if depends := yamlGet(ref, "depends"); depends != nil && depends.Kind == yaml.SequenceNode {
+			if removeFromList(depends, componentName) {
+				util.Warn("Component `%s` depends on `%s`; removed from `depends`", name, componentName)
+				yamlSetOrRemove(ref, "depends", depends)
+			}
+		}
+		kept = append(kept, ref)
+	}
+	components.Content = kept
+	yamlSetOrRemove(edit.document, "components", components)
+
+	if lifecycle := yamlGet(edit.document, "lifecycle"); lifecycle != nil && lifecycle.Kind == yaml.MappingNode {
+		for _, key := range []string{"order", "mandatory", "optional"} {
+			if list := yamlGet(lifecycle, key); list != nil && list.Kind == yaml.SequenceNode {
+				removeFromList(list, componentName)
+				yamlSetOrRemove(lifecycle, key, list)
+			}
+		}
+		yamlSetOrRemove(edit.document, "lifecycle", lifecycle)
+	}
+
+	used := make(map[string]bool)
+	complete := componentManifest != nil
+	for i, other := range others {
+		if other == nil {
+			complete = false
+			if config.Verbose {
+				log.Printf("Component `%s` manifest is not available, keeping unqualified stack parameters",
+					remaining[i].Name)
+			}
+			continue
+		}
+		for _, parameter := range manifest.FlattenParameters(other.Parameters, other.Meta.Name) {
+			used[parameter.Name] = true
+		}
+	}
+	// unqualified parameter is removed when it is still as added: component default value
+	defaults := make(map[string]interface{})
+	if componentManifest != nil {
+		for _, parameter := range manifest.FlattenParameters(componentManifest.Parameters, componentName) {
+			if util.Empty(parameter.Value) && !util.Empty(parameter.Default) {
+				defaults[parameter.Name] = parameter.Default
+			}
+		}
+	}
+	removed := make([]string, 0)
+	if parameters := yamlGet(edit.document, "parameters"); parameters != nil && parameters.Kind == yaml.SequenceNode {
+		removeStackParameters(parameters, "", "", func(name, component string, value interface{}) bool {
+			drop := component == componentName
+			if !drop && component == "" && complete && !used[name] {
+				if defaultValue, exist := defaults[name]; exist {
+					drop = sameYaml(value, defaultValue)
+				}
+			}
+			if drop {
+				removed = append(removed, name)
+			}
+			return drop
+		})
+		yamlSetOrRemove(edit.document, "parameters", parameters)
+	}
+
+	edit.save(dryRun)
+	if config.Verbose {
+		log.Printf("Removed component `%s` from %s", componentName, manifestFilename)
+		if len(removed) > 0 {
+			log.Printf("Removed parameters: %s", strings.Join(removed, ", "))
+		}
+	}
+	if componentRef.Source.Git.Remote != "" {
+		log.Printf("Component `%s` sources in %s are not removed", componentName,
+			manifest.ComponentSourceDirFromRef(componentRef, edit.stackBaseDir, edit.componentsBaseDir))
+	}
+}
+
+func loadStackEdit(manifestFilename, componentsBaseDir string) *stackEdit {
+	bytes, err := ioutil.ReadFile(manifestFilename)
+	if err != nil {
+		log.Fatalf("Unable to read %s: %v", manifestFilename, err)
+	}
+	documents := strings.Split(string(bytes), "\n---\n")
+	var stack manifest.Manifest
+	var root yaml.Node
+	if err := yamlv2.Unmarshal([]byte(documents[0]), &stack); err != nil {
+		log.Fatalf("Unable to parse %s: %v", manifestFilename, err)
+	}
+	if err := yaml.Unmarshal([]byte(documents[0]), &root); err != nil {
+		log.Fatalf("Unable to parse %s: %v", manifestFilename, err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		log.Fatalf("%s is not a stack manifest", manifestFilename)
+	}
+	if stack.Kind != "stack" && stack.Kind != "app" {
+		log.Fatalf("%s is not a stack manifest (kind: %s)", manifestFilename, stack.Kind)
+	}
+	stackBaseDir := util.StripDotDirs(filepath.Dir(manifestFilename))
+	if componentsBaseDir == "" {
+		componentsBaseDir = stackBaseDir
+	}
+	return &stackEdit{
+		filename:          manifestFilename,
+		original:          string(bytes),
+		stack:             &stack,
+		root:              &root,
+		document:          root.Content[0],
+		rest:              documents[1:],
+		stackBaseDir:      stackBaseDir,
+		componentsBaseDir: componentsBaseDir,
+	}
+}
+
+// save validates modified manifest with elaborate checks, then writes it back or prints the diff on dry run
+func (edit *stackEdit) save(dryRun bool) {
+	text, err := edit.render()
+	if err != nil {
+		log.Fatal(err)
+	}
+	edit.write(text, dryRun)
+}
+
+// render marshals edited manifest and checks it is still valid
+func (edit *stackEdit) render() (string, error) {
+	var out strings.Builder
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	err := encoder.Encode(edit.root)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return "", fmt.Errorf("Unable to marshal %s: %v", edit.filename, err)
+	}
+	text := restoreBlankLines(strings.Split(edit.original, "\n---\n")[0], out.String())
+	if strings.HasPrefix(edit.original, "---\n") {
+		text = "---\n" + text
+	}
+	if len(edit.rest) > 0 {
+		text = strings.TrimSuffix(text, "\n") + "\n---\n" + strings.Join(edit.rest, "\n---\n")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(edit.filename), ".hub-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("Unable to create temporary file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(text)
+	if err == nil {
+		err = tmp.Close()
+	}
+	if err != nil {
+		return "", fmt.Errorf("Unable to write %s: %v", tmp.Name(), err)
+	}
+	stack, _, _, err := manifest.ParseManifest([]string{tmp.Name()})
+	if err != nil {
+		return "", fmt.Errorf("Modified %s is not valid: %v", edit.filename, err)
+	}
+	checkComponentsNames(stack.Components)
+	checkLifecycle(stack.Components, stack.Lifecycle)
+	return text, nil
+}
+
+func (edit *stackEdit) write(text string, dryRun bool) {
+	if dryRun {
+		fmt.Print(util.UnifiedDiff(edit.filename, edit.filename, edit.original, text, 3))
+		return
+	}
+	if config.Verbose {
+		log.Printf("Writing %s", edit.filename)
+	}
+	mode := os.FileMode(0664)
+	if info, err := os.Stat(edit.filename); err == nil {
+		mode = info.Mode()
+	}
+	if err := ioutil.WriteFile(edit.filename, []byte(text), mode); err != nil {
+		log.Fatalf("Unable to write %s: %v", edit.filename, err)
+	}
+}
+
+// restoreBlankLines puts back blank lines separating top-level keys, as YAML encoder does not keep them
+func restoreBlankLines(original, text string) string {
+	topLevelKey := func(line string) string {
+		if line == "" || strings.ContainsAny(line[:1], " \t#-") {
+			return ""
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			return line[:i]
+		}
+		return ""
+	}
+	separated := make(map[string]bool)
+	blank := false
+	for _, line := range strings.Split(original, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			blank = true
+		case strings.HasPrefix(line, "#"):
+			// head comment goes with the key below
+		default:
+			if key := topLevelKey(line); key != "" && blank {
+				separated[key] = true
+			}
+			blank = false
+		}
+	}
+	lines := strings.Split(text, "\n")
+	restored := make([]string, 0, len(lines)+len(separated))
+	comments := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			comments++
+		} else {
+			if key := topLevelKey(line); separated[key] && len(restored) > comments {
+				at := len(restored) - comments
+				restored = append(restored[:at], append([]string{""}, restored[at:]...)...)
+			}
+			comments = 0
+		}
+		restored = append(restored, line)
+	}
+	return strings.Join(restored, "\n")
+}
+
+func componentRefFromSource(componentName, source, ref string) manifest.ComponentRef {
+	componentRef := manifest.ComponentRef{Name: componentName}
+	switch {
+	case strings.HasPrefix(source, "dir:"):
+		componentRef.Source.Dir = strings.TrimPrefix(source, "dir:")
+		if ref != "" {
+			util.Warn("Git ref `%s` is ignored for `dir:` source", ref)
+		}
+	case strings.HasPrefix(source, "git:"):
+		remote, subDir := strings.TrimPrefix(source, "git:"), ""
+		if i := strings.LastIndex(remote, "#"); i > 0 {
+			remote, subDir = remote[:i], remote[i+1:]
+		}
+		componentRef.Source.Git = manifest.Git{Remote: remote, Ref: ref, SubDir: subDir}
+	default:
+		log.Fatalf("Component source must be `git:<remote>[#subDir]` or `dir:<path>`; got `%s`", source)
+	}
+	if componentRef.Source.Dir == "" && componentRef.Source.Git.Remote == "" {
+		log.Fatalf("Component `%s` source location is empty", componentName)
+	}
+	return componentRef
+}
+
+// cloneComponentSource clones component Git repository into a temporary directory, so that nothing is left
+// in components base dir on --dry run or when component cannot be added; returns clone directory, install
+// func that moves the clone to the directory `hub deploy` expects the sources to be, and remove func.
+// Existing sources directory is used as is.
+func cloneComponentSource(componentRef *manifest.ComponentRef, componentsBaseDir string) (string, func(), func()) {
+	dir := filepath.Join(componentsBaseDir, manifest.ComponentSourceDirNameFromRef(componentRef))
+	if _, err := os.Stat(dir); err == nil {
+		if config.Verbose {
+			log.Printf("Using existing `%s` component sources in %s", componentRef.Name, dir)
+		}
+		return util.MustAbs(dir), func() {}, func() {}
+	}
+	tmp, err := ioutil.TempDir("", "hub-add-")
+	if err != nil {
+		log.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	remove := func() { os.RemoveAll(tmp) }
+	clone := filepath.Join(tmp, "source")
+	if err := gitClone(componentRef.Source.Git, clone); err != nil {
+		remove()
+		log.Fatalf("Unable to git clone %s: %v", componentRef.Source.Git.Remote, err)
+	}
+	install := func() {
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			remove()
+			log.Fatalf("Unable to create %s: %v", filepath.Dir(dir), err)
+		}
+		if err := os.Rename(clone, dir); err != nil {
+			// temporary directory is on another filesystem
+			if err := gitClone(componentRef.Source.Git, dir); err != nil {
+				remove()
+				log.Fatalf("Unable to git clone %s: %v", componentRef.Source.Git.Remote, err)
+			}
+		}
+		remove()
+	}
+	return clone, install, remove
+}
+
+func gitClone(source manifest.Git, dir string) error {
+	args := []string{"git", "clone", "--quiet"}
+	if source.Ref != "" {
+		args = append(args, "--branch", source.Ref)
+	}
+	args = append(args, source.Remote, dir)
+	cmd := exec.Cmd{
+		Path:   git.GitBinPath(),
+		Args:   args,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	if config.Debug {
+		log.Printf("Cloning component sources: %v", cmd.Args)
+	}
+	return cmd.Run()
+}
+
+func parseComponentManifest(componentRef *manifest.ComponentRef, stackBaseDir, componentsBaseDir string) *manifest.Manifest {
+	manifests, err := manifest.ParseComponentsManifests([]manifest.ComponentRef{*componentRef},
+		stackBaseDir, componentsBaseDir)
+	if err != nil {
+		util.Warn("%v", err)
+		return nil
+	}
+	return &manifests[0]
+}
+
+// parseStackComponentsManifests returns components manifests in components order, nil if manifest is not available
+func parseStackComponentsManifests(components []manifest.ComponentRef, stackBaseDir, componentsBaseDir string) []*manifest.Manifest {
+	manifests := make([]*manifest.Manifest, 0, len(components))
+	for i := range components {
+		manifests = append(manifests, parseComponentManifest(&components[i], stackBaseDir, componentsBaseDir))
+	}
+	return manifests
+}
+
+// providersOf returns components providing requirement, version constraint is not checked
+func providersOf(requirement string, components []manifest.ComponentRef, manifests []*manifest.Manifest) []string {
+	name := manifest.RequirementName(requirement)
+	providers := make([]string, 0)
+	for i, componentManifest := range manifests {
+		if componentManifest != nil && util.Contains(componentManifest.Provides, name) {
+			providers = append(providers, components[i].Name)
+		}
+	}
+	return providers
+}
+
+// addToOrder places component after the last provider of its requirements and before the first
+// component that requires something it provides; otherwise component goes last
+func addToOrder(componentName string, componentManifest *manifest.Manifest,
+	stack *manifest.Manifest, others []*manifest.Manifest) []string {
+
+	order := stack.Lifecycle.Order
+	after := 0
+	for _, requirement := range componentManifest.Requires {
+		providers := providersOf(requirement, stack.Components, others)
+		if len(providers) == 0 {
+			name := manifest.RequirementName(requirement)
+			if !util.Contains(manifest.RequirementNames(stack.Requires), name) &&
+				!util.Contains(stack.Platform.Provides, name) &&
+				!util.Contains(requirementProvidedByEnvironment, name) {
+				util.Warn("Component `%s` requires `%s` but no component provides it, nor stack `requires` it",
+					componentName, requirement)
+			}
+			continue
+		}
+		for _, provider := range providers {
+			if i := util.Index(order, provider); i+1 > after {
+				after = i + 1
+			}
+		}
+	}
+	before := len(order)
+	for k, other := range stack.Components {
+		if others[k] != nil && util.ContainsAny(manifest.RequirementNames(others[k].Requires), componentManifest.Provides) {
+			if i := util.Index(order, other.Name); i >= 0 && i < before {
+				before = i
+			}
+		}
+	}
+	position := before
+	if position < after {
+		util.Warn("Component `%s` provides for components ordered before its requirements providers; placed after providers",
+			componentName)
+		position = after
+	}
+	updated := make([]string, 0, len(order)+1)
+	updated = append(updated, order[:position]...)
+	updated = append(updated, componentName)
+	return append(updated, order[position:]...)
+}
+
+func stackParameterExist(parameters []manifest.Parameter, name, componentName string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name && (parameter.Component == "" || parameter.Component == componentName) {
+			return true
+		}
+	}
+	return false
+}
+
+func componentRefYaml(componentRef *manifest.ComponentRef) *yaml.Node {
+	source := &yaml.Node{Kind: yaml.MappingNode}
+	if componentRef.Source.Dir != "" {
+		yamlSet(source, "dir", yamlScalar(componentRef.Source.Dir))
+	} else {
+		g := componentRef.Source.Git
+		gitSource := &yaml.Node{Kind: yaml.MappingNode}
+		yamlSet(gitSource, "remote", yamlScalar(g.Remote))
+		if g.Ref != "" {
+			yamlSet(gitSource, "ref", yamlScalar(g.Ref))
+		}
+		if g.SubDir != "" {
+			yamlSet(gitSource, "subDir", yamlScalar(g.SubDir))
+		}
+		yamlSet(source, "git", gitSource)
+	}
+	ref := &yaml.Node{Kind: yaml.MappingNode}
+	yamlSet(ref, "name", yamlScalar(componentRef.Name))
+	yamlSet(ref, "source", source)
+	return ref
+}
+
+// removeStackParameters walks (nested) stack parameters and drops leafs matched by `drop`,
+// parameters left without nested parameters are dropped too
+func removeStackParameters(parameters *yaml.Node, prefix, component string,
+	drop func(name, component string, value interface{}) bool) {
+
+	kept := make([]*yaml.Node, 0, len(parameters.Content))
+	for _, parameter := range parameters.Content {
+		if parameter.Kind != yaml.MappingNode {
+			kept = append(kept, parameter)
+			continue
+		}
+		qualified := prefix + yamlString(yamlGet(parameter, "name"))
+		parameterComponent := component
+		if c := yamlGet(parameter, "component"); c != nil {
+			parameterComponent = yamlString(c)
+		}
+		if nested := yamlGet(parameter, "parameters"); nested != nil && nested.Kind == yaml.SequenceNode {
+			removeStackParameters(nested, qualified+".", parameterComponent, drop)
+			if len(nested.Content) > 0 {
+				kept = append(kept, parameter)
+			}
+			continue
+		}
+		var value interface{}
+		if v := yamlGet(parameter, "value"); v != nil {
+			if err := v.Decode(&value); err != nil {
+				kept = append(kept, parameter)
+				continue
+			}
+		}
+		if drop(qualified, parameterComponent, value) {
+			continue
+		}
+		kept = append(kept, parameter)
+	}
+	parameters.Content = kept
+}
+
+func sameYaml(a, b interface{}) bool {
+	aBytes, errA := yamlv2.Marshal(a)
+	bBytes, errB := yamlv2.Marshal(b)
+	return errA == nil && errB == nil && string(aBytes) == string(bBytes)
+}
+
+// removeFromList removes scalar value from sequence node, returns true if it was found
+func removeFromList(list *yaml.Node, value string) bool {
+	kept := make([]*yaml.Node, 0, len(list.Content))
+	for _, item := range list.Content {
+		if item.Kind != yaml.ScalarNode || item.Value != value {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(kept) != len(list.Content)
+	list.Content = kept
+	return removed
+}
+
+func yamlScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// yamlValue converts value into node tree
+func yamlValue(value interface{}) (*yaml.Node, error) {
+	var node yaml.Node
+	err := node.Encode(value)
+	return &node, err
+}
+
+func yamlString(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// yamlStrings makes a sequence of strings reusing items of existing sequence, if any, to keep their comments
+func yamlStrings(existing *yaml.Node, values []string) *yaml.Node {
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, value := range values {
+		var item *yaml.Node
+		if existing != nil {
+			for _, node := range existing.Content {
+				if node.Kind == yaml.ScalarNode && node.Value == value {
+					item = node
+					break
+				}
+			}
+		}
+		if item == nil {
+			item = yamlScalar(value)
+		}
+		list.Content = append(list.Content, item)
+	}
+	return list
+}
+
+// yamlList returns sequence under key, creating an empty one if key is not present
+func yamlList(mapping *yaml.Node, key string) *yaml.Node {
+	if list := yamlGet(mapping, key); list != nil && list.Kind == yaml.SequenceNode {
+		return list
+	}
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	yamlSet(mapping, key, list)
+	return list
+}
+
+// yamlMapping returns mapping under key, creating an empty one if key is not present
+func yamlMapping(mapping *yaml.Node, key string) *yaml.Node {
+	if dict := yamlGet(mapping, key); dict != nil && dict.Kind == yaml.MappingNode {
+		return dict
+	}
+	dict := &yaml.Node{Kind: yaml.MappingNode}
+	yamlSet(mapping, key, dict)
+	return dict
+}
+
+// yamlSet sets key to value in mapping, appending key if not present;
+// comments and flow / block style of the replaced value are kept
+func yamlSet(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			old := mapping.Content[i+1]
+			if old != value {
+				value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+				if old.Kind == value.Kind {
+					value.Style = old.Style
+				}
+				mapping.Content[i+1] = value
+			}
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, yamlScalar(key), value)
+}
+
+// yamlSetOrRemove sets key to value, or removes key if value is an empty sequence or mapping
+func yamlSetOrRemove(mapping *yaml.Node, key string, value *yaml.Node) {
+	if (value.Kind == yaml.SequenceNode || value.Kind == yaml.MappingNode) && len(value.Content) == 0 {
+		yamlRemove(mapping, key)
+		return
+	}
+	yamlSet(mapping, key, value)
+}
+
+func yamlGet(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func yamlRemove(mapping *yaml.Node, key string) {
+	kept := make([]*yaml.Node, 0, len(mapping.Content))
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			kept = append(kept, mapping.Content[i], mapping.Content[i+1])
+		}
+	}
+	mapping.Content = kept
+}
@@ -0,0 +1,141 @@
+package compose
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+func copyTestStack(t *testing.T, dir string) {
+	for _, component := range []string{"db", "app", "cache"} {
+		bytes, err := ioutil.ReadFile(filepath.Join("testdata", "edit", component, "hub-component.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, filepath.Join(dir, component, "hub-component.yaml"), string(bytes))
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func assertGolden(t *testing.T, golden, actual string) {
+	if *updateGolden {
+		writeTestFile(t, golden, actual)
+		return
+	}
+	if expected := readTestFile(t, golden); actual != expected {
+		t.Errorf("%s mismatch; re-run with -update to accept:\n%s", golden,
+			util.UnifiedDiff(golden, "actual", expected, actual, 3))
+	}
+}
+
+func TestStackEdit(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(filename string)
+	}{
+		// comments, flow style, blank lines, and leading `---` are kept; versioned requirement is ordered after provider
+		{"add-comments", func(filename string) { AddComponent(filename, "app", "dir:app", "", "", false) }},
+		// documents after the first are kept verbatim, parameters already set are not duplicated
+		{"add-multidoc", func(filename string) { AddComponent(filename, "app", "dir:app", "", "", false) }},
+		// depends, order, mandatory, component-scoped and defaulted parameters are cleaned up
+		{"remove-depends", func(filename string) { RemoveComponent(filename, "app", "", false) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "hub-stack-edit")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			copyTestStack(t, dir)
+			filename := filepath.Join(dir, "hub.yaml")
+			before := readTestFile(t, filepath.Join("testdata", "edit", test.name+".yaml"))
+			writeTestFile(t, filename, before)
+
+			test.edit(filename)
+			assertGolden(t, filepath.Join("testdata", "edit", test.name+".golden.yaml"), readTestFile(t, filename))
+		})
+	}
+}
+
+func TestStackEditDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-stack-edit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	copyTestStack(t, dir)
+	filename := filepath.Join(dir, "hub.yaml")
+	before := readTestFile(t, filepath.Join("testdata", "edit", "remove-depends.yaml"))
+	writeTestFile(t, filename, before)
+
+	RemoveComponent(filename, "db", "", true)
+	AddComponent(filename, "app2", "dir:app", "", "", true)
+	if after := readTestFile(t, filename); after != before {
+		t.Errorf("dry run modified %s:\n%s", filename, util.UnifiedDiff("before", "after", before, after, 3))
+	}
+}
+
+func TestAddComponentGitClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir, err := ioutil.TempDir("", "hub-stack-edit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	copyTestStack(t, dir)
+	remote := filepath.Join(dir, "remote")
+	writeTestFile(t, filepath.Join(remote, "components", "app", "hub-component.yaml"),
+		readTestFile(t, filepath.Join(dir, "app", "hub-component.yaml")))
+	writeTestFile(t, filepath.Join(remote, "empty", "README.md"), "no manifest\n")
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "components"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = remote
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	filename := filepath.Join(dir, "hub.yaml")
+	writeTestFile(t, filename, readTestFile(t, filepath.Join("testdata", "edit", "add-comments.yaml")))
+	base := filepath.Join(dir, "sources")
+	source := "git:file://" + filepath.ToSlash(remote) + "#components/app"
+
+	AddComponent(filename, "app", source, "", base, true)
+	if _, err := os.Stat(base); !os.IsNotExist(err) {
+		t.Errorf("dry run left component sources in %s: %v", base, err)
+	}
+
+	AddComponent(filename, "app", source, "", base, false)
+	if _, err := os.Stat(filepath.Join(base, "app", "components", "app", "hub-component.yaml")); err != nil {
+		t.Errorf("component sources are not cloned: %v", err)
+	}
+}
@@ -0,0 +1,342 @@
+package compose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	diffAdded     = "added"
+	diffRemoved   = "removed"
+	diffChanged   = "changed"
+	diffReordered = "reordered"
+
+	diffMasked = "(masked)"
+)
+
+// ManifestChange is a semantic difference between two elaborated manifests
+type ManifestChange struct {
+	Change    string      `json:"change"`
+	What      string      `json:"what"` // component, depends, parameter, output, requires, provides, verbs, templates, ...
+	Component string      `json:"component,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Field     string      `json:"field,omitempty"`
+	Old       interface{} `json:"old,omitempty"`
+	New       interface{} `json:"new,omitempty"`
+}
+
+type manifestDiff struct {
+	changes []ManifestChange
+}
+
+func (d *manifestDiff) add(change ManifestChange) {
+	change.Old = plainValue(change.Old)
+	change.New = plainValue(change.New)
+	d.changes = append(d.changes, change)
+}
+
+// plainValue converts manifest structs and YAML maps into JSON-friendly values with manifest field names
+func plainValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	var plain interface{}
+	if err := yaml.Unmarshal(bytes, &plain); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return stringKeys(plain)
+}
+
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		dict := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			dict[fmt.Sprintf("%v", key)] = stringKeys(entry)
+		}
+		return dict
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, entry := range v {
+			list = append(list, stringKeys(entry))
+		}
+		return list
+	}
+	return value
+}
+
+// Diff reports semantic changes between two hub.yaml.elaborate files
+func Diff(oldFilenames, newFilenames []string, jsonOutput bool) []ManifestChange {
+	oldStack, oldComponents, _, err := manifest.ParseManifest(oldFilenames)
+	if err != nil {
+		log.Fatalf("Unable to parse old manifest: %v", err)
+	}
+	newStack, newComponents, _, err := manifest.ParseManifest(newFilenames)
+	if err != nil {
+		log.Fatalf("Unable to parse new manifest: %v", err)
+	}
+
+	diff := &manifestDiff{changes: make([]ManifestChange, 0)}
+	diff.components(oldStack, newStack)
+	diff.strings("", "lifecycle.order", oldStack.Lifecycle.Order, newStack.Lifecycle.Order, true)
+	diff.strings("", "lifecycle.mandatory", oldStack.Lifecycle.Mandatory, newStack.Lifecycle.Mandatory, false)
+	diff.strings("", "lifecycle.optional", oldStack.Lifecycle.Optional, newStack.Lifecycle.Optional, false)
+	diff.manifest("", oldStack, newStack)
+
+	for _, newRef := range newStack.Components {
+		oldRef := manifest.ComponentRefByName(oldStack.Components, newRef.Name)
+		if oldRef == nil {
+			continue
+		}
+		oldManifest := manifest.ComponentManifestByRef(oldComponents, oldRef)
+		newManifest := manifest.ComponentManifestByRef(newComponents, &newRef)
+		if oldManifest == nil || newManifest == nil {
+			continue
+		}
+		diff.value(newRef.Name, "version", "", "", oldManifest.Meta.Version, newManifest.Meta.Version)
+		diff.manifest(newRef.Name, oldManifest, newManifest)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]interface{}{"changes": diff.changes}); err != nil {
+			log.Fatalf("Unable to marshal changes into JSON: %v", err)
+		}
+	} else {
+		printChanges(diff.changes)
+	}
+	return diff.changes
+}
+
+func (d *manifestDiff) components(oldStack, newStack *manifest.Manifest) {
+	oldNames := make([]string, 0, len(oldStack.Components))
+	for _, ref := range oldStack.Components {
+		oldNames = append(oldNames, ref.Name)
+	}
+	newNames := make([]string, 0, len(newStack.Components))
+	for _, ref := range newStack.Components {
+		newNames = append(newNames, ref.Name)
+	}
+	d.strings("", "component", oldNames, newNames, true)
+
+	for _, newRef := range newStack.Components {
+		oldRef := manifest.ComponentRefByName(oldStack.Components, newRef.Name)
+		if oldRef == nil {
+			continue
+		}
+		d.strings(newRef.Name, "depends", oldRef.Depends, newRef.Depends, false)
+		d.value(newRef.Name, "source", "", "", oldRef.Source, newRef.Source)
+	}
+}
+
+func (d *manifestDiff) manifest(component string, oldManifest, newManifest *manifest.Manifest) {
+	d.parameters(component,
+		manifest.FlattenParameters(oldManifest.Parameters, "old"),
+		manifest.FlattenParameters(newManifest.Parameters, "new"))
+	d.outputs(component, oldManifest.Outputs, newManifest.Outputs)
+	d.strings(component, "requires", oldManifest.Requires, newManifest.Requires, false)
+	d.strings(component, "provides", oldManifest.Provides, newManifest.Provides, false)
+	d.strings(component, "verbs", oldManifest.Lifecycle.Verbs, newManifest.Lifecycle.Verbs, false)
+	d.value(component, "templates", "", "", oldManifest.Templates, newManifest.Templates)
+	d.value(component, "readyConditions", "", "", oldManifest.Lifecycle.ReadyConditions, newManifest.Lifecycle.ReadyConditions)
+	d.value(component, "hooks", "", "", oldManifest.Lifecycle.Hooks, newManifest.Lifecycle.Hooks)
+}
+
+// strings reports added and removed elements, and a change of order if ordered
+func (d *manifestDiff) strings(component, what string, oldList, newList []string, ordered bool) {
+	for _, name := range newList {
+		if !util.Contains(oldList, name) {
+			d.add(ManifestChange{Change: diffAdded, What: what, Component: component, Name: name})
+		}
+	}
+	for _, name := range oldList {
+		if !util.Contains(newList, name) {
+			d.add(ManifestChange{Change: diffRemoved, What: what, Component: component, Name: name})
+		}
+	}
+	if ordered {
+		oldCommon := make([]string, 0, len(oldList))
+		for _, name := range oldList {
+			if util.Contains(newList, name) {
+				oldCommon = append(oldCommon, name)
+			}
+		}
+		newCommon := make([]string, 0, len(newList))
+		for _, name := range newList {
+			if util.Contains(oldList, name) {
+				newCommon = append(newCommon, name)
+			}
+		}
+		if !reflect.DeepEqual(oldCommon, newCommon) {
+			d.add(ManifestChange{Change: diffReordered, What: what, Component: component, Old: oldList, New: newList})
+		}
+	}
+}
+
+func (d *manifestDiff) value(component, what, name, field string, oldValue, newValue interface{}) {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	d.add(ManifestChange{Change: diffChanged, What: what, Component: component, Name: name, Field: field,
+		Old: oldValue, New: newValue})
+}
+
+func (d *manifestDiff) parameters(component string, oldParams, newParams []manifest.Parameter) {
+	oldByName := make(map[string]manifest.Parameter, len(oldParams))
+	for _, p := range oldParams {
+		oldByName[p.QName()] = p
+	}
+	newByName := make(map[string]manifest.Parameter, len(newParams))
+	for _, p := range newParams {
+		newByName[p.QName()] = p
+	}
+	for _, p := range newParams {
+		name := p.QName()
+		old, exist := oldByName[name]
+		if !exist {
+			d.add(ManifestChange{Change: diffAdded, What: "parameter", Component: component, Name: name,
+				New: maskParameterValue(p, parameterValue(p))})
+			continue
+		}
+		oldValue, newValue := parameterValue(old), parameterValue(p)
+		if !reflect.DeepEqual(oldValue, newValue) {
+			d.add(ManifestChange{Change: diffChanged, What: "parameter", Component: component, Name: name, Field: "value",
+				Old: maskParameterValue(old, oldValue), New: maskParameterValue(p, newValue)})
+		}
+		d.value(component, "parameter", name, "kind", old.Kind, p.Kind)
+		d.value(component, "parameter", name, "env", old.Env, p.Env)
+		d.value(component, "parameter", name, "fromEnv", old.FromEnv, p.FromEnv)
+		d.value(component, "parameter", name, "fromFile", old.FromFile, p.FromFile)
+		d.value(component, "parameter", name, "empty", old.Empty, p.Empty)
+	}
+	for _, p := range oldParams {
+		if _, exist := newByName[p.QName()]; !exist {
+			d.add(ManifestChange{Change: diffRemoved, What: "parameter", Component: component, Name: p.QName(),
+				Old: maskParameterValue(p, parameterValue(p))})
+		}
+	}
+}
+
+func parameterValue(p manifest.Parameter) interface{} {
+	if !util.Empty(p.Value) {
+		return p.Value
+	}
+	return p.Default
+}
+
+func maskParameterValue(p manifest.Parameter, value interface{}) interface{} {
+	if !util.Empty(value) && (strings.HasPrefix(p.Kind, "secret") || util.LooksLikeSecret(p.Name)) {
+		return diffMasked
+	}
+	return value
+}
+
+func (d *manifestDiff) outputs(component string, oldOutputs, newOutputs []manifest.Output) {
+	oldByName := make(map[string]manifest.Output, len(oldOutputs))
+	for _, o := range oldOutputs {
+		oldByName[o.Name] = o
+	}
+	newByName := make(map[string]manifest.Output, len(newOutputs))
+	for _, o := range newOutputs {
+		newByName[o.Name] = o
+	}
+	for _, o := range newOutputs {
+		old, exist := oldByName[o.Name]
+		if !exist {
+			d.add(ManifestChange{Change: diffAdded, What: "output", Component: component, Name: o.Name,
+				New: maskOutputValue(o, o.Value)})
+			continue
+		}
+		if !reflect.DeepEqual(old.Value, o.Value) {
+			d.add(ManifestChange{Change: diffChanged, What: "output", Component: component, Name: o.Name, Field: "value",
+				Old: maskOutputValue(old, old.Value), New: maskOutputValue(o, o.Value)})
+		}
+		d.value(component, "output", o.Name, "fromTfVar", old.FromTfVar, o.FromTfVar)
+		d.value(component, "output", o.Name, "kind", old.Kind, o.Kind)
+	}
+	for _, o := range oldOutputs {
+		if _, exist := newByName[o.Name]; !exist {
+			d.add(ManifestChange{Change: diffRemoved, What: "output", Component: component, Name: o.Name,
+				Old: maskOutputValue(o, o.Value)})
+		}
+	}
+}
+
+func maskOutputValue(o manifest.Output, value interface{}) interface{} {
+	if !util.Empty(value) && (strings.HasPrefix(o.Kind, "secret") || util.LooksLikeSecret(o.Name)) {
+		return diffMasked
+	}
+	return value
+}
+
+func printChanges(changes []ManifestChange) {
+	if len(changes) == 0 {
+		fmt.Print("No changes\n")
+		return
+	}
+	marks := map[string]string{diffAdded: "+", diffRemoved: "-", diffChanged: "~", diffReordered: "~"}
+	for _, change := range changes {
+		var line strings.Builder
+		line.WriteString(marks[change.Change])
+		if change.Component != "" {
+			fmt.Fprintf(&line, " component %s:", change.Component)
+		}
+		fmt.Fprintf(&line, " %s", change.What)
+		if change.Name != "" {
+			fmt.Fprintf(&line, " %s", change.Name)
+		}
+		if change.Field != "" {
+			fmt.Fprintf(&line, " %s", change.Field)
+		}
+		switch change.Change {
+		case diffAdded:
+			if change.New != nil {
+				fmt.Fprintf(&line, " = %s", diffValue(change.New))
+			}
+		case diffRemoved:
+			if change.Old != nil {
+				fmt.Fprintf(&line, " (was %s)", diffValue(change.Old))
+			}
+		case diffReordered:
+			fmt.Fprintf(&line, " reordered: %s -> %s", diffValue(change.Old), diffValue(change.New))
+		default:
+			fmt.Fprintf(&line, ": %s -> %s", diffValue(change.Old), diffValue(change.New))
+		}
+		fmt.Println(line.String())
+	}
+}
+
+func diffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		if v == "" {
+			return "(none)"
+		}
+		return fmt.Sprintf("%q", v)
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
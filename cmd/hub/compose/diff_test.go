@@ -0,0 +1,132 @@
+package compose
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns everything written to os.Stdout by fn
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	output := make(chan string)
+	go func() {
+		bytes, _ := ioutil.ReadAll(reader)
+		output <- string(bytes)
+	}()
+	defer func() { os.Stdout = stdout }()
+	fn()
+	writer.Close()
+	return <-output
+}
+
+var diffTestManifests = []string{
+	filepath.Join("testdata", "diff", "old.yaml.elaborate"),
+	filepath.Join("testdata", "diff", "new.yaml.elaborate"),
+}
+
+// values that must never show up in diff output
+var diffTestSecrets = []string{"old-password", "new-password", "api-token-value", "old-auth", "new-auth"}
+
+func TestDiff(t *testing.T) {
+	expected := []ManifestChange{
+		{Change: diffAdded, What: "component", Name: "queue"},
+		{Change: diffAdded, What: "depends", Component: "app", Name: "cache"},
+		{Change: diffAdded, What: "lifecycle.order", Name: "queue"},
+		{Change: diffReordered, What: "lifecycle.order",
+			Old: []interface{}{"db", "cache", "app"}, New: []interface{}{"cache", "db", "app", "queue"}},
+		{Change: diffChanged, What: "parameter", Name: "dns.domain", Field: "value",
+			Old: "old.example.com", New: "new.example.com"},
+		// nested parameters are compared by flattened name
+		{Change: diffChanged, What: "parameter", Name: "component.db.user", Field: "value", Old: "admin", New: "dbadmin"},
+		{Change: diffChanged, What: "parameter", Name: "component.db.password", Field: "value",
+			Old: diffMasked, New: diffMasked},
+		{Change: diffAdded, What: "parameter", Name: "component.app.apiToken", New: diffMasked},
+		{Change: diffRemoved, What: "parameter", Name: "component.app.replicas", Old: 1},
+		{Change: diffChanged, What: "output", Name: "app.url", Field: "value",
+			Old: "https://app.old.example.com", New: "https://app.new.example.com"},
+		{Change: diffChanged, What: "output", Name: "cache.auth", Field: "value", Old: diffMasked, New: diffMasked},
+		{Change: diffChanged, What: "version", Component: "db", Old: "1.0.0", New: "1.1.0"},
+		{Change: diffAdded, What: "parameter", Component: "db", Name: "component.db.port", New: 5432},
+		{Change: diffAdded, What: "verbs", Component: "db", Name: "backup"},
+	}
+
+	var changes []ManifestChange
+	text := captureStdout(t, func() {
+		changes = Diff(diffTestManifests[:1], diffTestManifests[1:], false)
+	})
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Diff changes:\n%#v\nexpected:\n%#v", changes, expected)
+	}
+	assertGolden(t, filepath.Join("testdata", "diff", "changes.golden.txt"), text)
+	for _, secret := range diffTestSecrets {
+		if strings.Contains(text, secret) {
+			t.Errorf("Diff output contains unmasked `%s`", secret)
+		}
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	var changes []ManifestChange
+	text := captureStdout(t, func() {
+		changes = Diff(diffTestManifests[1:], diffTestManifests[1:], false)
+	})
+	if len(changes) != 0 {
+		t.Errorf("Diff of the same manifest reported changes: %#v", changes)
+	}
+	if text != "No changes\n" {
+		t.Errorf("Diff of the same manifest printed %q", text)
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	var changes []ManifestChange
+	text := captureStdout(t, func() {
+		changes = Diff(diffTestManifests[:1], diffTestManifests[1:], true)
+	})
+	for _, secret := range diffTestSecrets {
+		if strings.Contains(text, secret) {
+			t.Errorf("Diff JSON contains unmasked `%s`", secret)
+		}
+	}
+
+	var decoded struct {
+		Changes []map[string]interface{} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+		t.Fatalf("Unable to parse diff JSON: %v\n%s", err, text)
+	}
+	if len(decoded.Changes) != len(changes) {
+		t.Fatalf("Diff JSON has %d changes, expected %d", len(decoded.Changes), len(changes))
+	}
+
+	reordered := decoded.Changes[3]
+	expectedReordered := map[string]interface{}{
+		"change": "reordered",
+		"what":   "lifecycle.order",
+		"old":    []interface{}{"db", "cache", "app"},
+		"new":    []interface{}{"cache", "db", "app", "queue"},
+	}
+	if !reflect.DeepEqual(reordered, expectedReordered) {
+		t.Errorf("Diff JSON reorder change = %v, expected %v", reordered, expectedReordered)
+	}
+	// empty fields are omitted
+	added := decoded.Changes[0]
+	expectedAdded := map[string]interface{}{"change": "added", "what": "component", "name": "queue"}
+	if !reflect.DeepEqual(added, expectedAdded) {
+		t.Errorf("Diff JSON added component = %v, expected %v", added, expectedAdded)
+	}
+	port := decoded.Changes[12]
+	if port["component"] != "db" || port["name"] != "component.db.port" || port["new"] != float64(5432) {
+		t.Errorf("Diff JSON added parameter = %v", port)
+	}
+}
@@ -44,7 +44,7 @@ var environment map[string]string
 func Elaborate(manifestFilename string,
 	parametersFilenames []string, environmentOverrides, explicitProvides string,
 	stateManifests []string, useStateStackParameters bool, elaborateManifests []string, componentsBaseDir string,
-	pipe io.WriteCloser) {
+	inferOrder, writeOrder bool, pipe io.WriteCloser) {
 
 	if config.Verbose {
 		parametersFrom := ""
@@ -100,7 +100,7 @@ func Elaborate(manifestFilename string,
 	}
 
 	stackManifest, componentsManifests := elaborate(manifestFilename, parametersFilenames, environment,
-		wellKnownKV, componentsBaseDir, []string{}, 0, extraKubernetesParams)
+		wellKnownKV, componentsBaseDir, []string{}, 0, inferOrder, writeOrder, extraKubernetesParams)
 
 	if pipe != nil {
 		metricTags := fmt.Sprintf("stack:%s", stackManifest.Meta.Name)
@@ -156,7 +156,7 @@ func Elaborate(manifestFilename string,
 
 func elaborate(manifestFilename string, parametersFilenames []string, overrides map[string]string,
 	wellKnown map[string]manifest.Parameter, componentsBaseDir string,
-	excludedComponents []string, depth int,
+	excludedComponents []string, depth int, inferOrder, writeOrder bool,
 	maybeExtraParameters func(manifest.Manifest) []manifest.Parameter) (*manifest.Manifest, []manifest.Manifest) {
 
 	stackManifest := parseManifest(manifestFilename)
@@ -179,6 +179,13 @@ func elaborate(manifestFilename string, parametersFilenames []string, overrides
 	}
 
 	checkComponentsNames(stackManifest.Components)
+	if inferOrder {
+		order, depends := inferLifecycle(stackManifest, componentsManifests)
+		applyInferredLifecycle(stackManifest, order, depends)
+		if writeOrder && depth == 0 {
+			writeInferredLifecycle(manifestFilename, componentsBaseDir, order, depends)
+		}
+	}
 	checkLifecycle(stackManifest.Components, stackManifest.Lifecycle)
 
 	isApplication := stackManifest.Kind == "application"
@@ -197,7 +204,7 @@ func elaborate(manifestFilename string, parametersFilenames []string, overrides
 		fromStackParams := scanParamsFiles(stackManifest.Meta.FromStack)
 		fromStackExcludedComponents := append(excludedComponents, manifest.ComponentsNamesFromRefs(stackManifest.Components)...)
 		fromStackManifest, fromStackComponentsManifests = elaborate(fromStackFilename, fromStackParams, overrides,
-			wellKnown, componentsBaseDir, fromStackExcludedComponents, depth+1, nil)
+			wellKnown, componentsBaseDir, fromStackExcludedComponents, depth+1, inferOrder, false, nil)
 	}
 
 	if config.Verbose {
@@ -238,12 +245,14 @@ func elaborate(manifestFilename string, parametersFilenames []string, overrides
 		elaborated.Components = mergeComponentsRefs(parentBaseDir, parentComponentsBaseDir,
 			fromStackManifest.Components, stackManifest.Components)
 		elaborated.Lifecycle = mergeLifecycle(fromStackManifest.Lifecycle, stackManifest.Lifecycle)
+		elaborated.Toolchain = mergeToolchain(fromStackManifest.Toolchain, stackManifest.Toolchain)
 		elaborated.Outputs = mergeOutputs(fromStackManifest.Outputs, stackManifest.Outputs)
 		componentsManifests = mergeComponentsManifests(fromStackComponentsManifests, componentsManifests)
 		elaborated.Platform.Provides = util.MergeUnique(fromStackManifest.Platform.Provides, stackManifest.Platform.Provides)
 	} else {
 		elaborated.Components = stackManifest.Components
 		elaborated.Lifecycle = stackManifest.Lifecycle
+		elaborated.Toolchain = stackManifest.Toolchain
 		elaborated.Outputs = stackManifest.Outputs
 		elaborated.Platform.Provides = stackManifest.Platform.Provides
 	}
@@ -578,6 +587,9 @@ func transformApplicationIntoComponent(stack *manifest.Manifest, components []ma
 			Verbs:           stack.Lifecycle.Verbs,
 			ReadyConditions: stack.Lifecycle.ReadyConditions,
 			Requires:        stack.Lifecycle.Requires,
+			Image:           stack.Lifecycle.Image,
+			Container:       stack.Lifecycle.Container,
+			Sandbox:         stack.Lifecycle.Sandbox,
 			Options:         stack.Lifecycle.Options,
 		},
 		Provides:   stack.Provides,
@@ -875,7 +887,7 @@ func connectRequires(parentStackName string, parentStackProvides []string,
 
 	requires := make(map[string][]string)
 	addReq := func(name, req string) {
-		by, exist := provides[req]
+		by, exist := provides[manifest.RequirementName(req)]
 		if exist {
 			if config.Debug {
 				log.Printf("Component `%s` requirement `%s` provided by `%s`",
@@ -937,7 +949,8 @@ func connectRequires(parentStackName string, parentStackProvides []string,
 func connectExplicitProvides(requires []string, provides []string) []string {
 	genuine := make([]string, 0, len(requires))
 	for _, r := range requires {
-		if util.Contains(requirementProvidedByEnvironment, r) || !util.Contains(provides, r) {
+		name := manifest.RequirementName(r)
+		if util.Contains(requirementProvidedByEnvironment, name) || !util.Contains(provides, name) {
 			genuine = append(genuine, r)
 		}
 	}
@@ -947,8 +960,9 @@ func connectExplicitProvides(requires []string, provides []string) []string {
 func connectStateProvides(requires []string, provides map[string][]string) []string {
 	genuine := make([]string, 0, len(requires))
 	for _, r := range requires {
-		if !util.Contains(requirementProvidedByEnvironment, r) {
-			if providers, exist := provides[r]; exist {
+		name := manifest.RequirementName(r)
+		if !util.Contains(requirementProvidedByEnvironment, name) {
+			if providers, exist := provides[name]; exist {
 				if !util.Contains(providers, "*environment*") {
 					continue
 				}
@@ -1018,10 +1032,69 @@ func mergeLifecycle(parent, child manifest.Lifecycle) manifest.Lifecycle {
 		Mandatory:       util.MergeUnique(parent.Mandatory, child.Mandatory),
 		Optional:        util.MergeUnique(parent.Optional, child.Optional),
 		Requires:        mergeRequiresTuning(parent.Requires, child.Requires),
+		Hooks:           mergeHooks(parent.Hooks, child.Hooks),
+		Sandbox:         mergeSandbox(parent.Sandbox, child.Sandbox),
+		Notifications:   append(append([]manifest.Notification{}, parent.Notifications...), child.Notifications...),
 		// Options:
 	}
 }
 
+func mergeSandbox(parent, child *manifest.SandboxOptions) *manifest.SandboxOptions {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	sandbox := *parent
+	if child.Enabled != nil {
+		sandbox.Enabled = child.Enabled
+	}
+	if child.Network != nil {
+		sandbox.Network = child.Network
+	}
+	sandbox.Files = util.MergeUnique(parent.Files, child.Files)
+	sandbox.Writable = util.MergeUnique(parent.Writable, child.Writable)
+	return &sandbox
+}
+
+func mergeToolchain(parent, child manifest.Toolchain) manifest.Toolchain {
+	toolchain := manifest.Toolchain{
+		Cache:  util.Value(child.Cache, parent.Cache),
+		Mirror: util.Value(child.Mirror, parent.Mirror),
+	}
+	tools := make([]manifest.Tool, 0, len(parent.Tools)+len(child.Tools))
+	for _, tool := range parent.Tools {
+		overridden := false
+		for _, childTool := range child.Tools {
+			if childTool.Name == tool.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			tools = append(tools, tool)
+		}
+	}
+	toolchain.Tools = append(tools, child.Tools...)
+	return toolchain
+}
+
+func mergeHooks(parent, child manifest.LifecycleHooks) manifest.LifecycleHooks {
+	concat := func(parent, child []manifest.LifecycleHook) []manifest.LifecycleHook {
+		hooks := make([]manifest.LifecycleHook, 0, len(parent)+len(child))
+		hooks = append(hooks, parent...)
+		return append(hooks, child...)
+	}
+	return manifest.LifecycleHooks{
+		PreDeploy:    concat(parent.PreDeploy, child.PreDeploy),
+		PostDeploy:   concat(parent.PostDeploy, child.PostDeploy),
+		PreUndeploy:  concat(parent.PreUndeploy, child.PreUndeploy),
+		PostUndeploy: concat(parent.PostUndeploy, child.PostUndeploy),
+		OnFailure:    concat(parent.OnFailure, child.OnFailure),
+	}
+}
+
 func mergeOrder(parent, child []string) []string {
 	overridesFromChild := make([]int, 0, len(child))
 	overridesToParent := make([]int, 0, len(child))
@@ -1170,7 +1243,37 @@ func mergeRequiresTuning(parent, child manifest.RequiresTuning) manifest.Require
 			merged = append(merged, req)
 		}
 	}
-	return manifest.RequiresTuning{Optional: util.Reverse(merged)}
+	return manifest.RequiresTuning{
+		Optional: util.Reverse(merged),
+		Outputs:  mergeRequiresOutputs(parent.Outputs, child.Outputs),
+		Binaries: mergeRequiresBinaries(parent.Binaries, child.Binaries),
+	}
+}
+
+func mergeRequiresBinaries(parent, child map[string]manifest.RequiresBinary) map[string]manifest.RequiresBinary {
+	if len(parent) == 0 && len(child) == 0 {
+		return nil
+	}
+	merged := make(map[string]manifest.RequiresBinary)
+	for _, binaries := range []map[string]manifest.RequiresBinary{parent, child} {
+		for name, binary := range binaries {
+			merged[name] = binary
+		}
+	}
+	return merged
+}
+
+func mergeRequiresOutputs(parent, child map[string][]string) map[string][]string {
+	if len(parent) == 0 && len(child) == 0 {
+		return nil
+	}
+	merged := make(map[string][]string)
+	for _, outputs := range []map[string][]string{parent, child} {
+		for req, names := range outputs {
+			merged[req] = util.MergeUnique(merged[req], names)
+		}
+	}
+	return merged
 }
 
 func mergeOutputs(parent, child []manifest.Output) []manifest.Output {
@@ -0,0 +1,290 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const platformNode = "(platform)"
+
+type GraphNode struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"` // component, platform
+	Status   string   `json:"status,omitempty"`
+	Requires []string `json:"requires,omitempty"`
+	Provides []string `json:"provides,omitempty"`
+}
+
+type GraphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Kind  string `json:"kind"` // depends, requires, parameter
+	Label string `json:"label,omitempty"`
+}
+
+type StackGraph struct {
+	Stack string      `json:"stack"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+var graphStatusColors = map[string]string{
+	"deployed":    "#b8e0a8",
+	"error":       "#f4a6a6",
+	"incomplete":  "#f6de8d",
+	"undeployed":  "#d9d9d9",
+	"in-progress": "#a8cbe8",
+}
+
+// Graph writes stack components dependency graph in `dot`, `mermaid`, or `json` format;
+// components are colored by status when state is given
+func Graph(elaborateManifests, stateManifests []string, format, output string) {
+	stackManifest, componentsManifests, _, err := manifest.ParseManifest(elaborateManifests)
+	if err != nil {
+		log.Fatalf("Unable to parse: %v", err)
+	}
+	var st *state.StateManifest
+	if len(stateManifests) > 0 {
+		st = state.MustParseStateFiles(stateManifests)
+	}
+
+	graph := stackGraph(stackManifest, componentsManifests, st)
+
+	var out string
+	switch format {
+	case "dot":
+		out = graphDot(graph)
+	case "mermaid":
+		out = graphMermaid(graph)
+	case "json":
+		var buffer strings.Builder
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false) // keep `>=` of versioned requirements readable
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(graph); err != nil {
+			log.Fatalf("Unable to marshal graph into JSON: %v", err)
+		}
+		out = buffer.String()
+	default:
+		log.Fatalf("Unknown graph format `%s`", format)
+	}
+
+	if output == "" || output == "-" {
+		os.Stdout.Write([]byte(out))
+		return
+	}
+	if err := ioutil.WriteFile(output, []byte(out), 0664); err != nil {
+		log.Fatalf("Unable to write %s: %v", output, err)
+	}
+}
+
+func stackGraph(stack *manifest.Manifest, componentsManifests []manifest.Manifest, st *state.StateManifest) *StackGraph {
+	graph := &StackGraph{Stack: stack.Meta.Name, Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	manifests := make(map[string]*manifest.Manifest)
+	for i := range componentsManifests {
+		manifests[componentsManifests[i].Meta.Name] = &componentsManifests[i]
+	}
+	names := manifest.ComponentsNamesFromRefs(stack.Components)
+	if len(stack.Lifecycle.Order) > 0 {
+		names = stack.Lifecycle.Order
+	}
+
+	platformProvides := stack.Platform.Provides
+	if st != nil {
+		platformProvides = util.MergeUnique(platformProvides, util.SortedKeys2(st.Provides))
+	}
+
+	addEdge := func(edge GraphEdge) {
+		if edge.From == edge.To {
+			return
+		}
+		for _, existing := range graph.Edges {
+			if existing == edge {
+				return
+			}
+		}
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	platformUsed := false
+	for _, name := range names {
+		node := GraphNode{Name: name, Kind: "component"}
+		if st != nil {
+			if step, exist := st.Components[name]; exist && step != nil {
+				node.Status = step.Status
+			}
+		}
+		componentManifest := manifests[name]
+		if componentManifest != nil {
+			node.Requires = componentManifest.Requires
+			node.Provides = componentManifest.Provides
+		}
+		graph.Nodes = append(graph.Nodes, node)
+
+		if ref := manifest.ComponentRefByName(stack.Components, name); ref != nil {
+			for _, dependency := range ref.Depends {
+				addEdge(GraphEdge{From: dependency, To: name, Kind: "depends"})
+			}
+		}
+		if componentManifest == nil {
+			continue
+		}
+		for _, requirement := range componentManifest.Requires {
+			required := manifest.RequirementName(requirement)
+			provided := false
+			for _, provider := range names {
+				if providerManifest := manifests[provider]; provider != name && providerManifest != nil &&
+					util.Contains(providerManifest.Provides, required) {
+					addEdge(GraphEdge{From: provider, To: name, Kind: "requires", Label: requirement})
+					provided = true
+				}
+			}
+			if !provided && util.Contains(platformProvides, required) {
+				addEdge(GraphEdge{From: platformNode, To: name, Kind: "requires", Label: requirement})
+				platformUsed = true
+			}
+		}
+		for _, parameter := range manifest.FlattenParameters(componentManifest.Parameters, name) {
+			for _, expr := range outputReferenceExprs(parameter.Value) {
+				addEdge(GraphEdge{From: expr[:strings.Index(expr, ":")], To: name, Kind: "parameter",
+					Label: fmt.Sprintf("%s: %s", parameter.Name, expr[strings.Index(expr, ":")+1:])})
+			}
+		}
+	}
+	for _, parameter := range manifest.FlattenParameters(stack.Parameters, stack.Meta.Name) {
+		exprs := outputReferenceExprs(parameter.Value)
+		if len(exprs) == 0 {
+			continue
+		}
+		for _, name := range names {
+			if (parameter.Component != "" && parameter.Component != name) ||
+				(parameter.Component == "" && !declaresParameter(manifests[name], parameter.Name)) {
+				continue
+			}
+			for _, expr := range exprs {
+				addEdge(GraphEdge{From: expr[:strings.Index(expr, ":")], To: name, Kind: "parameter",
+					Label: fmt.Sprintf("%s: %s", parameter.Name, expr[strings.Index(expr, ":")+1:])})
+			}
+		}
+	}
+	if platformUsed {
+		graph.Nodes = append([]GraphNode{{Name: platformNode, Kind: "platform", Provides: platformProvides}},
+			graph.Nodes...)
+	}
+
+	// drop edges from components outside of the stack, ie. misspelled `${component:output}`
+	known := make(map[string]bool)
+	for _, node := range graph.Nodes {
+		known[node.Name] = true
+	}
+	edges := make([]GraphEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if known[edge.From] {
+			edges = append(edges, edge)
+		}
+	}
+	graph.Edges = edges
+
+	return graph
+}
+
+func graphDot(graph *StackGraph) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "digraph %q {\n", graph.Stack)
+	out.WriteString("  rankdir=LR;\n  node [shape=box, style=\"rounded,filled\", fillcolor=white];\n")
+	for _, node := range graph.Nodes {
+		label := node.Name
+		if node.Status != "" {
+			label = fmt.Sprintf("%s\\n%s", node.Name, node.Status)
+		}
+		attrs := fmt.Sprintf("label=\"%s\"", label)
+		if node.Kind == "platform" {
+			attrs += ", shape=folder"
+		}
+		if color, exist := graphStatusColors[node.Status]; exist {
+			attrs += fmt.Sprintf(", fillcolor=%q", color)
+		}
+		fmt.Fprintf(&out, "  %q [%s];\n", node.Name, attrs)
+	}
+	for _, edge := range graph.Edges {
+		attrs := ""
+		switch edge.Kind {
+		case "requires":
+			attrs = "color=blue"
+		case "parameter":
+			attrs = "style=dashed"
+		}
+		if edge.Label != "" {
+			if attrs != "" {
+				attrs += ", "
+			}
+			attrs += fmt.Sprintf("label=%q", edge.Label)
+		}
+		if attrs != "" {
+			attrs = fmt.Sprintf(" [%s]", attrs)
+		}
+		fmt.Fprintf(&out, "  %q -> %q%s;\n", edge.From, edge.To, attrs)
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+func graphMermaid(graph *StackGraph) string {
+	ids := make(map[string]string)
+	for i, node := range graph.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", i)
+	}
+	quote := func(str string) string {
+		return strings.ReplaceAll(str, "\"", "#quot;")
+	}
+
+	var out strings.Builder
+	out.WriteString("graph LR\n")
+	for _, node := range graph.Nodes {
+		label := quote(node.Name)
+		if node.Status != "" {
+			label = fmt.Sprintf("%s<br/>%s", label, quote(node.Status))
+		}
+		if node.Kind == "platform" {
+			fmt.Fprintf(&out, "  %s[(\"%s\")]\n", ids[node.Name], label)
+		} else {
+			fmt.Fprintf(&out, "  %s[\"%s\"]\n", ids[node.Name], label)
+		}
+	}
+	for _, edge := range graph.Edges {
+		arrow := "-->"
+		switch edge.Kind {
+		case "parameter":
+			arrow = "-.->"
+		case "requires":
+			arrow = "==>"
+		}
+		if edge.Label != "" {
+			fmt.Fprintf(&out, "  %s %s|\"%s\"| %s\n", ids[edge.From], arrow, quote(edge.Label), ids[edge.To])
+		} else {
+			fmt.Fprintf(&out, "  %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+		}
+	}
+	for _, status := range util.SortedKeys(graphStatusColors) {
+		class := strings.ReplaceAll(status, "-", "")
+		nodes := make([]string, 0)
+		for _, node := range graph.Nodes {
+			if node.Status == status {
+				nodes = append(nodes, ids[node.Name])
+			}
+		}
+		if len(nodes) > 0 {
+			fmt.Fprintf(&out, "  classDef %s fill:%s\n  class %s %s\n",
+				class, graphStatusColors[status], strings.Join(nodes, ","), class)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,272 @@
+package compose
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+// orderEdge means `from` component must be deployed before `to`
+type orderEdge struct {
+	from   string
+	to     string
+	reason string
+}
+
+// inferLifecycle computes lifecycle order and components depends from explicit `depends`,
+// requires / provides, and `${component:output}` references in parameters;
+// current order, if any, is preserved as much as possible
+func inferLifecycle(stack *manifest.Manifest, componentsManifests []manifest.Manifest) ([]string, map[string][]string) {
+	names := manifest.ComponentsNamesFromRefs(stack.Components)
+	manifests := make(map[string]*manifest.Manifest)
+	for i := range componentsManifests {
+		manifests[componentsManifests[i].Meta.Name] = &componentsManifests[i]
+	}
+
+	edges := make([]orderEdge, 0)
+	depends := make(map[string][]string)
+	addEdge := func(from, to, reason string, depend bool) {
+		if from == to || !util.Contains(names, from) {
+			return
+		}
+		edges = append(edges, orderEdge{from: from, to: to, reason: reason})
+		if depend && !util.Contains(depends[to], from) {
+			depends[to] = append(depends[to], from)
+		}
+	}
+
+	for _, component := range stack.Components {
+		// explicit depends may refer to parent stack components
+		depends[component.Name] = append([]string{}, component.Depends...)
+		for _, dependency := range component.Depends {
+			addEdge(dependency, component.Name, "depends", false)
+		}
+	}
+	for _, component := range stack.Components {
+		componentManifest, exist := manifests[component.Name]
+		if !exist {
+			continue
+		}
+		for _, requirement := range componentManifest.Requires {
+			name := manifest.RequirementName(requirement)
+			for _, provider := range stack.Components {
+				if providerManifest, exist := manifests[provider.Name]; exist &&
+					util.Contains(providerManifest.Provides, name) {
+					addEdge(provider.Name, component.Name, fmt.Sprintf("requires %s", requirement), true)
+				}
+			}
+		}
+		for _, parameter := range manifest.FlattenParameters(componentManifest.Parameters, component.Name) {
+			for _, reference := range outputReferences(parameter.Value) {
+				addEdge(reference, component.Name, fmt.Sprintf("${%s:...}", reference), true)
+			}
+		}
+	}
+	for _, parameter := range manifest.FlattenParameters(stack.Parameters, stack.Meta.Name) {
+		references := outputReferences(parameter.Value)
+		if len(references) == 0 {
+			continue
+		}
+		for _, component := range stack.Components {
+			if parameter.Component != "" && parameter.Component != component.Name {
+				continue
+			}
+			if parameter.Component == "" && !declaresParameter(manifests[component.Name], parameter.Name) {
+				continue
+			}
+			for _, reference := range references {
+				addEdge(reference, component.Name, fmt.Sprintf("%s: ${%s:...}", parameter.Name, reference), true)
+			}
+		}
+	}
+
+	if cycle := findCycle(names, edges); len(cycle) > 0 {
+		log.Fatalf("Unable to infer lifecycle order, components dependency cycle:\n\t%s", strings.Join(cycle, "\n\t"))
+	}
+
+	// Kahn's algorithm picking ready components in current order, then in components list order
+	rank := make(map[string]int)
+	for i, name := range names {
+		rank[name] = len(stack.Lifecycle.Order) + i
+	}
+	for i, name := range stack.Lifecycle.Order {
+		if _, exist := rank[name]; exist {
+			rank[name] = i
+		}
+	}
+	incoming := make(map[string]int)
+	for _, edge := range edges {
+		incoming[edge.to]++
+	}
+	order := make([]string, 0, len(names))
+	ready := make([]string, 0)
+	for _, name := range names {
+		if incoming[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return rank[ready[i]] < rank[ready[j]] })
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+		for _, edge := range edges {
+			if edge.from == next {
+				incoming[edge.to]--
+				if incoming[edge.to] == 0 {
+					ready = append(ready, edge.to)
+				}
+			}
+		}
+	}
+
+	// keep depends in deployment order for predictable output lookup precedence
+	for name := range depends {
+		sort.Slice(depends[name], func(i, j int) bool {
+			return util.Index(order, depends[name][i]) < util.Index(order, depends[name][j])
+		})
+	}
+
+	if config.Verbose {
+		log.Printf("Inferred lifecycle order: %s", strings.Join(order, ", "))
+		if config.Debug {
+			for _, edge := range edges {
+				log.Printf("\t%s -> %s (%s)", edge.from, edge.to, edge.reason)
+			}
+		}
+	}
+	return order, depends
+}
+
+func declaresParameter(componentManifest *manifest.Manifest, name string) bool {
+	if componentManifest == nil {
+		return false
+	}
+	for _, parameter := range manifest.FlattenParameters(componentManifest.Parameters, componentManifest.Meta.Name) {
+		if parameter.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// outputReferences returns component names referenced as `${component:output}` in parameter value
+func outputReferences(value interface{}) []string {
+	references := make([]string, 0)
+	for _, expr := range outputReferenceExprs(value) {
+		if name := expr[:strings.Index(expr, ":")]; !util.Contains(references, name) {
+			references = append(references, name)
+		}
+	}
+	return references
+}
+
+// outputReferenceExprs returns `component:output` substitutions in parameter value
+func outputReferenceExprs(value interface{}) []string {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	exprs := make([]string, 0)
+	for _, match := range parameters.CurlyReplacement.FindAllString(str, -1) {
+		expr, isCel := parameters.StripCurly(match)
+		if isCel {
+			continue
+		}
+		name, _ := parameters.SplitEncodings(expr)
+		if strings.Index(name, ":") > 0 {
+			exprs = append(exprs, name)
+		}
+	}
+	return exprs
+}
+
+// findCycle returns dependency cycle path with reasons, or empty slice if there is no cycle
+func findCycle(names []string, edges []orderEdge) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int)
+	path := make([]orderEdge, 0)
+	var cycle []string
+
+	var visit func(string) bool
+	visit = func(name string) bool {
+		marks[name] = visiting
+		for _, edge := range edges {
+			if edge.from != name {
+				continue
+			}
+			path = append(path, edge)
+			switch marks[edge.to] {
+			case visiting:
+				start := 0
+				for i, step := range path {
+					if step.from == edge.to {
+						start = i
+						break
+					}
+				}
+				for _, step := range path[start:] {
+					cycle = append(cycle, fmt.Sprintf("%s -> %s (%s)", step.from, step.to, step.reason))
+				}
+				return true
+			case unvisited:
+				if visit(edge.to) {
+					return true
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		marks[name] = visited
+		return false
+	}
+
+	for _, name := range names {
+		if marks[name] == unvisited && visit(name) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// applyInferredLifecycle sets inferred order and depends on stack manifest
+func applyInferredLifecycle(stack *manifest.Manifest, order []string, depends map[string][]string) {
+	if len(stack.Lifecycle.Order) > 0 && !util.Equal(stack.Lifecycle.Order, order) {
+		log.Printf("Lifecycle order changed from: %s\n\tto: %s",
+			strings.Join(stack.Lifecycle.Order, ", "), strings.Join(order, ", "))
+	}
+	stack.Lifecycle.Order = order
+	for i := range stack.Components {
+		if dependencies := depends[stack.Components[i].Name]; len(dependencies) > 0 {
+			stack.Components[i].Depends = dependencies
+		}
+	}
+}
+
+// writeInferredLifecycle rewrites stack manifest source with inferred order and depends
+func writeInferredLifecycle(manifestFilename, componentsBaseDir string, order []string, depends map[string][]string) {
+	edit := loadStackEdit(manifestFilename, componentsBaseDir)
+
+	lifecycle := yamlMapping(edit.document, "lifecycle")
+	yamlSet(lifecycle, "order", yamlStrings(yamlGet(lifecycle, "order"), order))
+
+	if components := yamlGet(edit.document, "components"); components != nil {
+		for _, ref := range components.Content {
+			if dependencies := depends[yamlString(yamlGet(ref, "name"))]; len(dependencies) > 0 {
+				yamlSet(ref, "depends", yamlStrings(yamlGet(ref, "depends"), dependencies))
+			}
+		}
+	}
+
+	edit.save(false)
+	log.Printf("Wrote inferred lifecycle order and depends to %s", manifestFilename)
+}
@@ -0,0 +1,191 @@
+package compose
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+func testComponents(names ...string) []manifest.ComponentRef {
+	refs := make([]manifest.ComponentRef, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, manifest.ComponentRef{Name: name})
+	}
+	return refs
+}
+
+func TestInferLifecycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		stack      manifest.Manifest
+		components []manifest.Manifest
+		order      []string
+		depends    map[string][]string
+	}{
+		{
+			name: "existing order is preserved",
+			stack: manifest.Manifest{
+				Components: testComponents("a", "b", "c"),
+				Lifecycle:  manifest.Lifecycle{Order: []string{"c", "a", "b"}},
+			},
+			order:   []string{"c", "a", "b"},
+			depends: map[string][]string{"a": {}, "b": {}, "c": {}},
+		},
+		{
+			name: "new component goes after ordered ones",
+			stack: manifest.Manifest{
+				Components: testComponents("a", "b", "c"),
+				Lifecycle:  manifest.Lifecycle{Order: []string{"c", "a"}},
+			},
+			order:   []string{"c", "a", "b"},
+			depends: map[string][]string{"a": {}, "b": {}, "c": {}},
+		},
+		{
+			name: "provider with versioned requirement",
+			stack: manifest.Manifest{
+				Components: testComponents("app", "cache", "db"),
+				Lifecycle:  manifest.Lifecycle{Order: []string{"app", "cache", "db"}},
+			},
+			components: []manifest.Manifest{
+				{Meta: manifest.Metadata{Name: "app"}, Requires: []string{"postgresql>=12", "redis", "kubernetes"}},
+				{Meta: manifest.Metadata{Name: "cache"}, Provides: []string{"redis"}},
+				{Meta: manifest.Metadata{Name: "db"}, Provides: []string{"postgresql"}},
+			},
+			order:   []string{"cache", "db", "app"},
+			depends: map[string][]string{"app": {"cache", "db"}, "cache": {}, "db": {}},
+		},
+		{
+			name: "explicit depends and component parameter reference",
+			stack: manifest.Manifest{
+				Components: []manifest.ComponentRef{
+					{Name: "web", Depends: []string{"platform"}},
+					{Name: "api"},
+					{Name: "db"},
+				},
+			},
+			components: []manifest.Manifest{
+				{Meta: manifest.Metadata{Name: "web"}, Parameters: []manifest.Parameter{
+					{Name: "api.url", Value: "https://${api:component.api.host}/v1"}}},
+				{Meta: manifest.Metadata{Name: "api"}, Parameters: []manifest.Parameter{
+					{Name: "db.host", Value: "${db:component.db.host}"},
+					{Name: "db.password", Value: "${db:component.db.password/base64}"}}},
+				{Meta: manifest.Metadata{Name: "db"}},
+			},
+			order:   []string{"db", "api", "web"},
+			depends: map[string][]string{"web": {"platform", "api"}, "api": {"db"}, "db": {}},
+		},
+		{
+			name: "stack parameters references",
+			stack: manifest.Manifest{
+				Meta:       manifest.Metadata{Name: "stack"},
+				Components: testComponents("web", "api", "db", "cache"),
+				Parameters: []manifest.Parameter{
+					// unqualified parameter makes edges to components that declare it
+					{Name: "db.host", Value: "${db:component.db.host}"},
+					// component-qualified parameter makes edge to that component only
+					{Name: "cache.url", Component: "web", Value: "${cache:component.cache.url}"},
+					{Name: "cel.expr", Value: "#{uuid()}"},
+					{Name: "plain", Value: "${dns.domain}"},
+				},
+			},
+			components: []manifest.Manifest{
+				{Meta: manifest.Metadata{Name: "web"}, Parameters: []manifest.Parameter{{Name: "cache.url"}}},
+				{Meta: manifest.Metadata{Name: "api"}, Parameters: []manifest.Parameter{
+					{Name: "db.host"}, {Name: "cache.url"}}},
+				{Meta: manifest.Metadata{Name: "db"}},
+				{Meta: manifest.Metadata{Name: "cache"}},
+			},
+			order:   []string{"db", "api", "cache", "web"},
+			depends: map[string][]string{"web": {"cache"}, "api": {"db"}, "db": {}, "cache": {}},
+		},
+	}
+	for _, test := range tests {
+		order, depends := inferLifecycle(&test.stack, test.components)
+		if !reflect.DeepEqual(order, test.order) {
+			t.Errorf("%s: order = %v, want %v", test.name, order, test.order)
+		}
+		if !reflect.DeepEqual(depends, test.depends) {
+			t.Errorf("%s: depends = %v, want %v", test.name, depends, test.depends)
+		}
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	names := []string{"app", "db", "cache", "dns"}
+	edges := []orderEdge{
+		{from: "dns", to: "app", reason: "requires dns"},
+		{from: "db", to: "app", reason: "requires postgresql>=12"},
+		{from: "app", to: "cache", reason: "${app:...}"},
+		{from: "cache", to: "db", reason: "db.cache: ${cache:...}"},
+	}
+	expected := []string{
+		"app -> cache (${app:...})",
+		"cache -> db (db.cache: ${cache:...})",
+		"db -> app (requires postgresql>=12)",
+	}
+	if cycle := findCycle(names, edges); !reflect.DeepEqual(cycle, expected) {
+		t.Errorf("findCycle() = %q, want %q", cycle, expected)
+	}
+	if cycle := findCycle(names, edges[:3]); len(cycle) != 0 {
+		t.Errorf("findCycle() without cycle = %q", cycle)
+	}
+}
+
+func TestWriteInferredLifecycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hub.yaml")
+	writeTestFile(t, filename, `---
+version: 1
+kind: stack
+meta:
+  name: test
+
+components:
+  - name: app  # the app
+    source:
+      dir: app
+    depends: [dns]
+  - name: db
+    source:
+      dir: db
+
+lifecycle:
+  order:
+    - app  # item comments move with the item
+    - db
+`)
+
+	writeInferredLifecycle(filename, "", []string{"db", "app"}, map[string][]string{"app": {"dns", "db"}, "db": {}})
+
+	expected := `---
+version: 1
+kind: stack
+meta:
+  name: test
+
+components:
+  - name: app # the app
+    source:
+      dir: app
+    depends: [dns, db]
+  - name: db
+    source:
+      dir: db
+
+lifecycle:
+  order:
+    - db
+    - app # item comments move with the item
+`
+	if actual := readTestFile(t, filename); actual != expected {
+		t.Errorf("written manifest:\n%s\nwant:\n%s", actual, expected)
+	}
+}
@@ -43,6 +43,11 @@ var (
 	CryptoAwsKmsKeyArn       string
 	CryptoAzureKeyVaultKeyId string
 
+	OtelEndpoint string
+	OtelFile     string
+
+	MetricsSinks string
+
 	GitBinDefault = "/usr/bin/git"
 )
 
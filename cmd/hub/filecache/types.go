@@ -9,7 +9,8 @@ type AccessTokenBox struct {
 
 type Metrics struct {
 	Disabled bool
-	Host     *string `yaml:",omitempty"`
+	Host     *string  `yaml:",omitempty"`
+	Sinks    []string `yaml:",omitempty"`
 }
 
 type FileCache struct {
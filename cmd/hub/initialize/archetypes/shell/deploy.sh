@@ -0,0 +1,17 @@
+#!/bin/sh -e
+
+. ./config.env
+
+echo "Deploying [[ .Name ]]"
+[[- range .Parameters ]]
+echo "  [[ .Name ]] = ${[[ .Env ]]}"
+[[- end ]]
+
+# TODO deploy [[ .Name ]]
+
+echo
+echo Outputs:
+[[- range .Outputs ]]
+echo "[[ .Var ]] = [[ .Value ]]"
+[[- end ]]
+echo
@@ -0,0 +1,7 @@
+#!/bin/sh -e
+
+. ./config.env
+
+echo "Undeploying [[ .Name ]]"
+
+# TODO undeploy [[ .Name ]]
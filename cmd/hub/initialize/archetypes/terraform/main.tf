@@ -0,0 +1,8 @@
+terraform {
+  required_version = ">= 0.12"
+}
+
+# TODO resources of [[ .Name ]]
+locals {
+  name = "[[ .Name ]]"
+}
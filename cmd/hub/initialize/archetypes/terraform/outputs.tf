@@ -0,0 +1,6 @@
+[[- range $i, $o := .Outputs ]][[ if $i ]]
+
+[[ end ]]output "[[ .Var ]]" {
+  value = "[[ .Value ]]"
+}
+[[- end ]]
@@ -0,0 +1,7 @@
+[[- range $i, $p := .Parameters ]][[ if $i ]]
+
+[[ end ]]variable "[[ .Var ]]" {
+  type        = string
+  description = "[[ .Name ]]"
+}
+[[- end ]]
@@ -0,0 +1,354 @@
+package initialize
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/bindata"
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/git"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	archetypesDir         = initializeTemplates + "/archetypes"
+	archetypeManifestFile = "archetype.yaml"
+)
+
+var nonIdentifierChars = regexp.MustCompile("[^a-zA-Z0-9_]+")
+
+// ScaffoldRequest describes component to generate, empty fields are prompted for when on terminal
+type ScaffoldRequest struct {
+	Archetype  string // built-in archetype name, directory, or Git URL with optional #subdir
+	Name       string
+	Brief      string
+	Parameters string // name[=value],...
+	Outputs    string // name[=sample value],...
+}
+
+type scaffoldParameter struct {
+	Name  string
+	Env   string
+	Var   string
+	Value string
+}
+
+type scaffoldOutput struct {
+	Name  string
+	Var   string
+	Brief string
+	Value string
+}
+
+type scaffold struct {
+	Name       string
+	Brief      string
+	Archetype  string
+	Parameters []scaffoldParameter
+	Outputs    []scaffoldOutput
+}
+
+type archetypeManifest struct {
+	Description string
+}
+
+// archetypeFile is a file of archetype, either built-in or on disk
+type archetypeFile struct {
+	path    string // relative to archetype root
+	mode    os.FileMode
+	content func() ([]byte, error)
+}
+
+// Archetypes returns built-in archetypes names and descriptions
+func Archetypes() map[string]string {
+	names, err := bindata.AssetDir(archetypesDir)
+	if err != nil {
+		log.Fatalf("Unable to list built-in archetypes: %v", err)
+	}
+	archetypes := make(map[string]string, len(names))
+	for _, name := range names {
+		description := ""
+		if bytes, err := bindata.Asset(fmt.Sprintf("%s/%s/%s", archetypesDir, name, archetypeManifestFile)); err == nil {
+			var manifest archetypeManifest
+			if yaml.Unmarshal(bytes, &manifest) == nil {
+				description = manifest.Description
+			}
+		}
+		archetypes[name] = description
+	}
+	return archetypes
+}
+
+func PrintArchetypes() {
+	archetypes := Archetypes()
+	for _, name := range util.SortedKeys(archetypes) {
+		fmt.Printf("%-12s %s\n", name, archetypes[name])
+	}
+}
+
+// ScaffoldComponent generates component with deploy / undeploy implementation, templates, and outputs
+// from archetype in `dir`
+func ScaffoldComponent(dir string, request ScaffoldRequest) {
+	request.Archetype = ask(request.Archetype, fmt.Sprintf("Archetype (%s, a directory, or a Git URL)",
+		strings.Join(util.SortedKeys(Archetypes()), ", ")), "shell")
+	files, cleanup := archetypeFiles(request.Archetype)
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	abs := util.MustAbs(dir)
+	data := scaffold{Archetype: request.Archetype}
+	data.Name = ask(request.Name, "Component name", filepath.Base(abs))
+	data.Brief = ask(request.Brief, "Brief description", data.Name)
+	data.Parameters = scaffoldParameters(ask(request.Parameters,
+		"Parameters, comma separated: name[=value],...", "dns.domain"))
+	data.Outputs = scaffoldOutputs(data.Name, ask(request.Outputs,
+		"Outputs, comma separated: name[=sample value],...", "endpoint"))
+
+	if config.Debug {
+		log.Printf("Scaffolding %+v", data)
+	}
+
+	funcs := template.FuncMap{
+		"quote": func(str string) string {
+			bytes, _ := json.Marshal(str)
+			return string(bytes)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+	// render everything and check for existing files first, so that nothing is written on error
+	rendered := make([]string, len(files))
+	existing := make([]string, 0)
+	for i, file := range files {
+		if file.path == archetypeManifestFile {
+			continue
+		}
+		bytes, err := file.content()
+		if err != nil {
+			log.Fatalf("Unable to read archetype file `%s`: %v", file.path, err)
+		}
+		// [[ ]] delimiters do not clash with Hub ${} and {{}} templates and Helm charts
+		tmpl, err := template.New(file.path).Delims("[[", "]]").Funcs(funcs).Parse(string(bytes))
+		if err != nil {
+			log.Fatalf("Unable to parse archetype file `%s`: %v", file.path, err)
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			log.Fatalf("Unable to render archetype file `%s`: %v", file.path, err)
+		}
+		rendered[i] = out.String()
+		if _, err := os.Stat(filepath.Join(dir, file.path)); err == nil {
+			existing = append(existing, file.path)
+		}
+	}
+	if len(existing) > 0 && !config.Force {
+		log.Fatalf("%s exist in %s, add --force to override", strings.Join(existing, ", "), dir)
+	}
+	for i, file := range files {
+		if file.path != archetypeManifestFile {
+			writeScaffoldFile(filepath.Join(dir, file.path), rendered[i], file.mode)
+		}
+	}
+	log.Printf("Generated `%s` component from `%s` archetype in %s", data.Name, request.Archetype, dir)
+}
+
+func writeScaffoldFile(path, content string, mode os.FileMode) {
+	if err := os.MkdirAll(filepath.Dir(path), 0775); err != nil {
+		log.Fatalf("Unable to create directory for `%s`: %v", path, err)
+	}
+	if mode&0111 != 0 {
+		mode = 0775
+	} else {
+		mode = 0664
+	}
+	if err := ioutil.WriteFile(path, []byte(content), mode); err != nil {
+		log.Fatalf("Unable to write `%s`: %v", path, err)
+	}
+	if config.Verbose {
+		log.Printf("Wrote `%s`", path)
+	}
+}
+
+func isGitUrl(archetype string) bool {
+	for _, prefix := range []string{"git@", "git://", "ssh://", "file://"} {
+		if strings.HasPrefix(archetype, prefix) {
+			return true
+		}
+	}
+	return (strings.HasPrefix(archetype, "https://") || strings.HasPrefix(archetype, "http://")) &&
+		strings.Contains(archetype, ".git")
+}
+
+// archetypeFiles lists files of built-in archetype, archetype directory, or archetype in Git repository;
+// cleanup function removes temporary clone
+func archetypeFiles(archetype string) ([]archetypeFile, func()) {
+	if _, exist := Archetypes()[archetype]; exist {
+		return builtinArchetypeFiles(fmt.Sprintf("%s/%s", archetypesDir, archetype), ""), nil
+	}
+	if isGitUrl(archetype) {
+		remote, subdir := archetype, ""
+		if i := strings.LastIndex(archetype, "#"); i > 0 {
+			remote, subdir = archetype[:i], archetype[i+1:]
+		}
+		clone, err := ioutil.TempDir("", "hub-archetype-")
+		if err != nil {
+			log.Fatalf("Unable to create temporary directory: %v", err)
+		}
+		cleanup := func() { os.RemoveAll(clone) }
+		cmd := exec.Cmd{
+			Path:   git.GitBinPath(),
+			Args:   []string{"git", "clone", "--depth", "1", "--quiet", remote, clone},
+			Stdin:  os.Stdin,
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+		}
+		if config.Debug {
+			log.Printf("Cloning archetype repository: %v", cmd.Args)
+		}
+		if err := cmd.Run(); err != nil {
+			cleanup()
+			log.Fatalf("Unable to git clone %s: %v", remote, err)
+		}
+		return dirArchetypeFiles(filepath.Join(clone, subdir)), cleanup
+	}
+	info, err := os.Stat(archetype)
+	if err != nil || !info.IsDir() {
+		log.Fatalf("Archetype `%s` is not built-in, a directory, or a Git URL; built-in archetypes: %v",
+			archetype, util.SortedKeys(Archetypes()))
+	}
+	return dirArchetypeFiles(archetype), nil
+}
+
+func builtinArchetypeFiles(root, prefix string) []archetypeFile {
+	names, err := bindata.AssetDir(filepath.Join(root, prefix))
+	if err != nil {
+		// not a directory
+		asset := fmt.Sprintf("%s/%s", root, prefix)
+		info, err := bindata.AssetInfo(asset)
+		if err != nil {
+			log.Fatalf("Unable to read archetype file `%s`: %v", asset, err)
+		}
+		return []archetypeFile{{
+			path:    prefix,
+			mode:    info.Mode(),
+			content: func() ([]byte, error) { return bindata.Asset(asset) },
+		}}
+	}
+	files := make([]archetypeFile, 0, len(names))
+	for _, name := range names {
+		files = append(files, builtinArchetypeFiles(root, filepath.Join(prefix, name))...)
+	}
+	return files
+}
+
+func dirArchetypeFiles(root string) []archetypeFile {
+	files := make([]archetypeFile, 0)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, archetypeFile{
+			path:    rel,
+			mode:    info.Mode(),
+			content: func() ([]byte, error) { return ioutil.ReadFile(path) },
+		})
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Unable to read archetype directory `%s`: %v", root, err)
+	}
+	return files
+}
+
+func scaffoldIdentifier(name string) string {
+	return strings.Trim(nonIdentifierChars.ReplaceAllString(name, "_"), "_")
+}
+
+func scaffoldParameters(list string) []scaffoldParameter {
+	params := make([]scaffoldParameter, 0)
+	for _, spec := range util.SplitPaths(list) {
+		name, value := spec, ""
+		if i := strings.Index(spec, "="); i > 0 {
+			name, value = spec[:i], spec[i+1:]
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		identifier := scaffoldIdentifier(name)
+		params = append(params, scaffoldParameter{
+			Name:  name,
+			Env:   strings.ToUpper(identifier),
+			Var:   strings.ToLower(identifier),
+			Value: value,
+		})
+	}
+	return params
+}
+
+func scaffoldOutputs(componentName, list string) []scaffoldOutput {
+	outputs := make([]scaffoldOutput, 0)
+	for _, spec := range util.SplitPaths(list) {
+		name, value := spec, ""
+		if i := strings.Index(spec, "="); i > 0 {
+			name, value = spec[:i], spec[i+1:]
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		short := name
+		if strings.Contains(name, ".") {
+			short = name[strings.LastIndex(name, ".")+1:]
+		} else {
+			name = fmt.Sprintf("component.%s.%s", componentName, name)
+		}
+		identifier := strings.ToLower(scaffoldIdentifier(short))
+		outputs = append(outputs, scaffoldOutput{
+			Name:  name,
+			Var:   identifier,
+			Brief: strings.Title(strings.ReplaceAll(short, "_", " ")),
+			Value: util.Value(value, "sample-"+identifier),
+		})
+	}
+	return outputs
+}
+
+// ask prompts for a value on terminal unless the value is already set
+func ask(value, prompt, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return defaultValue
+	}
+	fmt.Printf("%s [%s]: ", prompt, defaultValue)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return defaultValue
+	}
+	return util.Value(strings.TrimSpace(line), defaultValue)
+}
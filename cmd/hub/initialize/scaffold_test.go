@@ -0,0 +1,218 @@
+package initialize
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+func TestScaffoldBuiltinArchetypes(t *testing.T) {
+	archetypes := Archetypes()
+	if len(archetypes) == 0 {
+		t.Fatal("no built-in archetypes")
+	}
+	for name, description := range archetypes {
+		if description == "" {
+			t.Errorf("archetype `%s` has no description", name)
+		}
+		dir, err := ioutil.TempDir("", "hub-scaffold")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		ScaffoldComponent(dir, ScaffoldRequest{
+			Archetype:  name,
+			Name:       "demo",
+			Brief:      "Demo \"component\"",
+			Parameters: "dns.domain,component.demo.size=small",
+			Outputs:    "endpoint=https://demo.example.com,component.demo.api.token",
+		})
+
+		if _, err := os.Stat(filepath.Join(dir, archetypeManifestFile)); !os.IsNotExist(err) {
+			t.Errorf("%s: %s is copied", name, archetypeManifestFile)
+		}
+		components, _, _, err := manifest.ParseManifest([]string{filepath.Join(dir, "hub-component.yaml")})
+		if err != nil {
+			t.Errorf("%s: unable to parse hub-component.yaml: %v", name, err)
+			continue
+		}
+		if components.Kind != "component" || components.Meta.Name != "demo" || components.Meta.Brief != "Demo \"component\"" {
+			t.Errorf("%s: kind %s, meta %+v", name, components.Kind, components.Meta)
+		}
+		parameters := make(map[string]interface{})
+		for _, parameter := range manifest.FlattenParameters(components.Parameters, "demo") {
+			parameters[parameter.Name] = parameter.Value
+		}
+		if value, exist := parameters["dns.domain"]; !exist || value != nil {
+			t.Errorf("%s: dns.domain parameter = %v (%v)", name, value, exist)
+		}
+		if value := parameters["component.demo.size"]; value != "small" {
+			t.Errorf("%s: component.demo.size parameter = %v", name, value)
+		}
+		// Kubernetes archetypes set outputs by value, others capture raw outputs
+		vars := map[string]string{"component.demo.endpoint": "endpoint", "component.demo.api.token": "token"}
+		outputs := make([]string, 0)
+		for _, output := range components.Outputs {
+			outputs = append(outputs, output.Name)
+			if output.FromTfVar != "" && output.FromTfVar != vars[output.Name] {
+				t.Errorf("%s: output `%s` fromTfVar = %s", name, output.Name, output.FromTfVar)
+			}
+		}
+		sort.Strings(outputs)
+		if expected := util.SortedKeys(vars); !reflect.DeepEqual(outputs, expected) {
+			t.Errorf("%s: outputs = %v, want %v", name, outputs, expected)
+		}
+	}
+}
+
+func TestScaffoldExistingFiles(t *testing.T) {
+	if dir := os.Getenv("HUB_TEST_SCAFFOLD_DIR"); dir != "" {
+		ScaffoldComponent(dir, ScaffoldRequest{Archetype: "shell", Name: "demo", Brief: "Demo",
+			Parameters: "dns.domain", Outputs: "endpoint"})
+		return
+	}
+	dir, err := ioutil.TempDir("", "hub-scaffold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "undeploy.sh"), []byte("keep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// log.Fatal exits, so scaffold runs in a nested test process
+	cmd := exec.Command(os.Args[0], "-test.run=^TestScaffoldExistingFiles$")
+	cmd.Env = append(os.Environ(), "HUB_TEST_SCAFFOLD_DIR="+dir)
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("scaffold over existing file succeeded:\n%s", out)
+	}
+	if !strings.Contains(string(out), "undeploy.sh exist in") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("files are written before existing file check: %d files in %s", len(files), dir)
+	}
+}
+
+func TestIsGitUrl(t *testing.T) {
+	tests := map[string]bool{
+		"git@github.com:agilestacks/components.git":           true,
+		"git://github.com/agilestacks/components":             true,
+		"ssh://git@github.com/agilestacks/components.git#sub": true,
+		"file:///tmp/archetypes":                              true,
+		"https://github.com/agilestacks/components.git":       true,
+		"https://github.com/agilestacks/components.git#shell": true,
+		"https://github.com/agilestacks/components":           false,
+		"http://example.com/archetype.tar.gz":                 false,
+		"shell":                                               false,
+		"./archetypes/shell":                                  false,
+		"/tmp/archetype.git":                                  false,
+	}
+	for archetype, expected := range tests {
+		if actual := isGitUrl(archetype); actual != expected {
+			t.Errorf("isGitUrl(%q) = %v, want %v", archetype, actual, expected)
+		}
+	}
+}
+
+func archetypePaths(files []archetypeFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, filepath.ToSlash(file.path))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestArchetypeFiles(t *testing.T) {
+	files, cleanup := archetypeFiles("shell")
+	if cleanup != nil {
+		t.Error("cleanup is returned for built-in archetype")
+	}
+	expected := []string{"archetype.yaml", "config.env.template", "deploy.sh", "hub-component.yaml", "undeploy.sh"}
+	if paths := archetypePaths(files); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("shell archetype files = %v, want %v", paths, expected)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file.path, ".sh") && file.mode&0111 == 0 {
+			t.Errorf("shell archetype `%s` is not executable: %v", file.path, file.mode)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "hub-archetype")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for path, content := range map[string]string{
+		"custom/hub-component.yaml":       "kind: component\n",
+		"custom/templates/values.yaml":    "replicas: 1\n",
+		"custom/.git/config":              "[core]\n",
+		"custom/.git/objects/ab/cd":       "object",
+		"other/hub-component.yaml":        "kind: component\n",
+		"custom/templates/.hidden.config": "kept\n",
+	} {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, cleanup = archetypeFiles(filepath.Join(dir, "custom"))
+	if cleanup != nil {
+		t.Error("cleanup is returned for directory archetype")
+	}
+	expected = []string{"hub-component.yaml", "templates/.hidden.config", "templates/values.yaml"}
+	if paths := archetypePaths(files); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("directory archetype files = %v, want %v", paths, expected)
+	}
+	for _, file := range files {
+		if bytes, err := file.content(); err != nil || len(bytes) == 0 {
+			t.Errorf("directory archetype `%s` content: %q, %v", file.path, bytes, err)
+		}
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	if err := os.RemoveAll(filepath.Join(dir, "custom", ".git")); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "archetypes"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	files, cleanup = archetypeFiles("file://" + filepath.ToSlash(dir) + "#custom")
+	if cleanup == nil {
+		t.Fatal("no cleanup for Git archetype clone")
+	}
+	if paths := archetypePaths(files); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Git archetype files = %v, want %v", paths, expected)
+	}
+	cleanup()
+	for _, file := range files {
+		if _, err := file.content(); err == nil {
+			t.Errorf("Git archetype clone `%s` is not removed by cleanup", file.path)
+		}
+	}
+}
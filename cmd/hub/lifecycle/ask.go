@@ -94,6 +94,7 @@ func AskParameter(parameter manifest.Parameter,
 		if !util.Empty(parameter.Default) {
 			prompt = fmt.Sprintf("%s [%v]", prompt, parameter.Default)
 		}
+		activeNotifications.AwaitingInput(prompt)
 		fmt.Printf("%s: ", prompt)
 		var value string
 		read, err := fmt.Scanln(&value)
@@ -7,16 +7,20 @@ import (
 	"io"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"gopkg.in/yaml.v2"
 
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
 	"github.com/agilestacks/hub/cmd/hub/state"
 	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -56,6 +60,10 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 		componentsBaseDir = stackBaseDir
 	}
 
+	checkSimulationState(request)
+	checkSimulationBundles(request, bundles)
+	simulation := startSimulation(request)
+
 	components := stackManifest.Components
 	checkComponentsManifests(components, componentsManifests)
 	order := stackManifest.Lifecycle.Order
@@ -97,6 +105,10 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 		os.Exit(1)
 	}
 
+	setRequiresBinaries(stackManifest.Lifecycle.Requires.Binaries)
+	setToolchain(stackManifest.Toolchain, stackBaseDir)
+	setSandbox(request.SandboxMode, stackManifest.Lifecycle.Sandbox, stackBaseDir)
+	setTemplateComponentsDirs(stackManifest.Components, stackBaseDir, componentsBaseDir)
 	optionalRequires := parseRequiresTunning(stackManifest.Lifecycle.Requires)
 	requiresOfOptionalComponents := calculateRequiresOfOptionalComponents(componentsManifests, &stackManifest.Lifecycle, stackManifest.Requires)
 	stackRequires := maybeOmitCloudRequires(stackManifest.Requires, request.EnabledClouds)
@@ -136,7 +148,39 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 	}
 	failedComponents := make([]string, 0)
 
+	progress := startProgress(verb, stackManifest.Meta.Name, implementsBackup)
+	generatedId, err := uuid.NewRandom()
+	if err != nil {
+		log.Fatalf("Unable to generate operation Id random v4 UUID: %v", err)
+	}
+	operationId := simulation.operationId(generatedId.String())
+	logs := startOperationLogs(request, stackBaseDir, operationId)
+	operationSpan := tracing.Start(fmt.Sprintf("hub %s", verb),
+		"hub.operation.id", operationId, "hub.stack", stackManifest.Meta.Name)
+	operationStart := time.Now()
+	util.AtDone(func() <-chan struct{} {
+		operationSpan.End()
+		tracing.Flush()
+		status := bundle.Status
+		if status == "" {
+			status = "error"
+		}
+		metrics.Timing("hub_operation_duration_seconds", time.Since(operationStart),
+			"stack", stackManifest.Meta.Name, "verb", request.Verb, "status", status)
+		metrics.Flush()
+		return nil
+	})
+	defer util.Done()
+	if request.Events != "" && bundleFiles == nil && (request.EventsOutput == "" || request.EventsOutput == "-") {
+		log.Fatal("Backup bundle is written to stdout, set --events-output to a file or fd:N")
+	}
+	events := startEvents(request.Events, request.EventsOutput, operationId, verb, stackManifest.Meta.Name)
+	events.OperationStart(implementsBackup)
+	notifications := startNotifications(stackManifest.Lifecycle.Notifications, operationId,
+		verb, stackManifest.Meta.Name)
 	for componentIndex, componentName := range implementsBackup {
+		progress.Start(componentName)
+		events.ComponentStart(componentName, "backing up")
 		if config.Verbose {
 			log.Printf("%s ***%s*** (%d/%d)", verb, componentName, componentIndex+1, len(implementsBackup))
 		}
@@ -163,6 +207,7 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 				componentName, util.Errors("\n\t", errs...))
 		}
 		componentParameters := parameters.MergeParameters(make(parameters.LockedParameters), expandedComponentParameters)
+		notifications.Secrets(expandedComponentParameters)
 
 		if config.Debug {
 			log.Print("Component parameters:")
@@ -172,7 +217,9 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 		prepareComponentRequires(provides, componentManifest, stackParameters, allOutputs, optionalRequires, request.EnabledClouds)
 
 		dir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
-		stdout, _, err := delegate(verb, component, componentManifest, componentParameters, dir, osEnv, "")
+		logs.Open(componentName, verb)
+		stdout, _, err := delegate(verb, component, componentManifest, componentParameters, nil, dir, osEnv, "")
+		logs.Close(componentName)
 
 		var rawOutputs parameters.RawOutputs
 		if len(stdout) > 0 {
@@ -184,6 +231,8 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 				err = errors.New("no outputs emited by the component")
 			}
 			log.Printf("Component `%s` failed to %s: %v", componentName, verb, err)
+			progress.Fail(componentName)
+			events.ComponentEnd(componentName, "error", err.Error())
 			failedComponents = append(failedComponents, componentName)
 			if !allowPartial {
 				break
@@ -191,12 +240,14 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 		} else {
 			status = "success"
 			log.Printf("Component `%s` completed %s", componentName, verb)
+			progress.Done(componentName)
+			events.ComponentEnd(componentName, "success", "")
 		}
 		kind, exist := rawOutputs["kind"]
 		if !exist || kind == "" {
 			kind = componentName
 		}
-		timestamp := time.Now()
+		timestamp := util.Now()
 		timestampStr, exist := rawOutputs["timestamp"]
 		if exist && timestampStr != "" {
 			timestamp2, err := time.Parse(time.RFC3339, timestampStr)
@@ -213,6 +264,7 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 		for name, value := range rawOutputs {
 			outputs = append(outputs, parameters.CapturedOutput{Name: name, Value: value})
 		}
+		sort.Slice(outputs, func(i, j int) bool { return outputs[i].Name < outputs[j].Name })
 		bundle.Components[componentName] = state.ComponentBackup{
 			Timestamp: timestamp,
 			Status:    status,
@@ -220,6 +272,8 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 			Outputs:   outputs,
 		}
 	}
+	logs.Stop()
+	progress.Stop()
 
 	if len(failedComponents) > 0 {
 		log.Printf("Component(s) failed to %s: %v", verb, failedComponents)
@@ -231,7 +285,20 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 	} else {
 		bundle.Status = "success"
 	}
-	bundle.Timestamp = time.Now()
+	bundle.Timestamp = util.Now()
+	simulation.Stop()
+	events.OperationEnd(bundle.Status, "")
+	events.Stop()
+	if len(failedComponents) > 0 {
+		message := fmt.Sprintf("Component(s) failed to %s: %v", verb, failedComponents)
+		if bundle.Status == "error" {
+			operationSpan.SetError(errors.New(message))
+		}
+		notifications.Failure(failedComponents[0], message)
+	} else {
+		notifications.Success()
+	}
+	notifications.Stop()
 
 	format := "yaml"
 	marshall := yaml.Marshal
@@ -246,7 +313,10 @@ func BackupCreate(request *Request, bundles []string, jsonOutput, allowPartial b
 	if bundleFiles != nil {
 		_, errs := storage.Write(bytes, bundleFiles)
 		if len(errs) > 0 {
-			log.Fatalf("Unable to write backup bundle: %s", util.Errors2(errs...))
+			message := fmt.Sprintf("Unable to write backup bundle: %s", util.Errors2(errs...))
+			operationSpan.SetError(errors.New(message))
+			util.Done()
+			log.Fatal(message)
 		}
 	} else {
 		os.Stdout.Write([]byte(fmt.Sprintf("--- %s\n", format)))
@@ -0,0 +1,485 @@
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const componentTestStackState = "hub.yaml.state"
+
+// ComponentTests is `hub-component.test.yaml` describing component tests
+type ComponentTests struct {
+	Version int
+	Kind    string
+	Tests   []ComponentTest
+}
+
+type ComponentTest struct {
+	Name             string
+	Parameters       []manifest.Parameter           `yaml:",omitempty"`
+	PlatformProvides []string                       `yaml:"platformProvides,omitempty"`
+	Simulate         bool                           `yaml:",omitempty"`
+	Fixtures         *SimulationFixtures            `yaml:",omitempty"` // implies simulate
+	Steps            []string                       `yaml:",omitempty"` // default to deploy-test (if implemented), deploy, undeploy
+	Expect           map[string]ComponentTestExpect `yaml:",omitempty"` // step => expectations
+}
+
+type ComponentTestExpect struct {
+	ExitCode  int                             `yaml:"exitCode,omitempty"`
+	Outputs   map[string]interface{}          `yaml:",omitempty"` // component output => value
+	Templates map[string]ComponentTestContent `yaml:",omitempty"` // path relative to component dir => content
+}
+
+type ComponentTestContent struct {
+	Equals   *string  `yaml:",omitempty"`
+	Contains []string `yaml:",omitempty"`
+	Matches  []string `yaml:",omitempty"` // regexp
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Classname string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// TestComponent runs component tests from `hub-component.test.yaml` against a synthetic
+// single-component stack, each step as nested Hub CLI invocation; returns number of failures
+func TestComponent(componentDir, testsFilename, junitOutput, workDir, only string) int {
+	componentDir = util.MustAbs(componentDir)
+	if testsFilename == "" {
+		testsFilename = filepath.Join(componentDir, "hub-component.test.yaml")
+	}
+	bytes, err := ioutil.ReadFile(testsFilename)
+	if err != nil {
+		log.Fatalf("Unable to read component tests: %v", err)
+	}
+	var tests ComponentTests
+	if err := yaml.UnmarshalStrict(bytes, &tests); err != nil {
+		log.Fatalf("Unable to parse component tests `%s`: %v", testsFilename, err)
+	}
+	if len(tests.Tests) == 0 {
+		log.Fatalf("No tests found in `%s`", testsFilename)
+	}
+
+	componentManifest, _, _, err := manifest.ParseManifest([]string{filepath.Join(componentDir, "hub-component.yaml")})
+	if err != nil {
+		log.Fatalf("Unable to parse component manifest: %v", err)
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Unable to locate Hub CLI executable: %v", err)
+	}
+
+	keepWorkDir := workDir != ""
+	if workDir == "" {
+		workDir, err = ioutil.TempDir("", "hub-component-test-")
+		if err != nil {
+			log.Fatalf("Unable to create work directory: %v", err)
+		}
+	}
+	workDir = util.MustAbs(workDir)
+
+	report := junitTestSuites{Name: fmt.Sprintf("hub test component %s", componentManifest.Meta.Name)}
+	start := time.Now()
+	for _, test := range tests.Tests {
+		if only != "" && test.Name != only {
+			continue
+		}
+		testDir := filepath.Join(workDir, testDirName(test.Name))
+		if err := os.MkdirAll(testDir, 0755); err != nil {
+			log.Fatalf("Unable to create test directory: %v", err)
+		}
+		suite := runComponentTest(bin, componentDir, componentManifest, test, testDir)
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+	report.Time = junitSeconds(time.Since(start))
+	if len(report.Suites) == 0 {
+		log.Fatalf("No test named `%s` found in `%s`", only, testsFilename)
+	}
+
+	if junitOutput != "" {
+		bytes, err := xml.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("Unable to marshal JUnit report: %v", err)
+		}
+		bytes = append([]byte(xml.Header), append(bytes, '\n')...)
+		if junitOutput == "-" {
+			os.Stdout.Write(bytes)
+		} else if err := ioutil.WriteFile(junitOutput, bytes, 0644); err != nil {
+			log.Fatalf("Unable to write JUnit report: %v", err)
+		} else if config.Verbose {
+			log.Printf("Wrote JUnit report %s", junitOutput)
+		}
+	}
+
+	if keepWorkDir {
+		log.Printf("Test work directory kept in %s", workDir)
+	} else if err := os.RemoveAll(workDir); err != nil {
+		util.Warn("Unable to remove test work directory: %v", err)
+	}
+
+	if report.Failures > 0 {
+		log.Printf("%d of %d test steps failed", report.Failures, report.Tests)
+	} else {
+		log.Printf("All %d test steps passed", report.Tests)
+	}
+	return report.Failures
+}
+
+func runComponentTest(bin, componentDir string, componentManifest *manifest.Manifest,
+	test ComponentTest, testDir string) junitTestSuite {
+
+	componentName := componentManifest.Meta.Name
+	suite := junitTestSuite{
+		Name:      fmt.Sprintf("%s/%s", componentName, test.Name),
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05"),
+	}
+	classname := fmt.Sprintf("%s.%s", componentName, test.Name)
+	start := time.Now()
+
+	stackFilename := filepath.Join(testDir, "hub.yaml")
+	elaborateFilename := filepath.Join(testDir, "hub.yaml.elaborate")
+	stateFilename := filepath.Join(testDir, componentTestStackState)
+	fixturesFilename := filepath.Join(testDir, "fixtures.yaml")
+
+	common := []string{"--tty=false"}
+	if config.Debug {
+		common = append(common, "--debug")
+	}
+	simulate := test.Simulate || test.Fixtures != nil
+
+	writeErr := writeComponentTestStack(stackFilename, componentDir, componentManifest, test)
+	if writeErr == nil && test.Fixtures != nil {
+		writeErr = writeYaml(fixturesFilename, test.Fixtures)
+	}
+
+	steps := componentTestSteps(componentDir, test.Steps, simulate)
+
+	addCase := func(tc junitTestCase) {
+		tc.Classname = classname
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+			log.Printf("FAIL %s %s: %s", suite.Name, tc.Name, tc.Failure.Message)
+		} else if tc.Skipped != nil {
+			suite.Skipped++
+		} else if config.Verbose {
+			log.Printf("PASS %s %s", suite.Name, tc.Name)
+		}
+	}
+	skipRest := func(from int, reason string) {
+		for _, step := range steps[from:] {
+			addCase(junitTestCase{Name: step, Time: "0", Skipped: &junitSkipped{Message: reason}})
+		}
+	}
+
+	// elaborate
+	if writeErr != nil {
+		addCase(junitTestCase{Name: "elaborate", Time: "0",
+			Failure: &junitFailure{Message: fmt.Sprintf("Unable to write test stack: %v", writeErr)}})
+		skipRest(0, "elaborate failed")
+		suite.Time = junitSeconds(time.Since(start))
+		return suite
+	}
+	args := append([]string{"elaborate", stackFilename, "-o", elaborateFilename}, common...)
+	if len(test.PlatformProvides) > 0 {
+		args = append(args, "-p", strings.Join(test.PlatformProvides, ","))
+	}
+	exitCode, out, elapsed := runNestedHub(bin, testDir, args)
+	tc := junitTestCase{Name: "elaborate", Time: junitSeconds(elapsed), SystemOut: out}
+	if exitCode != 0 {
+		tc.Failure = &junitFailure{Message: fmt.Sprintf("Elaborate exited with code %d", exitCode), Text: out}
+	}
+	addCase(tc)
+	if exitCode != 0 {
+		skipRest(0, "elaborate failed")
+		suite.Time = junitSeconds(time.Since(start))
+		return suite
+	}
+
+	for i, step := range steps {
+		verb := strings.TrimSuffix(step, "-test")
+		dryRun := verb != step
+		if !util.Contains([]string{"deploy", "undeploy"}, verb) {
+			addCase(junitTestCase{Name: step, Time: "0",
+				Failure: &junitFailure{Message: fmt.Sprintf("Unsupported test step `%s`", step)}})
+			continue
+		}
+		name := maybeTestVerb(verb, dryRun)
+		renderedDir := filepath.Join(testDir, "rendered", fmt.Sprintf("%d-%s", i+1, name))
+		args := append([]string{verb, elaborateFilename, "-s", stateFilename,
+			"--log-dir", filepath.Join(testDir, "logs")}, common...)
+		if dryRun {
+			args = append(args, "--dry")
+		}
+		if verb == "deploy" {
+			args = append(args, "--rendered-dir", renderedDir)
+		}
+		if test.Fixtures != nil {
+			args = append(args, "--simulate-fixtures", fixturesFilename)
+		} else if simulate {
+			args = append(args, "--simulate")
+		}
+
+		exitCode, out, elapsed := runNestedHub(bin, testDir, args)
+		tc := junitTestCase{Name: name, Time: junitSeconds(elapsed), SystemOut: out}
+		expect := test.Expect[name]
+		failures := make([]string, 0)
+		if exitCode != expect.ExitCode {
+			failures = append(failures, fmt.Sprintf("exit code %d, expected %d", exitCode, expect.ExitCode))
+		}
+		if len(expect.Outputs) > 0 {
+			failures = append(failures, checkComponentTestOutputs(stateFilename, componentName, expect.Outputs)...)
+		}
+		if len(expect.Templates) > 0 {
+			failures = append(failures, checkComponentTestTemplates(renderedDir, componentName, expect.Templates)...)
+		}
+		if len(failures) > 0 {
+			text := strings.Join(failures, "\n")
+			if exitCode != expect.ExitCode {
+				text = fmt.Sprintf("%s\n\n%s", text, outputTail(out, 20))
+			}
+			tc.Failure = &junitFailure{Message: failures[0], Text: text}
+		}
+		addCase(tc)
+	}
+	suite.Time = junitSeconds(time.Since(start))
+	return suite
+}
+
+// componentTestSteps defaults to deploy-test, if implemented or simulated, then deploy, undeploy
+func componentTestSteps(componentDir string, steps []string, simulate bool) []string {
+	if len(steps) > 0 {
+		return steps
+	}
+	steps = []string{"deploy", "undeploy"}
+	if impl, _ := probeImplementation(componentDir, maybeTestVerb("deploy", true)); simulate || impl {
+		steps = append([]string{maybeTestVerb("deploy", true)}, steps...)
+	}
+	return steps
+}
+
+// writeComponentTestStack writes synthetic stack manifest with the component under test;
+// component requirements not provided by platform are stack requirements
+func writeComponentTestStack(filename, componentDir string, componentManifest *manifest.Manifest, test ComponentTest) error {
+	componentName := componentManifest.Meta.Name
+	stack := yaml.MapSlice{
+		{Key: "version", Value: 1},
+		{Key: "kind", Value: "stack"},
+		{Key: "meta", Value: yaml.MapSlice{{Key: "name", Value: fmt.Sprintf("%s-test", componentName)}}},
+	}
+	requires := make([]string, 0)
+	for _, requirement := range componentManifest.Requires {
+		if !util.Contains(test.PlatformProvides, manifest.RequirementName(requirement)) {
+			requires = append(requires, requirement)
+		}
+	}
+	if len(requires) > 0 {
+		stack = append(stack, yaml.MapItem{Key: "requires", Value: requires})
+	}
+	stack = append(stack, yaml.MapItem{Key: "components", Value: []yaml.MapSlice{{
+		{Key: "name", Value: componentName},
+		{Key: "source", Value: yaml.MapSlice{{Key: "dir", Value: componentDir}}},
+	}}})
+	lifecycle := yaml.MapSlice{{Key: "order", Value: []string{componentName}}}
+	if len(componentManifest.Lifecycle.Verbs) > 0 {
+		lifecycle = append(yaml.MapSlice{{Key: "verbs", Value: componentManifest.Lifecycle.Verbs}}, lifecycle...)
+	}
+	stack = append(stack, yaml.MapItem{Key: "lifecycle", Value: lifecycle})
+	if len(test.Parameters) > 0 {
+		stack = append(stack, yaml.MapItem{Key: "parameters", Value: test.Parameters})
+	}
+	return writeYaml(filename, stack)
+}
+
+func writeYaml(filename string, value interface{}) error {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, bytes, 0644)
+}
+
+func runNestedHub(bin, dir string, args []string) (int, string, time.Duration) {
+	if config.Debug {
+		log.Printf("Running %s %s", bin, strings.Join(args, " "))
+	}
+	start := time.Now()
+	cmd := exec.Command(bin, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	elapsed := time.Since(start)
+	exitCode := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = -1
+			out = append(out, []byte(fmt.Sprintf("\nUnable to run %s: %v\n", bin, err))...)
+		}
+	}
+	if config.Trace {
+		log.Printf("%s", out)
+	}
+	return exitCode, string(out), elapsed
+}
+
+func checkComponentTestOutputs(stateFilename, componentName string, expected map[string]interface{}) []string {
+	if _, err := os.Stat(stateFilename); err != nil {
+		return []string{fmt.Sprintf("no state file to check outputs: %v", err)}
+	}
+	stateManifest := state.MustParseStateFiles([]string{stateFilename})
+	step := stateManifest.Components[componentName]
+	failures := make([]string, 0)
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var actual interface{}
+		found := false
+		if step != nil {
+			for _, output := range step.CapturedOutputs {
+				if output.Name == name {
+					actual = output.Value
+					found = true
+				}
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("output `%s` not found", name))
+		} else if !sameTestValue(expected[name], actual) {
+			failures = append(failures, fmt.Sprintf("output `%s` = `%v`, expected `%v`", name, actual, expected[name]))
+		}
+	}
+	return failures
+}
+
+func sameTestValue(expected, actual interface{}) bool {
+	if str, ok := expected.(string); ok {
+		return str == util.String(actual)
+	}
+	bytes, err := yaml.Marshal(expected)
+	bytes2, err2 := yaml.Marshal(actual)
+	return err == nil && err2 == nil && string(bytes) == string(bytes2)
+}
+
+// checkComponentTestTemplates checks rendered templates copies kept by deploy in rendered dir
+func checkComponentTestTemplates(renderedDir, componentName string, expected map[string]ComponentTestContent) []string {
+	entries, err := ioutil.ReadDir(renderedDir)
+	operations := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			operations = append(operations, entry.Name())
+		}
+	}
+	if err != nil || len(operations) == 0 {
+		return []string{"no rendered templates found"}
+	}
+	filesDir := filepath.Join(renderedDir, operations[len(operations)-1], componentName, renderedFilesDir)
+	failures := make([]string, 0)
+	paths := make([]string, 0, len(expected))
+	for path := range expected {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		bytes, err := ioutil.ReadFile(filepath.Join(filesDir, filepath.FromSlash(path)))
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("template `%s` was not rendered", path))
+			continue
+		}
+		content := string(bytes)
+		check := expected[path]
+		if check.Equals != nil && *check.Equals != content {
+			failures = append(failures, fmt.Sprintf("template `%s` content differs:\n%s", path,
+				util.UnifiedDiff("expected", path, *check.Equals, content, 3)))
+		}
+		for _, str := range check.Contains {
+			if !strings.Contains(content, str) {
+				failures = append(failures, fmt.Sprintf("template `%s` does not contain `%s`", path, str))
+			}
+		}
+		for _, expr := range check.Matches {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("template `%s` invalid regexp `%s`: %v", path, expr, err))
+			} else if !re.MatchString(content) {
+				failures = append(failures, fmt.Sprintf("template `%s` does not match `%s`", path, expr))
+			}
+		}
+	}
+	return failures
+}
+
+func outputTail(out string, lines int) string {
+	all := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n")
+}
+
+func testDirName(name string) string {
+	return regexp.MustCompile(`[^A-Za-z0-9._-]+`).ReplaceAllString(name, "_")
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
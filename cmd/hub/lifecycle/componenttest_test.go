@@ -0,0 +1,192 @@
+package lifecycle
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComponentTestSteps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-component-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	plain := filepath.Join(dir, "plain")
+	withTest := filepath.Join(dir, "with-test")
+	writeTestFiles(t, dir, map[string]string{
+		"plain/Makefile":     "deploy:\n\ttrue\nundeploy:\n\ttrue\n",
+		"with-test/Makefile": "deploy:\n\ttrue\ndeploy-test:\n\ttrue\nundeploy:\n\ttrue\n",
+	})
+
+	tests := []struct {
+		dir      string
+		steps    []string
+		simulate bool
+		expected []string
+	}{
+		{plain, nil, false, []string{"deploy", "undeploy"}},
+		{plain, nil, true, []string{"deploy-test", "deploy", "undeploy"}},
+		{withTest, nil, false, []string{"deploy-test", "deploy", "undeploy"}},
+		{withTest, []string{"deploy"}, false, []string{"deploy"}},
+	}
+	for _, test := range tests {
+		steps := componentTestSteps(test.dir, test.steps, test.simulate)
+		if !reflect.DeepEqual(steps, test.expected) {
+			t.Errorf("componentTestSteps(%s, %v, %v) = %v, want %v",
+				filepath.Base(test.dir), test.steps, test.simulate, steps, test.expected)
+		}
+	}
+}
+
+func TestSameTestValue(t *testing.T) {
+	tests := []struct {
+		expected interface{}
+		actual   interface{}
+		same     bool
+	}{
+		{"5432", 5432, true},
+		{"5432", "5432", true},
+		{"true", true, true},
+		{"app.example.com", "app.test.example.com", false},
+		{5432, 5432, true},
+		{[]interface{}{80, 443}, []interface{}{80, 443}, true},
+		{[]interface{}{80, 443}, []interface{}{443, 80}, false},
+		{map[string]interface{}{"a": 1}, map[interface{}]interface{}{"a": 1}, true},
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"a": "1"}, false},
+	}
+	for _, test := range tests {
+		if same := sameTestValue(test.expected, test.actual); same != test.same {
+			t.Errorf("sameTestValue(%#v, %#v) = %v, want %v", test.expected, test.actual, same, test.same)
+		}
+	}
+}
+
+func TestCheckComponentTestTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-component-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// rendered dir layout: <operation>/<component>/files/<path>; the latest operation is checked
+	writeTestFiles(t, dir, map[string]string{
+		".gitignore": "*\n",
+		"20210101T000000-1111/app/files/values.yaml":        "domain: old.example.com\n",
+		"20210101T000001-2222/app/files/values.yaml":        "domain: test.example.com\nreplicas: 2\n",
+		"20210101T000001-2222/app/files/nested/config.json": `{"port": 80}`,
+	})
+	equals := "domain: test.example.com\nreplicas: 2\n"
+	differs := "domain: example.com\n"
+
+	failures := checkComponentTestTemplates(dir, "app", map[string]ComponentTestContent{
+		"values.yaml":        {Equals: &equals, Contains: []string{"replicas: 2"}, Matches: []string{`replicas: \d+`}},
+		"nested/config.json": {Contains: []string{`"port": 80`}},
+	})
+	if len(failures) > 0 {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+
+	failures = checkComponentTestTemplates(dir, "app", map[string]ComponentTestContent{
+		"missing.yaml": {Contains: []string{"x"}},
+		"values.yaml":  {Equals: &differs, Contains: []string{"old.example.com"}, Matches: []string{`replicas: [a-z]+`, `(`}},
+	})
+	expected := []string{
+		"template `missing.yaml` was not rendered",
+		"template `values.yaml` content differs",
+		"template `values.yaml` does not contain `old.example.com`",
+		"template `values.yaml` does not match `replicas: [a-z]+`",
+		"template `values.yaml` invalid regexp `(`",
+	}
+	if len(failures) != len(expected) {
+		t.Fatalf("failures = %q, want %d", failures, len(expected))
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(failures[i], prefix) {
+			t.Errorf("failure %d = %q, want prefix %q", i, failures[i], prefix)
+		}
+	}
+
+	if failures := checkComponentTestTemplates(filepath.Join(dir, "app"), "app",
+		map[string]ComponentTestContent{"values.yaml": {}}); len(failures) != 1 ||
+		failures[0] != "no rendered templates found" {
+		t.Errorf("failures without rendered operations = %q", failures)
+	}
+}
+
+func TestComponentJUnitReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-component-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// nested Hub CLI is this test binary, see TestMain
+	os.Setenv("HUB_TEST_NESTED_CLI", "1")
+	defer os.Unsetenv("HUB_TEST_NESTED_CLI")
+
+	junit := filepath.Join(dir, "junit.xml")
+	failures := TestComponent(filepath.Join("testdata", "component"), "", junit, filepath.Join(dir, "work"), "")
+	if failures != 1 {
+		t.Errorf("TestComponent() = %d failures, want 1", failures)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal([]byte(readTestFile(t, junit)), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Name != "hub test component app" || report.Tests != 9 || report.Failures != 1 || report.Skipped != 0 {
+		t.Errorf("report = %s: tests %d, failures %d, skipped %d",
+			report.Name, report.Tests, report.Failures, report.Skipped)
+	}
+	expected := []struct {
+		suite  string
+		cases  []string
+		failed string
+	}{
+		{"app/defaults", []string{"elaborate", "deploy-test", "deploy", "undeploy"}, ""},
+		{"app/undeploy failure", []string{"elaborate", "deploy", "undeploy"}, ""},
+		{"app/wrong expectations", []string{"elaborate", "deploy"}, "deploy"},
+	}
+	if len(report.Suites) != len(expected) {
+		t.Fatalf("report has %d suites, want %d", len(report.Suites), len(expected))
+	}
+	for i, suite := range report.Suites {
+		names := make([]string, 0, len(suite.Cases))
+		for _, tc := range suite.Cases {
+			names = append(names, tc.Name)
+			if tc.Classname != strings.Replace(suite.Name, "/", ".", 1) {
+				t.Errorf("%s %s classname = %s", suite.Name, tc.Name, tc.Classname)
+			}
+			if tc.SystemOut == "" {
+				t.Errorf("%s %s has no system-out", suite.Name, tc.Name)
+			}
+			if failed := tc.Failure != nil; failed != (tc.Name == expected[i].failed) {
+				t.Errorf("%s %s failure = %+v", suite.Name, tc.Name, tc.Failure)
+			}
+		}
+		if suite.Name != expected[i].suite || !reflect.DeepEqual(names, expected[i].cases) {
+			t.Errorf("suite %d = %s %v, want %s %v", i, suite.Name, names, expected[i].suite, expected[i].cases)
+		}
+		if suite.Tests != len(suite.Cases) || suite.Timestamp == "" {
+			t.Errorf("suite %s: tests %d, timestamp %q", suite.Name, suite.Tests, suite.Timestamp)
+		}
+	}
+
+	failure := report.Suites[2].Cases[1].Failure
+	if failure != nil {
+		for _, message := range []string{
+			"output `component.app.endpoint` = `simulated-endpoint`, expected `app.example.com`",
+			"template `values.yaml` content differs",
+		} {
+			if !strings.Contains(failure.Text, message) {
+				t.Errorf("failure text does not contain %q:\n%s", message, failure.Text)
+			}
+		}
+		if !strings.HasPrefix(failure.Message, "output `component.app.endpoint`") {
+			t.Errorf("failure message = %q", failure.Message)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const envVarNameContainerRuntime = "HUB_CONTAINER_RUNTIME"
+
+var (
+	containerRuntimes = []string{"docker", "podman"}
+
+	// host-specific variables that make no sense inside container
+	containerOmitEnv = []string{
+		"PATH", "HOME", "SHELL", "TMPDIR", "USER", "LOGNAME", "LD_LIBRARY_PATH", "SSH_AUTH_SOCK",
+		"GOBIN", "GOPATH", "GOROOT", "DOCKER_*", "NVM_*", "SDKMAN_*", "VIRTUALENVWRAPPER_*", "VIRTUAL_ENV",
+		"PWD", "OLDPWD", "SHLVL", "HOSTNAME", "_", "CONTAINER_HOST", "CONTAINER_CONNECTION",
+	}
+	// variables pointing to credential files that are mounted read-only at the same path
+	containerFileEnv = []string{
+		"KUBECONFIG", "GOOGLE_APPLICATION_CREDENTIALS", "AWS_SHARED_CREDENTIALS_FILE", "AWS_CONFIG_FILE",
+		"AZURE_AUTH_LOCATION",
+	}
+)
+
+func containerRuntime(options *manifest.ContainerOptions) (string, error) {
+	requested := os.Getenv(envVarNameContainerRuntime)
+	if options != nil && options.Runtime != "" {
+		requested = options.Runtime
+	}
+	candidates := containerRuntimes
+	if requested != "" {
+		if !util.Contains(containerRuntimes, requested) {
+			return "", fmt.Errorf("Container runtime `%s` is not one of %v", requested, containerRuntimes)
+		}
+		candidates = []string{requested}
+	}
+	for _, candidate := range candidates {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("Container runtime not found in PATH, tried %v", candidates)
+}
+
+// containerImplementation wraps component verb implementation into `docker|podman run` executing
+// in component image with component dir bind-mounted at the same path, so that file outputs,
+// ie. Terraform state, are written back to the host
+func containerImplementation(impl *exec.Cmd, image string, options *manifest.ContainerOptions) (*exec.Cmd, error) {
+	bin, err := containerRuntime(options)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := filepath.Abs(impl.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine absolute path of `%s`: %v", impl.Dir, err)
+	}
+	runtimeName := filepath.Base(bin)
+
+	args := []string{runtimeName, "run", "--rm", "--workdir", dir, "--volume", dir + ":" + dir}
+	if runtime.GOOS == "linux" {
+		if runtimeName == "podman" {
+			args = append(args, "--userns=keep-id")
+		} else {
+			args = append(args, "--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()))
+		}
+	}
+
+	var command string
+	switch {
+	case len(impl.Args) == 0: // script in component dir
+		command = impl.Path
+		if !filepath.IsAbs(command) {
+			command = filepath.Join(dir, command)
+		}
+	case !strings.ContainsRune(impl.Args[0], os.PathSeparator): // make, skaffold - use binary from image
+		command = impl.Args[0]
+	default: // helm, kustomize, terraform extensions
+		command, err = filepath.Abs(impl.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to determine absolute path of `%s`: %v", impl.Path, err)
+		}
+	}
+	if filepath.IsAbs(command) && !strings.HasPrefix(command, dir+string(os.PathSeparator)) {
+		commandDir := filepath.Dir(command)
+		args = append(args, "--volume", commandDir+":"+commandDir+":ro")
+	}
+
+	mounted := make(map[string]struct{})
+	env := make([]string, 0, len(impl.Env))
+	for _, v := range impl.Env {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || util.ContainsPrefix(containerOmitEnv, kv[0]) {
+			continue
+		}
+		env = append(env, kv[0])
+		if util.Contains(containerFileEnv, kv[0]) {
+			for _, path := range filepath.SplitList(kv[1]) {
+				if _, seen := mounted[path]; seen || !filepath.IsAbs(path) {
+					continue
+				}
+				if _, err := os.Stat(path); err == nil {
+					mounted[path] = struct{}{}
+					args = append(args, "--volume", path+":"+path+":ro")
+				}
+			}
+		}
+	}
+	for _, name := range env {
+		args = append(args, "--env", name)
+	}
+
+	if options != nil {
+		for _, volume := range options.Volumes {
+			args = append(args, "--volume", os.ExpandEnv(volume))
+		}
+		if options.Network != "" {
+			args = append(args, "--network", options.Network)
+		}
+		args = append(args, options.Options...)
+	}
+
+	args = append(args, image, command)
+	if len(impl.Args) > 1 {
+		args = append(args, impl.Args[1:]...)
+	}
+
+	if config.Debug {
+		log.Printf("Executing in `%s` container via %s", image, runtimeName)
+	}
+
+	// values are passed via runtime process environment to keep them out of process list
+	return &exec.Cmd{Path: bin, Args: args, Dir: impl.Dir, Env: impl.Env}, nil
+}
@@ -1,20 +1,24 @@
 package lifecycle
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
 	"github.com/agilestacks/hub/cmd/hub/state"
 	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -29,10 +33,44 @@ func Execute(request *Request, pipe io.WriteCloser) {
 	isUndeploy := strings.HasPrefix(request.Verb, "undeploy")
 	isSomeComponents := len(request.Components) > 0 || request.OffsetComponent != ""
 
+	// operation id is also used to name log files directory and in events
+	operationId, err := uuid.NewRandom()
+	if err != nil {
+		log.Fatalf("Unable to generate operation Id random v4 UUID: %v", err)
+	}
+	operationLogId := operationId.String()
+	operationSpan := tracing.Start(fmt.Sprintf("hub %s", maybeTestVerb(request.Verb, request.DryRun)),
+		"hub.operation.id", operationLogId)
+	operationStart := time.Now()
+	operationStatus := "error"
+	metricsStack := ""
+	util.AtDone(func() <-chan struct{} {
+		operationSpan.End()
+		tracing.Flush()
+		metrics.Timing("hub_operation_duration_seconds", time.Since(operationStart),
+			"stack", metricsStack, "verb", request.Verb, "status", operationStatus)
+		metrics.Flush()
+		return nil
+	})
+
 	stackManifest, componentsManifests, chosenManifestFilename, err := manifest.ParseManifest(request.ManifestFilenames)
 	if err != nil {
 		log.Fatalf("Unable to %s: %s", request.Verb, err)
 	}
+	operationSpan.SetAttribute("hub.stack", stackManifest.Meta.Name)
+	metricsStack = stackManifest.Meta.Name
+
+	checkSimulationState(request)
+	simulation := startSimulation(request)
+	if id := simulation.operationId(operationLogId); id != operationLogId {
+		operationLogId = id
+		operationSpan.SetAttribute("hub.operation.id", operationLogId)
+	}
+
+	events := startEvents(request.Events, request.EventsOutput, operationLogId,
+		maybeTestVerb(request.Verb, request.DryRun), stackManifest.Meta.Name)
+	notifications := startNotifications(stackManifest.Lifecycle.Notifications, operationLogId,
+		maybeTestVerb(request.Verb, request.DryRun), stackManifest.Meta.Name)
 
 	if pipe != nil {
 		metricTags := fmt.Sprintf("stack:%s", stackManifest.Meta.Name)
@@ -56,6 +94,10 @@ func Execute(request *Request, pipe io.WriteCloser) {
 	}
 
 	components := stackManifest.Components
+	setRequiresBinaries(stackManifest.Lifecycle.Requires.Binaries)
+	setToolchain(stackManifest.Toolchain, stackBaseDir)
+	setSandbox(request.SandboxMode, stackManifest.Lifecycle.Sandbox, stackBaseDir)
+	setTemplateComponentsDirs(components, stackBaseDir, componentsBaseDir)
 	checkComponentsManifests(components, componentsManifests)
 	checkLifecycleOrder(components, stackManifest.Lifecycle)
 	checkLifecycleRequires(components, stackManifest.Lifecycle.Requires)
@@ -81,7 +123,6 @@ func Execute(request *Request, pipe io.WriteCloser) {
 	var stateManifest *state.StateManifest
 	var operationsHistory []state.LifecycleOperation
 	stateUpdater := func(interface{}) {}
-	var operationLogId string
 	if len(request.StateFilenames) > 0 {
 		stateFiles, errs := storage.Check(request.StateFilenames, "state")
 		if len(errs) > 0 {
@@ -122,13 +163,16 @@ func Execute(request *Request, pipe io.WriteCloser) {
 		if request.SyncStackInstance && request.StackInstance != "" {
 			syncer = hubSyncer(request)
 		}
-		stateUpdater = state.InitWriter(stateFiles, syncer)
-
-		u, err := uuid.NewRandom()
-		if err != nil {
-			log.Fatalf("Unable to generate operation Id random v4 UUID: %v", err)
+		if events != nil {
+			hubSyncer := syncer
+			syncer = func(written *state.StateManifest) {
+				events.StateWritten(stateFiles)
+				if hubSyncer != nil {
+					hubSyncer(written)
+				}
+			}
 		}
-		operationLogId = u.String()
+		stateUpdater = state.InitWriter(stateFiles, syncer)
 	}
 
 	deploymentIdParameterName := "hub.deploymentId"
@@ -146,7 +190,7 @@ func Execute(request *Request, pipe io.WriteCloser) {
 		if err != nil {
 			log.Fatalf("Unable to generate `hub.deploymentId` random v4 UUID: %v", err)
 		}
-		deploymentId = u.String()
+		deploymentId = simulation.operationId(u.String())
 	}
 	plainStackNameParameterName := "hub.stackName"
 	plainStackName := util.PlainName(stackManifest.Meta.Name)
@@ -241,17 +285,81 @@ func Execute(request *Request, pipe io.WriteCloser) {
 		stateManifest = state.UpdateOperation(stateManifest, operationLogId, request.Verb, "in-progress",
 			map[string]interface{}{"args": os.Args})
 	}
+	events.OperationStart(order)
+	logs := startOperationLogs(request, stackBaseDir, operationLogId)
+	if stateManifest != nil && logs != nil {
+		stateManifest = state.UpdateOperationLogDir(stateManifest, operationLogId, logs.Dir(), logs.Remote())
+	}
+	var rendered *renderedCache
+	if isDeploy && !request.DryRun {
+		rendered = startRenderedCache(request, stackBaseDir, operationLogId)
+	}
 
 	ctx := watchInterrupt()
 
+	stackExec := &execContext{
+		name:       stackManifest.Meta.Name,
+		dir:        stackBaseDir,
+		parameters: stackParameters,
+		outputs:    allOutputs,
+		osEnv:      osEnv,
+	}
+	runStackHooks := func(phase, message string) error {
+		if request.DryRun {
+			return nil
+		}
+		stackExec.outputs = allOutputs
+		return runHooks(&stackManifest.Lifecycle.Hooks, phase, stackExec, message)
+	}
+	stackFailureHooksDone := false
+	runStackFailureHooks := func(message string) {
+		if stackFailureHooksDone {
+			return
+		}
+		stackFailureHooksDone = true
+		if err := runStackHooks(hookOnFailure, message); err != nil {
+			util.Warn("%v", err)
+		}
+	}
+	stackFailed := func(message string) {
+		runStackFailureHooks(message)
+		if stateManifest != nil {
+			stateManifest = state.UpdateStackStatus(stateManifest, "incomplete", message)
+			stateManifest = state.UpdateOperation(stateManifest, operationLogId, request.Verb, "error", nil)
+			stateUpdater(stateManifest)
+		}
+		events.StackStatus("incomplete", message)
+		events.OperationEnd("error", message)
+		operationSpan.SetError(errors.New(message))
+		notifications.Failure("", message)
+		util.MaybeFatalf("%s", message)
+	}
+
+	progress := startProgress(maybeTestVerb(request.Verb, request.DryRun), stackManifest.Meta.Name, order)
+
+	if err := runStackHooks(hookPhase(request.Verb, "pre"), ""); err != nil {
+		stackFailed(fmt.Sprintf("Stack %s hook failed: %v", hookPhase(request.Verb, "pre"), err))
+	}
+
 NEXT_COMPONENT:
 	for componentIndex, componentName := range order {
 		if skipComponent(componentIndex, componentName) {
 			if config.Debug {
 				log.Printf("Skip %s", componentName)
 			}
+			progress.Skip(componentName)
+			events.ComponentSkip(componentName, "not selected")
 			continue
 		}
+		progress.Start(componentName)
+		componentStart := time.Now()
+		componentMetrics := func(status string) {
+			tags := []string{"stack", metricsStack, "verb", request.Verb, "component", componentName}
+			metrics.Timing("hub_component_duration_seconds", time.Since(componentStart), append(tags, "status", status)...)
+			if status == "error" {
+				metrics.Count("hub_component_failures_total", 1, tags...)
+			}
+		}
 
 		if config.Verbose {
 			log.Printf(util.HighlightColor("%s ***%s*** (%d/%d)"), maybeTestVerb(request.Verb, request.DryRun),
@@ -270,10 +378,45 @@ NEXT_COMPONENT:
 				allOutputs)
 		}
 
-		var updateStateComponentFailed func(string, bool)
+		componentDir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
+		componentExec := &execContext{
+			name:       componentName,
+			component:  true,
+			dir:        componentDir,
+			parameters: stackParameters,
+			outputs:    allOutputs,
+			depends:    component.Depends,
+			osEnv:      osEnv,
+		}
+		runComponentHooks := func(phase, message string) error {
+			if request.DryRun {
+				return nil
+			}
+			return runHooks(&componentManifest.Lifecycle.Hooks, phase, componentExec, message)
+		}
+
 		if stateManifest != nil {
 			stateManifest = state.UpdateComponentStartTimestamp(stateManifest, componentName)
-			updateStateComponentFailed = func(msg string, final bool) {
+		}
+		componentFailed := func(msg string, final bool) {
+			progress.Fail(componentName)
+			events.ComponentEnd(componentName, "error", msg)
+			componentMetrics("error")
+			if !config.Force && !optionalComponent(&stackManifest.Lifecycle, componentName) {
+				events.StackStatus("incomplete", msg)
+			}
+			if final {
+				events.OperationEnd("error", msg)
+				operationSpan.SetError(errors.New(msg))
+				notifications.Failure(componentName, msg)
+			}
+			if err := runComponentHooks(hookOnFailure, msg); err != nil {
+				util.Warn("%v", err)
+			}
+			if final {
+				runStackFailureHooks(msg)
+			}
+			if stateManifest != nil {
 				stateManifest = state.UpdateComponentStatus(stateManifest, componentName, &componentManifest.Meta, "error", msg)
 				stateManifest = state.UpdatePhase(stateManifest, operationLogId, componentName, "error")
 				// Erasing provides of a failed component on redeploy has undesirable effect on undeploy, for example:
@@ -304,7 +447,7 @@ NEXT_COMPONENT:
 				maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
 					fmt.Sprintf("Component `%s` failed to %s: depends on failed optional component `%s`",
 						componentName, request.Verb, strings.Join(failed, ", ")),
-					updateStateComponentFailed)
+					componentFailed)
 				failedComponents = append(failedComponents, componentName)
 				continue NEXT_COMPONENT
 			}
@@ -318,6 +461,8 @@ NEXT_COMPONENT:
 		optionalParametersFalse := calculateOptionalFalseParameters(componentName, allParameters, optionalRequires)
 		if len(optionalParametersFalse) > 0 {
 			log.Printf("Surprisingly, let skip `%s` due to optional parameter %v evaluated to false", componentName, optionalParametersFalse)
+			progress.Skip(componentName)
+			events.ComponentSkip(componentName, fmt.Sprintf("optional parameter %v evaluated to false", optionalParametersFalse))
 			if stateManifest != nil {
 				stateManifest = state.EraseComponentEmptyState(stateManifest, componentName)
 			}
@@ -328,12 +473,13 @@ NEXT_COMPONENT:
 			maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
 				fmt.Sprintf("Component `%s` parameters expansion failed:\n\t%s",
 					componentName, util.Errors("\n\t", expansionErrs...)),
-				updateStateComponentFailed)
+				componentFailed)
 			failedComponents = append(failedComponents, componentName)
 			continue NEXT_COMPONENT
 		}
 
 		componentParameters := parameters.MergeParameters(make(parameters.LockedParameters), expandedComponentParameters)
+		componentExec.parameters = componentParameters
 
 		if optionalNotProvided, err := prepareComponentRequires(provides, componentManifest, allParameters, allOutputs, optionalRequires, request.EnabledClouds); len(optionalNotProvided) > 0 || err != nil {
 			if err != nil {
@@ -341,18 +487,27 @@ NEXT_COMPONENT:
 					// proceed without --force set to handle required component (depends on) being already undeployed via --component
 					util.Warn("%v", err)
 				} else {
-					maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName, fmt.Sprintf("%v", err), updateStateComponentFailed)
+					maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName, fmt.Sprintf("%v", err), componentFailed)
 					continue NEXT_COMPONENT
 				}
 			}
 			if len(optionalNotProvided) > 0 {
 				log.Printf("Skip %s due to unsatisfied optional requirements %v", componentName, optionalNotProvided)
+				progress.Skip(componentName)
+				events.ComponentSkip(componentName, fmt.Sprintf("unsatisfied optional requirements %v", optionalNotProvided))
 				// there will be a gap in state file but `deploy -c` will be able to find some state from
 				// a preceding component
 				continue NEXT_COMPONENT
 			}
 		}
 
+		logFile := logs.Open(componentName, maybeTestVerb(request.Verb, request.DryRun))
+		status := fmt.Sprintf("%sing", request.Verb)
+		events.ComponentStart(componentName, status)
+		notifications.Secrets(expandedComponentParameters)
+		if isDeploy {
+			events.ParametersLocked(componentName, expandedComponentParameters)
+		}
 		if stateManifest != nil {
 			if isDeploy {
 				stateManifest = state.UpdateState(stateManifest, componentName,
@@ -361,10 +516,12 @@ NEXT_COMPONENT:
 					noEnvironmentProvides(provides),
 					false)
 			}
-			status := fmt.Sprintf("%sing", request.Verb)
 			stateManifest = state.UpdateComponentStatus(stateManifest, componentName, &componentManifest.Meta, status, "")
 			stateManifest = state.UpdateStackStatus(stateManifest, status, "")
 			stateManifest = state.UpdatePhase(stateManifest, operationLogId, componentName, "in-progress")
+			if logFile != "" {
+				stateManifest = state.UpdatePhaseLogFile(stateManifest, operationLogId, componentName, logFile)
+			}
 			stateUpdater(stateManifest)
 			stateUpdater("sync")
 		}
@@ -379,10 +536,13 @@ NEXT_COMPONENT:
 		if err != nil {
 			util.Warn("Unable to set %s: %v", HubEnvVarNameRandom, err)
 		}
-		componentDir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
-		stdout, stderr, err := delegate(maybeTestVerb(request.Verb, request.DryRun),
-			component, componentManifest, componentParameters,
-			componentDir, osEnv, randomStr)
+		var stdout, stderr []byte
+		err = runComponentHooks(hookPhase(request.Verb, "pre"), "")
+		if err == nil {
+			stdout, stderr, err = delegate(maybeTestVerb(request.Verb, request.DryRun),
+				component, componentManifest, componentParameters, dependsOutputs(allOutputs, component.Depends),
+				componentDir, osEnv, randomStr)
+		}
 
 		var rawOutputs parameters.RawOutputs
 		if err != nil {
@@ -392,7 +552,7 @@ NEXT_COMPONENT:
 			}
 			maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
 				fmt.Sprintf("Component `%s` failed to %s: %v", componentName, request.Verb, err),
-				updateStateComponentFailed)
+				componentFailed)
 			failedComponents = append(failedComponents, componentName)
 		} else if isDeploy {
 			rawOutputsCaptured, componentOutputs, dynamicProvides, errs :=
@@ -404,7 +564,7 @@ NEXT_COMPONENT:
 				maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
 					fmt.Sprintf("Component `%s` outputs capture failed:\n\t%s",
 						componentName, util.Errors("\n\t", errs...)),
-					updateStateComponentFailed)
+					componentFailed)
 				failedComponents = append(failedComponents, componentName)
 			}
 			if len(componentOutputs) > 0 &&
@@ -435,9 +595,28 @@ NEXT_COMPONENT:
 			}
 			parameters.MergeOutputs(allOutputs, componentComplexOutputs)
 
-			mergeProvides(provides, componentName, append(dynamicProvides, componentManifest.Provides...), componentOutputs)
+			componentProvides := append(dynamicProvides, componentManifest.Provides...)
+			errs = checkProvidedOutputs(stackManifest.Lifecycle.Requires.Outputs, componentName, componentProvides,
+				componentOutputs, componentComplexOutputs)
+			if len(errs) > 0 && !util.Contains(failedComponents, componentName) {
+				log.Printf("Component `%s` failed to %s", componentName, request.Verb)
+				maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
+					fmt.Sprintf("Component `%s` provided outputs check failed:\n\t%s",
+						componentName, util.Errors("\n\t", errs...)),
+					componentFailed)
+				failedComponents = append(failedComponents, componentName)
+			}
+
+			events.OutputsCaptured(componentName, componentOutputs, componentComplexOutputs)
+			notifications.Secrets(nil, componentOutputs, componentComplexOutputs)
+
+			providesBefore := util.CopyMap2(provides)
+			mergeProvides(provides, componentName, componentProvides, componentOutputs)
+			events.ProvidesChanged(componentName, providesBefore, provides)
 		} else if isUndeploy {
+			providesBefore := util.CopyMap2(provides)
 			eraseProvides(provides, componentName)
+			events.ProvidesChanged(componentName, providesBefore, provides)
 			if stateManifest != nil {
 				stateManifest.Provides = noEnvironmentProvides(provides)
 			}
@@ -456,12 +635,29 @@ NEXT_COMPONENT:
 		}
 
 		if err == nil && isDeploy {
-			err = waitForReadyConditions(ctx, componentManifest.Lifecycle.ReadyConditions, componentParameters, allOutputs, component.Depends)
+			var readyStatuses []state.ReadyConditionStatus
+			readyStatuses, err = waitForReadyConditions(ctx, componentManifest.Lifecycle.ReadyConditions, componentExec)
+			events.ReadyConditions(componentName, readyStatuses)
+			if stateManifest != nil && len(readyStatuses) > 0 {
+				stateManifest = state.UpdateReadyConditions(stateManifest, componentName, readyStatuses)
+			}
 			if err != nil {
 				log.Printf("Component `%s` failed to %s", componentName, request.Verb)
 				maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
 					fmt.Sprintf("Component `%s` ready condition failed: %v", componentName, err),
-					updateStateComponentFailed)
+					componentFailed)
+				failedComponents = append(failedComponents, componentName)
+			}
+		}
+
+		if err == nil && !util.Contains(failedComponents, componentName) {
+			phase := hookPhase(request.Verb, "post")
+			err = runComponentHooks(phase, "")
+			if err != nil {
+				log.Printf("Component `%s` failed to %s", componentName, request.Verb)
+				maybeFatalIfMandatory(&stackManifest.Lifecycle, componentName,
+					fmt.Sprintf("Component `%s` %s hook failed: %v", componentName, phase, err),
+					componentFailed)
 				failedComponents = append(failedComponents, componentName)
 			}
 		}
@@ -469,7 +665,15 @@ NEXT_COMPONENT:
 		if err == nil && config.Verbose {
 			log.Printf("Component `%s` completed %s", componentName, request.Verb)
 		}
+		logs.Close(componentName)
+		if !util.Contains(failedComponents, componentName) {
+			progress.Done(componentName)
+		}
 
+		if !util.Contains(failedComponents, componentName) {
+			events.ComponentEnd(componentName, fmt.Sprintf("%sed", request.Verb), "")
+			componentMetrics("success")
+		}
 		if stateManifest != nil {
 			if !util.Contains(failedComponents, componentName) {
 				stateManifest = state.UpdateComponentStatus(stateManifest, componentName, &componentManifest.Meta,
@@ -482,30 +686,67 @@ NEXT_COMPONENT:
 		// end of component cycle
 	}
 
-	stackReadyConditionFailed := false
+	stackCheckFailed := false
 	if isDeploy {
-		err := waitForReadyConditions(ctx, stackManifest.Lifecycle.ReadyConditions, stackParameters, allOutputs, nil)
+		stackExec.outputs = allOutputs
+		readyStatuses, err := waitForReadyConditions(ctx, stackManifest.Lifecycle.ReadyConditions, stackExec)
+		events.ReadyConditions("", readyStatuses)
+		if stateManifest != nil && len(readyStatuses) > 0 {
+			stateManifest = state.UpdateReadyConditions(stateManifest, "", readyStatuses)
+		}
 		if err != nil {
-			message := fmt.Sprintf("Stack ready condition failed: %v", err)
-			if stateManifest != nil {
-				stateManifest = state.UpdateStackStatus(stateManifest, "incomplete", message)
-				stateManifest = state.UpdateOperation(stateManifest, operationLogId, request.Verb, "error", nil)
-				stateUpdater(stateManifest)
-			}
-			util.MaybeFatalf("%s", message)
-			stackReadyConditionFailed = true
+			stackFailed(fmt.Sprintf("Stack ready condition failed: %v", err))
+			stackCheckFailed = true
+		}
+	}
+	if isDeploy && !stackCheckFailed && len(failedComponents) == 0 && ctx.Err() == nil &&
+		request.LimitComponent == "" && (len(request.Components) == 0 || request.LoadFinalState) {
+		expanded := parameters.ExpandRequestedOutputs(stackParameters, allOutputs, stackManifest.Outputs, false)
+		errs := parameters.AssertRequestedOutputs(stackParameters, allOutputs, stackManifest.Outputs, expanded)
+		if len(errs) > 0 {
+			stackFailed(fmt.Sprintf("Stack outputs assertion failed:\n\t%s", util.Errors("\n\t", errs...)))
+			stackCheckFailed = true
 		}
 	}
+	if !stackCheckFailed && len(failedComponents) == 0 && ctx.Err() == nil {
+		phase := hookPhase(request.Verb, "post")
+		if err := runStackHooks(phase, ""); err != nil {
+			stackFailed(fmt.Sprintf("Stack %s hook failed: %v", phase, err))
+			stackCheckFailed = true
+		}
+	}
+	if len(failedComponents) > 0 {
+		runStackFailureHooks(fmt.Sprintf("Failed to %s %s", request.Verb, strings.Join(failedComponents, ", ")))
+	}
 
 	if stateManifest != nil {
-		if !stackReadyConditionFailed {
+		if !stackCheckFailed {
 			status, message := calculateStackStatus(stackManifest, stateManifest, request.Verb)
 			stateManifest = state.UpdateStackStatus(stateManifest, status, message)
 			stateManifest = state.UpdateOperation(stateManifest, operationLogId, request.Verb, "success", nil)
 			stateUpdater(stateManifest)
+			events.StackStatus(status, message)
 		}
 		stateUpdater("sync")
 	}
+	if !stackCheckFailed {
+		if len(failedComponents) == 0 {
+			operationStatus = "success"
+			events.OperationEnd("success", "")
+			notifications.Success()
+		} else {
+			// --force or optional components: the operation completed, but not cleanly
+			message := fmt.Sprintf("Failed to %s %s", request.Verb, strings.Join(failedComponents, ", "))
+			events.OperationEnd("error", message)
+			notifications.Failure(failedComponents[0], message)
+		}
+	}
+	logs.Stop()
+	rendered.Stop()
+	simulation.Stop()
+	progress.Stop()
+	events.Stop()
+	notifications.Stop()
 
 	var stackOutputs []parameters.ExpandedOutput
 	if stateManifest != nil {
@@ -588,9 +829,22 @@ func maybeTestVerb(verb string, test bool) string {
 	return verb
 }
 
+// dependsOutputs selects outputs of the components listed in `depends`
+func dependsOutputs(outputs parameters.CapturedOutputs, depends []string) parameters.CapturedOutputs {
+	selected := make(parameters.CapturedOutputs)
+	for key, output := range outputs {
+		if util.Contains(depends, output.Component) {
+			selected[key] = output
+		}
+	}
+	return selected
+}
+
+// delegate renders templates, with parameters and outputs of `depends` components bound,
+// and runs the component verb
 func delegate(verb string, component *manifest.ComponentRef, componentManifest *manifest.Manifest,
-	componentParameters parameters.LockedParameters,
-	dir string, osEnv []string, random string) ([]byte, []byte, error) {
+	componentParameters parameters.LockedParameters, componentDependsOutputs parameters.CapturedOutputs,
+	dir string, osEnv []string, random string) (stdout []byte, stderr []byte, err error) {
 
 	if config.Debug && len(componentParameters) > 0 {
 		log.Print("Component parameters:")
@@ -598,12 +852,21 @@ func delegate(verb string, component *manifest.ComponentRef, componentManifest *
 	}
 
 	componentName := manifest.ComponentQualifiedNameFromRef(component)
-	errs := processTemplates(component, &componentManifest.Templates, componentParameters, nil, dir)
+	span := tracing.Start(fmt.Sprintf("%s %s", verb, componentName), "hub.component", componentName, "hub.verb", verb)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
+	errs := processTemplates(component, &componentManifest.Templates, componentParameters, componentDependsOutputs, dir)
 	if len(errs) > 0 {
 		return nil, nil, fmt.Errorf("Failed to process templates:\n\t%s", util.Errors("\n\t", errs...))
 	}
 
 	processEnv := parametersInEnv(componentName, componentParameters)
+	if activeSimulation != nil {
+		return activeSimulation.delegate(verb, componentName, componentManifest)
+	}
 	impl, err := findImplementation(dir, verb)
 	if err != nil {
 		if componentManifest.Lifecycle.Bare == "allow" {
@@ -615,7 +878,7 @@ func delegate(verb string, component *manifest.ComponentRef, componentManifest *
 		return nil, nil, err
 	}
 	skaffoldEnvironment := skaffoldEnv(impl, processEnv)
-	impl.Env = mergeOsEnviron(osEnv, processEnv, randomEnv(random), skaffoldEnvironment)
+	impl.Env = mergeOsEnviron(osEnv, toolchainEnv(osEnv), tracing.Env(), processEnv, randomEnv(random), skaffoldEnvironment)
 	if config.Debug && len(processEnv) > 0 {
 		log.Print("Component environment:")
 		printEnvironment(processEnv)
@@ -626,8 +889,19 @@ func delegate(verb string, component *manifest.ComponentRef, componentManifest *
 		}
 	}
 
-	stdout, stderr, err := execImplementation(impl, false, true)
-	return stdout, stderr, err
+	if image := componentManifest.Lifecycle.Image; image != "" {
+		impl, err = containerImplementation(impl, image, componentManifest.Lifecycle.Container)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to run `%s` in container: %v", componentName, err)
+		}
+	} else {
+		impl, err = sandboxImplementation(impl, componentName, componentManifest.Lifecycle.Sandbox)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to run `%s` in sandbox: %v", componentName, err)
+		}
+	}
+
+	return execImplementation(impl, false, true, componentName)
 }
 
 func randomEnv(random string) []string {
@@ -0,0 +1,424 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/aws"
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/crypto"
+	"github.com/agilestacks/hub/cmd/hub/ext"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/toolchain"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	DoctorPass = "pass"
+	DoctorWarn = "warn"
+	DoctorFail = "fail"
+)
+
+type DoctorRequest struct {
+	ManifestFilenames []string
+	StateFilenames    []string
+	ComponentsBaseDir string
+	EnabledClouds     []string
+	Extensions        []string
+}
+
+type DoctorCheck struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+var doctorBinHints = map[string]string{
+	"aws":       "https://docs.aws.amazon.com/cli/latest/userguide/install-cliv2.html",
+	"az":        "https://docs.microsoft.com/en-us/cli/azure/install-azure-cli",
+	"gcloud":    "https://cloud.google.com/sdk/docs/install",
+	"gsutil":    "https://cloud.google.com/sdk/docs/install",
+	"kubectl":   "https://kubernetes.io/docs/tasks/tools/",
+	"helm":      "https://helm.sh/docs/intro/install/",
+	"terraform": "https://www.terraform.io/downloads.html",
+	"vault":     "https://www.vaultproject.io/downloads",
+}
+
+func Doctor(request *DoctorRequest) []DoctorCheck {
+	checks := make([]DoctorCheck, 0)
+	add := func(category, name, status, message, hint string) {
+		checks = append(checks, DoctorCheck{Category: category, Name: name, Status: status, Message: message, Hint: hint})
+	}
+
+	add("hub", "version", DoctorPass, fmt.Sprintf("Hub CLI %s %s", util.CliVersion, runtime.Version()), "")
+
+	requires := make([]string, 0)
+	tools := []string{"make"}
+	manifestParsed := false
+	if len(request.ManifestFilenames) > 0 {
+		stackManifest, componentsManifests, _, err := manifest.ParseManifest(request.ManifestFilenames)
+		if err != nil {
+			add("manifest", strings.Join(request.ManifestFilenames, ", "), DoctorFail, err.Error(),
+				"Run `hub elaborate hub.yaml -o hub.yaml.elaborate` to assemble the stack manifest")
+		} else {
+			manifestParsed = true
+			add("manifest", strings.Join(request.ManifestFilenames, ", "), DoctorPass,
+				fmt.Sprintf("Stack `%s` with %d %s", stackManifest.Meta.Name,
+					len(stackManifest.Components), util.Plural(len(stackManifest.Components), "component")), "")
+			requires = append(requires, stackManifest.Requires...)
+			for _, componentManifest := range componentsManifests {
+				requires = append(requires, componentManifest.Requires...)
+			}
+			requires = maybeOmitCloudRequires(util.Uniq(requires), request.EnabledClouds)
+			setRequiresBinaries(stackManifest.Lifecycle.Requires.Binaries)
+
+			stackBaseDir := util.Basedir(request.ManifestFilenames)
+			componentsBaseDir := request.ComponentsBaseDir
+			if componentsBaseDir == "" {
+				componentsBaseDir = stackBaseDir
+			}
+			doctorToolchain(stackManifest.Toolchain, stackBaseDir, add)
+			tools = doctorComponents(stackManifest, stackBaseDir, componentsBaseDir, add)
+		}
+	}
+
+	bins := make([]string, 0)
+	for _, require := range requires {
+		name := manifest.RequirementName(require)
+		if _, exist := binVersionCommands[name]; exist {
+			bins = append(bins, name)
+		}
+	}
+	for _, tool := range tools {
+		if !util.Contains(bins, tool) {
+			bins = append(bins, tool)
+		}
+	}
+	if !manifestParsed {
+		// nothing is known about the stack - check common tools, but don't fail
+		bins = append(bins, "kubectl", "helm", "terraform", "vault", "aws", "gcp", "azure")
+	}
+	checked := make(map[string]struct{})
+	for _, bin := range bins {
+		cmd := binVersionCommands[bin]
+		if _, seen := checked[cmd[0]]; seen {
+			continue
+		}
+		checked[cmd[0]] = struct{}{}
+		status, message, hint := doctorBinary(cmd)
+		if status == DoctorFail && !manifestParsed {
+			status = DoctorWarn
+		}
+		add("binary", cmd[0], status, message, hint)
+	}
+
+	for _, require := range requires {
+		name, constraint := manifest.ParseRequirement(require)
+		if _, declared := requiresBinaries[name]; constraint == "" && !declared {
+			continue
+		}
+		if version, err := checkRequireVersion(name, constraint); err != nil {
+			add("requirement", require, DoctorFail, err.Error(),
+				"Install required version or adjust `requires` and `lifecycle.requires.binaries`")
+		} else {
+			add("requirement", require, DoctorPass, fmt.Sprintf("Version %s", version), "")
+		}
+	}
+
+	clouds := make([]string, 0)
+	for _, require := range requires {
+		if name := manifest.RequirementName(require); util.Contains(supportedCloudRequires, name) {
+			clouds = append(clouds, name)
+		}
+	}
+	if !manifestParsed {
+		clouds = request.EnabledClouds
+		if len(clouds) == 0 {
+			clouds = guessEnabledClouds()
+		}
+	}
+	for _, cloud := range util.Uniq(clouds) {
+		status, message, hint := doctorCloud(cloud)
+		add("cloud", cloud, status, message, hint)
+	}
+
+	if !manifestParsed || requiresKubernetes(requires) {
+		status, message, hint := doctorKubeContext()
+		if status == DoctorFail && !manifestParsed {
+			status = DoctorWarn
+		}
+		add("kubernetes", "context", status, message, hint)
+	}
+
+	if len(request.Extensions) > 0 {
+		found := make([]string, 0, len(request.Extensions))
+		missing := make([]string, 0)
+		for _, extension := range request.Extensions {
+			if _, _, err := ext.ExtensionPath([]string{extension}, nil); err != nil {
+				missing = append(missing, extension)
+			} else {
+				found = append(found, extension)
+			}
+		}
+		if len(missing) > 0 {
+			add("extension", "extensions", DoctorWarn, fmt.Sprintf("Not found: %s", strings.Join(missing, ", ")),
+				"Install extensions with `hub extensions install` or set HUB_EXTENSIONS")
+		} else {
+			add("extension", "extensions", DoctorPass, fmt.Sprintf("Found: %s", strings.Join(found, ", ")), "")
+		}
+	}
+
+	if len(request.StateFilenames) > 0 {
+		doctorState(request.StateFilenames, add)
+	}
+
+	doctorEncryption(len(request.StateFilenames) > 0, add)
+
+	return checks
+}
+
+func requiresKubernetes(requires []string) bool {
+	for _, require := range requires {
+		if name := manifest.RequirementName(require); name == "kubectl" || name == "kubernetes" {
+			return true
+		}
+	}
+	return false
+}
+
+func doctorComponents(stackManifest *manifest.Manifest, stackBaseDir, componentsBaseDir string,
+	add func(category, name, status, message, hint string)) []string {
+
+	tools := make([]string, 0)
+	for i := range stackManifest.Components {
+		component := &stackManifest.Components[i]
+		componentName := manifest.ComponentQualifiedNameFromRef(component)
+		dir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
+		if _, err := os.Stat(dir); err != nil {
+			add("component", componentName, DoctorFail, fmt.Sprintf("Source directory: %v", err),
+				"Check `components.source.dir` or set --base-dir")
+			continue
+		}
+		impl, err := findImplementation(dir, "deploy")
+		if err != nil {
+			add("component", componentName, DoctorFail, err.Error(),
+				"Add Makefile, `deploy` script, Helm chart, Kustomize, Skaffold, or Terraform sources")
+			continue
+		}
+		tool := filepath.Base(impl.Path)
+		if len(impl.Args) > 0 {
+			tool = filepath.Base(impl.Args[0])
+		}
+		if _, known := binVersionCommands[tool]; known {
+			tools = util.MergeUnique(tools, []string{tool})
+		} else {
+			tool = "script"
+		}
+		add("component", componentName, DoctorPass, fmt.Sprintf("Implemented by %s in `%s`", tool, dir), "")
+	}
+	return tools
+}
+
+func doctorToolchain(stackToolchain manifest.Toolchain, stackBaseDir string,
+	add func(category, name, status, message, hint string)) {
+
+	dirs, missing := toolchain.Path(stackToolchain, stackBaseDir)
+	toolchainPath = dirs
+	for _, tool := range stackToolchain.Tools {
+		name := fmt.Sprintf("%s %s", tool.Name, tool.Version)
+		installed := true
+		for _, m := range missing {
+			if m.Name == tool.Name {
+				installed = false
+				break
+			}
+		}
+		if installed {
+			add("toolchain", name, DoctorPass, "Installed in "+toolchain.CacheDir(stackToolchain, stackBaseDir), "")
+		} else {
+			add("toolchain", name, DoctorFail, "Not installed", "Run `hub tools install`")
+		}
+	}
+}
+
+func doctorBinary(cmd []string) (string, string, string) {
+	bin := cmd[0]
+	hint := doctorBinHints[bin]
+	if hint != "" {
+		hint = "Install from " + hint
+	}
+	path, err := toolchainLookPath(bin)
+	if err != nil {
+		return DoctorFail, fmt.Sprintf("Not found in PATH: %v", err), hint
+	}
+	out, err := checkRequiresBin(cmd...)
+	if err != nil {
+		return DoctorFail, fmt.Sprintf("%s: %v", path, err), hint
+	}
+	if verReq, exist := binVersion[bin]; exist {
+		if err := checkRequiresBinVersion(verReq.minVersion, verReq.versionRegexp, out); err != nil {
+			return DoctorWarn, fmt.Sprintf("%s: %v", path, err),
+				fmt.Sprintf("Update `%s` to version %s or later", bin, verReq.minVersion)
+		}
+	}
+	version := ""
+	if verReq, exist := binVersion[bin]; exist {
+		if match := verReq.versionRegexp.FindSubmatch(out); len(match) == 2 {
+			version = string(match[1])
+		}
+	}
+	if version == "" {
+		if match := anyVersionRegexp.FindSubmatch(out); len(match) == 2 {
+			version = string(match[1])
+		}
+	}
+	if version != "" {
+		return DoctorPass, fmt.Sprintf("%s version %s", path, version), ""
+	}
+	return DoctorPass, path, ""
+}
+
+func doctorCloud(cloud string) (string, string, string) {
+	switch cloud {
+	case "aws":
+		creds, err := aws.DefaultCredentials("doctor").Get()
+		if err != nil {
+			return DoctorFail, fmt.Sprintf("No AWS credentials: %v", err),
+				"Set AWS_PROFILE, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or run `aws configure`"
+		}
+		return DoctorPass, fmt.Sprintf("AWS credentials from %s", creds.ProviderName), ""
+	case "azure":
+		if err := checkRequiresAzure(); err != nil {
+			return DoctorFail, err.Error(), fmt.Sprintf("Run `az login` or see %s", azureGoSdkAuthHelp)
+		}
+		return DoctorPass, "Azure CLI is logged in", ""
+	case "gcp", "gcs":
+		if err := checkRequiresGcp(); err != nil {
+			return DoctorFail, err.Error(), fmt.Sprintf("Run `gcloud auth login` or see %s", gcpServiceAccountsHelp)
+		}
+		return DoctorPass, "Google Cloud SDK is authenticated", ""
+	}
+	return DoctorWarn, "Don't know how to check credentials", ""
+}
+
+func doctorKubeContext() (string, string, string) {
+	hint := "Use `hub kubeconfig hub.yaml.state` or `kubectl config use-context`"
+	kubectl, err := toolchainLookPath("kubectl")
+	if err != nil {
+		return DoctorFail, "`kubectl` not found in toolchain or PATH", doctorBinHints["kubectl"]
+	}
+	out, err := exec.Command(kubectl, "config", "current-context").Output()
+	context := strings.TrimSpace(string(out))
+	if err != nil || context == "" {
+		return DoctorFail, "No current Kubeconfig context", hint
+	}
+	out, err = exec.Command(kubectl, "config", "get-contexts", "-o", "name").Output()
+	contexts := "(unknown)"
+	if err == nil {
+		list := strings.Fields(string(out))
+		sort.Strings(list)
+		contexts = strings.Join(list, ", ")
+	}
+	return DoctorPass, fmt.Sprintf("Current context `%s`; available: %s", context, contexts), ""
+}
+
+func doctorState(filenames []string, add func(category, name, status, message, hint string)) {
+	files, errs := storage.Check(filenames, "state")
+	for _, err := range errs {
+		add("state", strings.Join(filenames, ", "), DoctorFail, err.Error(), "Check state file path and cloud storage access")
+	}
+	if files == nil {
+		return
+	}
+	exist := false
+	for _, file := range files.Files {
+		switch {
+		case file.Locked:
+			add("state", file.Path, DoctorWarn, "Lock file exists",
+				fmt.Sprintf("Another operation is in progress or crashed; remove `%s.lock` if stale", file.Path))
+		case file.Exist:
+			exist = true
+			add("state", file.Path, DoctorPass, fmt.Sprintf("Accessible, modified %v", file.ModTime), "")
+		default:
+			add("state", file.Path, DoctorPass, "Accessible, does not exist yet", "")
+		}
+	}
+	if exist {
+		if _, err := state.ParseState(files); err != nil {
+			add("state", "parse", DoctorFail, err.Error(), "State may be encrypted with a different key")
+		}
+	}
+}
+
+func doctorEncryption(stateRequested bool, add func(category, name, status, message, hint string)) {
+	hint := "Set HUB_CRYPTO_PASSWORD, HUB_CRYPTO_AWS_KMS_KEY_ARN, or HUB_CRYPTO_AZURE_KEYVAULT_KEY_ID"
+	if config.CryptoPassword == "" && config.CryptoAwsKmsKeyArn == "" && config.CryptoAzureKeyVaultKeyId == "" {
+		status := DoctorPass
+		if stateRequested {
+			status = DoctorWarn
+		}
+		add("encryption", "key", status, "No encryption key is configured - state and backups are stored in clear", hint)
+		return
+	}
+	encrypted, err := crypto.Encrypt([]byte("hub doctor"))
+	if err == nil {
+		_, err = crypto.Decrypt(encrypted)
+	}
+	if err != nil {
+		add("encryption", "key", DoctorFail, fmt.Sprintf("Unable to encrypt / decrypt: %v", err), hint)
+		return
+	}
+	add("encryption", "key", DoctorPass, "Encryption key is usable", "")
+}
+
+func DoctorFailed(checks []DoctorCheck) bool {
+	for _, check := range checks {
+		if check.Status == DoctorFail {
+			return true
+		}
+	}
+	return false
+}
+
+func PrintDoctorReport(checks []DoctorCheck, jsonFormat bool) {
+	if jsonFormat {
+		out, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			util.MaybeFatalf("Unable to marshal report into JSON: %v", err)
+		}
+		os.Stdout.Write(out)
+		os.Stdout.Write([]byte("\n"))
+		return
+	}
+	colors := map[string]func(string) string{
+		DoctorPass: func(s string) string { return s },
+		DoctorWarn: util.WarnColor,
+		DoctorFail: util.ErrorColor,
+	}
+	category := ""
+	for _, check := range checks {
+		if check.Category != category {
+			category = check.Category
+			fmt.Printf("%s:\n", strings.Title(category))
+		}
+		fmt.Printf("\t%s %s: %s\n", colors[check.Status](fmt.Sprintf("[%s]", check.Status)), check.Name, check.Message)
+		if check.Hint != "" && check.Status != DoctorPass {
+			fmt.Printf("\t\t%s\n", util.HighlightColor(check.Hint))
+		}
+	}
+	counts := make(map[string]int)
+	for _, check := range checks {
+		counts[check.Status]++
+	}
+	fmt.Printf("%d passed, %d warning(s), %d failed\n", counts[DoctorPass], counts[DoctorWarn], counts[DoctorFail])
+}
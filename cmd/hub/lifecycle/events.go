@@ -0,0 +1,239 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	eventOperationStart   = "operation.start"
+	eventOperationEnd     = "operation.end"
+	eventStackStatus      = "stack.status"
+	eventComponentStart   = "component.start"
+	eventComponentSkip    = "component.skip"
+	eventComponentEnd     = "component.end"
+	eventParametersLocked = "parameters.locked"
+	eventOutputsCaptured  = "outputs.captured"
+	eventProvidesChanged  = "provides.changed"
+	eventReadyCondition   = "ready.condition"
+	eventRetry            = "retry"
+	eventStateWritten     = "state.written"
+
+	eventsMasked = "(masked)"
+)
+
+var eventsFormats = []string{"json"}
+
+type event struct {
+	Time      time.Time   `json:"time"`
+	Event     string      `json:"event"`
+	Operation string      `json:"operation,omitempty"`
+	Verb      string      `json:"verb,omitempty"`
+	Stack     string      `json:"stack,omitempty"`
+	Component string      `json:"component,omitempty"`
+	Status    string      `json:"status,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// events is a stream of JSON lines describing lifecycle transitions for CI systems;
+// the events are emitted next to state file updates so that both always agree
+type events struct {
+	mu        sync.Mutex
+	out       io.Writer
+	encoder   *json.Encoder
+	operation string
+	verb      string
+	stack     string
+}
+
+var activeEvents *events
+
+// lifecycleStdout receives component sub-processes output and lifecycle blurbs;
+// it is switched to stderr while events are streamed to stdout
+var lifecycleStdout = os.Stdout
+
+// startEvents opens events output: - or empty for stdout, fd:N for a file descriptor, or a file path;
+// when events are sent to stdout, lifecycle output is sent to stderr instead
+func startEvents(format, output, operationId, verb, stackName string) *events {
+	if format == "" || activeEvents != nil {
+		return nil
+	}
+	if !util.Contains(eventsFormats, format) {
+		log.Fatalf("Events format `%s` is not one of %v", format, eventsFormats)
+	}
+	var out io.Writer
+	switch {
+	case output == "" || output == "-":
+		out = os.Stdout
+		lifecycleStdout = os.Stderr
+	case strings.HasPrefix(output, "fd:"):
+		fd, err := strconv.Atoi(output[3:])
+		if err != nil || fd < 0 {
+			log.Fatalf("Unable to parse events output `%s` file descriptor: %v", output, err)
+		}
+		out = os.NewFile(uintptr(fd), output)
+	default:
+		file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			log.Fatalf("Unable to open events output `%s`: %v", output, err)
+		}
+		out = file
+	}
+	activeEvents = &events{
+		out:       out,
+		encoder:   json.NewEncoder(out),
+		operation: operationId,
+		verb:      verb,
+		stack:     stackName,
+	}
+	e := activeEvents
+	util.AtDone(func() <-chan struct{} {
+		e.Stop()
+		return nil
+	})
+	return e
+}
+
+// Stop detaches the stream from lifecycle operation and restores lifecycle output to stdout;
+// the stream stays writable as late state updates are still reported
+func (e *events) Stop() {
+	if e == nil || activeEvents != e {
+		return
+	}
+	activeEvents = nil
+	lifecycleStdout = os.Stdout
+}
+
+func (e *events) emit(ev event) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ev.Time = time.Now()
+	ev.Operation = e.operation
+	ev.Verb = e.verb
+	ev.Stack = e.stack
+	if err := e.encoder.Encode(ev); err != nil {
+		util.WarnOnce("Unable to write lifecycle event: %v", err)
+	}
+}
+
+func (e *events) OperationStart(components []string) {
+	e.emit(event{Event: eventOperationStart, Status: "in-progress", Data: map[string]interface{}{"components": components}})
+}
+
+func (e *events) OperationEnd(status, message string) {
+	e.emit(event{Event: eventOperationEnd, Status: status, Message: message})
+}
+
+func (e *events) StackStatus(status, message string) {
+	e.emit(event{Event: eventStackStatus, Status: status, Message: message})
+}
+
+func (e *events) ComponentStart(name, status string) {
+	e.emit(event{Event: eventComponentStart, Component: name, Status: status})
+}
+
+func (e *events) ComponentSkip(name, reason string) {
+	e.emit(event{Event: eventComponentSkip, Component: name, Status: "skipped", Message: reason})
+}
+
+func (e *events) ComponentEnd(name, status, message string) {
+	e.emit(event{Event: eventComponentEnd, Component: name, Status: status, Message: message})
+}
+
+func (e *events) ParametersLocked(name string, params []parameters.LockedParameter) {
+	if e == nil {
+		return
+	}
+	values := make(map[string]interface{}, len(params))
+	for _, p := range params {
+		value := p.Value
+		if util.LooksLikeSecret(p.Name) && !util.Empty(value) {
+			value = eventsMasked
+		}
+		values[p.QName()] = value
+	}
+	e.emit(event{Event: eventParametersLocked, Component: name, Data: map[string]interface{}{"parameters": values}})
+}
+
+func (e *events) OutputsCaptured(name string, outputs ...parameters.CapturedOutputs) {
+	if e == nil {
+		return
+	}
+	values := make(map[string]interface{})
+	for _, captured := range outputs {
+		for _, output := range captured {
+			value := output.Value
+			if (strings.HasPrefix(output.Kind, "secret") || util.LooksLikeSecret(output.Name)) && !util.Empty(value) {
+				value = eventsMasked
+			}
+			values[output.Name] = value
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+	e.emit(event{Event: eventOutputsCaptured, Component: name, Data: map[string]interface{}{"outputs": values}})
+}
+
+func (e *events) ProvidesChanged(name string, before, after map[string][]string) {
+	if e == nil {
+		return
+	}
+	added := make([]string, 0)
+	removed := make([]string, 0)
+	for provide, by := range after {
+		if util.Contains(by, name) && !util.Contains(before[provide], name) {
+			added = append(added, provide)
+		}
+	}
+	for provide, by := range before {
+		if util.Contains(by, name) && !util.Contains(after[provide], name) {
+			removed = append(removed, provide)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	e.emit(event{Event: eventProvidesChanged, Component: name,
+		Data: map[string]interface{}{"added": added, "removed": removed}})
+}
+
+func (e *events) ReadyConditions(name string, statuses []state.ReadyConditionStatus) {
+	for _, status := range statuses {
+		e.emit(event{Event: eventReadyCondition, Component: name, Status: status.Status, Message: status.Message,
+			Data: map[string]interface{}{"condition": status.Condition, "seconds": status.Seconds}})
+	}
+}
+
+func (e *events) Retry(name, what string) {
+	e.emit(event{Event: eventRetry, Component: name, Message: what})
+}
+
+func (e *events) StateWritten(files *storage.Files) {
+	if e == nil {
+		return
+	}
+	paths := make([]string, 0, len(files.Files))
+	for _, file := range files.Files {
+		paths = append(paths, file.Path)
+	}
+	e.emit(event{Event: eventStateWritten, Data: map[string]interface{}{"files": paths}})
+}
@@ -23,7 +23,10 @@ func goWait(routine func()) chan string {
 	return ch
 }
 
-func execImplementation(impl *exec.Cmd, passStdin, paginate bool) ([]byte, []byte, error) {
+// execImplementation runs sub-process; when progress view is active, the output of paginated
+// sub-process is shown under the name of the component or hook; when component log file is open
+// the output is copied there
+func execImplementation(impl *exec.Cmd, passStdin, paginate bool, name string) ([]byte, []byte, error) {
 	stderrImpl, err := impl.StderrPipe()
 	if err != nil {
 		return nil, nil, fmt.Errorf("Unable to obtain sub-process stderr pipe: %v", err)
@@ -41,13 +44,19 @@ func execImplementation(impl *exec.Cmd, passStdin, paginate bool) ([]byte, []byt
 
 	logOutput := log.Writer()
 
-	var stdout io.Writer = os.Stdout
+	var stdout io.Writer = lifecycleStdout
 	var stderr io.Writer = os.Stderr
 
-	if paginate && config.Tty && !config.Debug {
-		stdoutTerminal := isatty.IsTerminal(os.Stdout.Fd())
+	progress := activeProgress
+	if paginate && progress != nil {
+		output, exited := progress.execOutput(name)
+		defer exited()
+		stdout = output
+		stderr = output
+	} else if paginate && config.Tty && !config.Debug {
+		stdoutTerminal := isatty.IsTerminal(lifecycleStdout.Fd())
 		stderrTerminal := isatty.IsTerminal(os.Stderr.Fd())
-		to := os.Stdout
+		to := lifecycleStdout
 		if !stdoutTerminal && stderrTerminal {
 			to = os.Stderr
 		}
@@ -63,13 +72,28 @@ func execImplementation(impl *exec.Cmd, passStdin, paginate bool) ([]byte, []byt
 		log.SetOutput(tail)
 	}
 
+	// component verb and hooks output is also written into component log file
+	if logFile := activeLogs.writer(name); logFile != nil {
+		fmt.Fprintf(logFile, "--- %s\n", implBlurb)
+		stdout = io.MultiWriter(stdout, logFile)
+		stderr = io.MultiWriter(stderr, logFile)
+	}
+
+	// operation output tail is sent in failure notification
+	if tail := activeNotifications.output(); tail != nil {
+		stdout = io.MultiWriter(stdout, tail)
+		stderr = io.MultiWriter(stderr, tail)
+	}
+
 	var stdoutBuffer bytes.Buffer
 	var stderrBuffer bytes.Buffer
 	stdoutWritter := io.MultiWriter(&stdoutBuffer, stdout)
 	stderrWritter := io.MultiWriter(&stderrBuffer, stderr)
 
-	fmt.Printf("--- %s\n", implBlurb)
-	os.Stdout.Sync()
+	if progress == nil {
+		fmt.Fprintf(lifecycleStdout, "--- %s\n", implBlurb)
+	}
+	lifecycleStdout.Sync()
 	os.Stderr.Sync()
 
 	if passStdin {
@@ -86,8 +110,10 @@ func execImplementation(impl *exec.Cmd, passStdin, paginate bool) ([]byte, []byt
 	<-stdoutComplete
 	<-stderrComplete
 
-	fmt.Print("---\n")
-	os.Stdout.Sync()
+	if progress == nil {
+		fmt.Fprint(lifecycleStdout, "---\n")
+	}
+	lifecycleStdout.Sync()
 	os.Stderr.Sync()
 
 	log.SetOutput(logOutput)
@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	HubEnvVarNameHook        = "HUB_HOOK"
+	HubEnvVarNameHookMessage = "HUB_HOOK_MESSAGE"
+
+	hookPreDeploy    = "pre-deploy"
+	hookPostDeploy   = "post-deploy"
+	hookPreUndeploy  = "pre-undeploy"
+	hookPostUndeploy = "post-undeploy"
+	hookOnFailure    = "on-failure"
+
+	hookFailurePolicyFail   = "fail"
+	hookFailurePolicyWarn   = "warn"
+	hookFailurePolicyIgnore = "ignore"
+)
+
+var hookFailurePolicies = []string{hookFailurePolicyFail, hookFailurePolicyWarn, hookFailurePolicyIgnore}
+
+type execContext struct {
+	name       string // component or stack name
+	component  bool
+	dir        string
+	parameters parameters.LockedParameters
+	outputs    parameters.CapturedOutputs
+	depends    []string
+	osEnv      []string
+}
+
+func (ectx *execContext) parametersInEnv() []string {
+	if ectx.component {
+		return parametersInEnv(ectx.name, ectx.parameters)
+	}
+	return stackParametersInEnv(ectx.parameters)
+}
+
+func lifecycleHooks(hooks *manifest.LifecycleHooks, phase string) []manifest.LifecycleHook {
+	switch phase {
+	case hookPreDeploy:
+		return hooks.PreDeploy
+	case hookPostDeploy:
+		return hooks.PostDeploy
+	case hookPreUndeploy:
+		return hooks.PreUndeploy
+	case hookPostUndeploy:
+		return hooks.PostUndeploy
+	case hookOnFailure:
+		return hooks.OnFailure
+	}
+	return nil
+}
+
+func hookPhase(verb, when string) string {
+	if strings.HasPrefix(verb, "undeploy") {
+		return when + "-undeploy"
+	}
+	return when + "-deploy"
+}
+
+// runHooks executes lifecycle hooks in order, an error is returned only
+// if a hook with `failurePolicy: fail` (the default) failed
+func runHooks(hooks *manifest.LifecycleHooks, phase string, hctx *execContext, message string) error {
+	list := lifecycleHooks(hooks, phase)
+	if len(list) == 0 {
+		return nil
+	}
+	if config.Verbose {
+		log.Printf("Running `%s` %s %s", hctx.name, phase, util.Plural(len(list), "hook"))
+	}
+	kv := parameters.ParametersAndOutputsKV(hctx.parameters, hctx.outputs, nil)
+	env := hctx.parametersInEnv()
+	for i, hook := range list {
+		policy := hook.FailurePolicy
+		if policy == "" {
+			policy = hookFailurePolicyFail
+		}
+		if !util.Contains(hookFailurePolicies, policy) {
+			return fmt.Errorf("`%s` %s hook #%d `failurePolicy: %s` is not one of %v",
+				hctx.name, phase, i+1, policy, hookFailurePolicies)
+		}
+		err := runHook(hook, phase, i, hctx, kv, env, message)
+		if err != nil {
+			switch policy {
+			case hookFailurePolicyFail:
+				return err
+			case hookFailurePolicyWarn:
+				util.Warn("%v", err)
+			case hookFailurePolicyIgnore:
+				if config.Debug {
+					log.Printf("Ignoring: %v", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func runHook(hook manifest.LifecycleHook, phase string, index int, hctx *execContext,
+	kv map[string]interface{}, env []string, message string) error {
+
+	what := fmt.Sprintf("%s.%d", phase, index)
+	command, errs := expandHookParameter(what+".command", hook.Command, hctx.depends, kv)
+	if len(errs) > 0 {
+		return fmt.Errorf("`%s` %s hook #%d command expansion failed:\n\t%s",
+			hctx.name, phase, index+1, util.Errors("\n\t", errs...))
+	}
+	if command == "" {
+		return fmt.Errorf("`%s` %s hook #%d has no command", hctx.name, phase, index+1)
+	}
+	hookEnv := []string{
+		fmt.Sprintf("%s=%s", HubEnvVarNameHook, phase),
+	}
+	if message != "" {
+		hookEnv = append(hookEnv, fmt.Sprintf("%s=%s", HubEnvVarNameHookMessage, message))
+	}
+	for _, name := range util.SortedKeys(hook.Env) {
+		value, errs := expandHookParameter(fmt.Sprintf("%s.env.%s", what, name), hook.Env[name], hctx.depends, kv)
+		if len(errs) > 0 {
+			return fmt.Errorf("`%s` %s hook #%d env `%s` expansion failed:\n\t%s",
+				hctx.name, phase, index+1, name, util.Errors("\n\t", errs...))
+		}
+		hookEnv = append(hookEnv, fmt.Sprintf("%s=%s", name, value))
+	}
+
+	if activeSimulation != nil {
+		if config.Verbose {
+			log.Printf("Simulating `%s` %s hook #%d: %s", hctx.name, phase, index+1, command)
+		}
+		return nil
+	}
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		shell = "/bin/sh"
+		util.WarnOnce("Unable to lookup `sh` in PATH: %v; trying `%s`", err, shell)
+	}
+	impl := &exec.Cmd{Path: shell, Args: []string{"sh", "-c", command}, Dir: hctx.dir}
+	impl.Env = mergeOsEnviron(hctx.osEnv, toolchainEnv(hctx.osEnv), tracing.Env(), env, hookEnv)
+	if config.Debug {
+		log.Print("Hook environment:")
+		printEnvironment(hookEnv)
+	}
+
+	_, _, err = execImplementation(impl, false, true, hctx.name)
+	if err != nil {
+		return fmt.Errorf("`%s` %s hook #%d failed: %v", hctx.name, phase, index+1, err)
+	}
+	return nil
+}
+
+func expandHookParameter(what string, value string, depends []string, kv map[string]interface{}) (string, []error) {
+	if !parameters.RequireExpansion(value) {
+		return value, nil
+	}
+	piggy := manifest.Parameter{Name: fmt.Sprintf("lifecycle.hooks.%s", what), Value: value}
+	errs := parameters.ExpandParameter(&piggy, depends, kv)
+	return util.String(piggy.Value), errs
+}
+
+func stackParametersInEnv(params parameters.LockedParameters) []string {
+	env := make([]string, 0)
+	for _, parameter := range params {
+		if parameter.Env != "" && parameter.Component == "" {
+			env = append(env, fmt.Sprintf("%s=%s", parameter.Env, strings.TrimSpace(util.MaybeJson(parameter.Value))))
+		}
+	}
+	return mergeOsEnviron(env) // sort
+}
@@ -96,7 +96,7 @@ func Invoke(request *Request) {
 		}
 	}
 
-	_, _, err = execImplementation(impl, true, false)
+	_, _, err = execImplementation(impl, true, false, "")
 
 	if err != nil {
 		util.MaybeFatalf("Failed to %s %s: %v", request.Verb, request.Component, err)
@@ -0,0 +1,463 @@
+package lifecycle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	gotemplate "text/template"
+	"text/template/parse"
+
+	"github.com/alexkappa/mustache"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+// templateProblem is a template check finding with file:line position
+type templateProblem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (p templateProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+type templateChecker struct {
+	problems []templateProblem
+	warnings []templateProblem
+}
+
+func (c *templateChecker) problem(file string, line int, format string, args ...interface{}) {
+	c.problems = append(c.problems, templateProblem{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *templateChecker) warning(file string, line int, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, templateProblem{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// CheckTemplates statically checks templates of every (or selected) component of elaborated stack:
+// substitutions are resolved against component parameters and outputs of components it depends on;
+// no output files are written; number of problems found is returned
+func CheckTemplates(manifestFilenames []string, componentsBaseDir string, componentNames []string) int {
+	stackManifest, componentsManifests, _, err := manifest.ParseManifest(manifestFilenames)
+	if err != nil {
+		log.Fatalf("Unable to check templates: %v", err)
+	}
+	stackBaseDir := util.Basedir(manifestFilenames)
+	if componentsBaseDir == "" {
+		componentsBaseDir = stackBaseDir
+	}
+	components := stackManifest.Components
+	if len(componentNames) > 0 {
+		manifest.CheckComponentsExist(components, componentNames...)
+	}
+	setTemplateComponentsDirs(components, stackBaseDir, componentsBaseDir)
+
+	stackKV := make(map[string]interface{})
+	for _, p := range manifest.FlattenParameters(stackManifest.Parameters, stackManifest.Meta.Name) {
+		stackKV[p.QName()] = util.Value(util.String(p.Value), util.String(p.Default))
+	}
+
+	checker := &templateChecker{}
+	for i := range components {
+		component := &components[i]
+		componentName := manifest.ComponentQualifiedNameFromRef(component)
+		if len(componentNames) > 0 && !util.Contains(componentNames, componentName) {
+			continue
+		}
+		componentManifest := manifest.ComponentManifestByRef(componentsManifests, component)
+		if componentManifest == nil {
+			continue
+		}
+		kv := componentTemplateNamespace(componentName, component.Depends, componentManifest, componentsManifests,
+			components, stackKV)
+		dir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
+		checker.component(component, &componentManifest.Templates, kv, dir)
+	}
+
+	for _, warning := range checker.warnings {
+		fmt.Printf("%s (warning)\n", warning)
+	}
+	for _, problem := range checker.problems {
+		fmt.Printf("%s\n", problem)
+	}
+	if config.Verbose {
+		if len(checker.problems) == 0 {
+			log.Print("No template problems found")
+		} else {
+			log.Printf("%d template %s found", len(checker.problems), util.Plural(len(checker.problems), "problem"))
+		}
+	}
+	return len(checker.problems)
+}
+
+// componentTemplateNamespace returns the names visible to component templates during deploy and render:
+// component parameters, hub.* parameters added by lifecycle, and outputs of components it depends on
+func componentTemplateNamespace(componentName string, depends []string, componentManifest *manifest.Manifest,
+	componentsManifests []manifest.Manifest, components []manifest.ComponentRef,
+	stackKV map[string]interface{}) map[string]interface{} {
+
+	kv := make(map[string]interface{})
+	for _, p := range manifest.FlattenParameters(componentManifest.Parameters, componentName) {
+		value, exist := parameters.FindValue(p.Name, componentName, depends, stackKV)
+		if !exist || util.Empty(value) {
+			value = util.Value(util.String(p.Value), util.String(p.Default))
+		}
+		kv[p.Name] = value
+	}
+	kv["hub.componentName"] = componentName
+	kv["hub.provides"] = ""
+	for _, dependsOn := range depends {
+		ref := manifest.ComponentRefByName(components, dependsOn)
+		if ref == nil {
+			continue
+		}
+		dependsManifest := manifest.ComponentManifestByRef(componentsManifests, ref)
+		if dependsManifest == nil {
+			continue
+		}
+		for _, output := range dependsManifest.Outputs {
+			kv[parameters.OutputQualifiedName(output.Name, dependsOn)] = ""
+			if _, exist := kv[output.Name]; !exist {
+				kv[output.Name] = ""
+			}
+		}
+	}
+	return kv
+}
+
+func (c *templateChecker) component(component *manifest.ComponentRef, setup *manifest.TemplateSetup,
+	kv map[string]interface{}, dir string) {
+
+	componentName := manifest.ComponentQualifiedNameFromRef(component)
+	templateSetup, err := expandParametersInTemplateSetup(setup, kv)
+	if err != nil {
+		c.problem(componentName, 0, "%v", err)
+		return
+	}
+	if err := checkTemplateSetupKind(templateSetup); err != nil {
+		c.problem(componentName, 0, "%v", err)
+		return
+	}
+	templates := scanTemplates(componentName, dir, templateSetup)
+	if len(templates) == 0 {
+		return
+	}
+
+	for _, when := range uniqueTemplatesWhen(templates) {
+		c.cel(componentName, "when: "+when, 0, when, component.Depends, kv)
+	}
+
+	var partials []templatePartial
+	var mustacheKV, goKV map[string]interface{}
+	var mustachePartials []mustache.Option
+	var goBase *gotemplate.Template
+	for _, template := range templates {
+		bytes, err := ioutil.ReadFile(template.Filename)
+		if err != nil {
+			c.problem(template.Filename, 0, "%v", err)
+			continue
+		}
+		if template.Output != "" {
+			if _, err := templateOutputPath(template, componentName); err != nil {
+				c.problem(template.Filename, 0, "%v", err)
+			}
+		}
+		content := string(bytes)
+		switch template.Kind {
+		case "", curlyKind:
+			c.replacement(template.Filename, content, componentName, component.Depends, kv, curlyReplacement, stripCurly)
+		case mustacheKind:
+			c.replacement(template.Filename, content, componentName, component.Depends, kv, mustacheReplacement, stripMustache)
+		case trueMustacheKind:
+			if mustacheKV == nil {
+				mustacheKV = mustacheCompatibleBindings(kv)
+				if partials == nil {
+					partials, err = scanTemplatePartials(componentName, dir, templateSetup.Partials)
+				}
+				if err == nil {
+					mustachePartials, err = mustacheTemplatePartials(componentName, partials)
+				}
+				if err != nil {
+					c.problem(componentName, 0, "%v", err)
+					return
+				}
+			}
+			c.mustacheTemplate(template.Filename, content, mustacheKV, partials, mustachePartials)
+		case goKind:
+			if goBase == nil {
+				goKV = goTemplateBindings(kv)
+				if partials == nil {
+					partials, err = scanTemplatePartials(componentName, dir, templateSetup.Partials)
+				}
+				if err == nil {
+					goBase, err = goTemplateBase(componentName, dir, partials)
+				}
+				if err != nil {
+					c.problem(componentName, 0, "%v", err)
+					return
+				}
+			}
+			c.goTemplate(template.Filename, content, goKV, goBase)
+		}
+	}
+}
+
+func uniqueTemplatesWhen(templates []TemplateRef) []string {
+	conditions := make([]string, 0)
+	for _, template := range templates {
+		if template.When != "" && !util.Contains(conditions, template.When) {
+			conditions = append(conditions, template.When)
+		}
+	}
+	return conditions
+}
+
+func lineAt(content string, offset int) int {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+func (c *templateChecker) replacement(filename, content, componentName string, depends []string,
+	kv map[string]interface{}, replacement *regexp.Regexp, strip func(string) string) {
+
+	matches := replacement.FindAllStringIndex(content, -1)
+	if len(matches) == 0 {
+		c.warning(filename, 0, "No substitutions found")
+		return
+	}
+	for _, match := range matches {
+		line := lineAt(content, match[0])
+		variable, encodings := parameters.SplitEncodings(strip(content[match[0]:match[1]]))
+		if _, exist := parameters.FindValue(variable, componentName, depends, kv); !exist {
+			c.problem(filename, line, "Unknown substitution `%s`", variable)
+		}
+		if unknown := parameters.UnknownEncoders(encodings); len(unknown) > 0 {
+			c.problem(filename, line, "Unknown encoding(s) %v in substitution `%s`; supported %v",
+				unknown, variable, parameters.EncoderNames())
+		} else if legacy := parameters.LegacyOrder(encodings); !util.Equal(legacy, encodings) {
+			c.warning(filename, line, "Substitution `%s` encodings %v are applied in legacy order %v",
+				variable, encodings, legacy)
+		}
+	}
+}
+
+// cel checks CEL expression syntax and that every identifier resolves
+func (c *templateChecker) cel(filename, what string, line int, expr string, depends []string, kv map[string]interface{}) {
+	ast, issues := parameters.CEL.Parse(expr)
+	if issues != nil && issues.Err() != nil {
+		c.problem(filename, line, "CEL `%s` parse error: %v", what, issues.Err())
+		return
+	}
+	component := util.String(kv["hub.componentName"])
+	for _, name := range celIdentifiers(ast.Expr()) {
+		resolved := false
+		parts := strings.Split(name, ".")
+		for i := len(parts); i > 0 && !resolved; i-- {
+			_, resolved = parameters.FindValue(strings.Join(parts[:i], "."), component, depends, kv)
+		}
+		if !resolved {
+			c.problem(filename, line, "CEL `%s` refers to unknown `%s`", what, name)
+		}
+	}
+}
+
+func celIdentifiers(expr *exprpb.Expr) []string {
+	names := make([]string, 0)
+	var qualified func(*exprpb.Expr) (string, bool)
+	qualified = func(e *exprpb.Expr) (string, bool) {
+		if ident := e.GetIdentExpr(); ident != nil {
+			return ident.GetName(), true
+		}
+		if sel := e.GetSelectExpr(); sel != nil && !sel.GetTestOnly() {
+			if operand, ok := qualified(sel.GetOperand()); ok {
+				return operand + "." + sel.GetField(), true
+			}
+		}
+		return "", false
+	}
+	var walk func(*exprpb.Expr)
+	walk = func(e *exprpb.Expr) {
+		if e == nil {
+			return
+		}
+		if name, ok := qualified(e); ok {
+			if !util.Contains(names, name) {
+				names = append(names, name)
+			}
+			return
+		}
+		switch {
+		case e.GetSelectExpr() != nil:
+			walk(e.GetSelectExpr().GetOperand())
+		case e.GetCallExpr() != nil:
+			walk(e.GetCallExpr().GetTarget())
+			for _, arg := range e.GetCallExpr().GetArgs() {
+				walk(arg)
+			}
+		case e.GetListExpr() != nil:
+			for _, element := range e.GetListExpr().GetElements() {
+				walk(element)
+			}
+		case e.GetStructExpr() != nil:
+			for _, entry := range e.GetStructExpr().GetEntries() {
+				walk(entry.GetMapKey())
+				walk(entry.GetValue())
+			}
+		case e.GetComprehensionExpr() != nil:
+			// iteration variables are local to the comprehension
+			walk(e.GetComprehensionExpr().GetIterRange())
+		}
+	}
+	walk(expr)
+	sort.Strings(names)
+	return names
+}
+
+var mustacheTag = regexp.MustCompile(`\{\{(\{?)\s*([#^/!>&=]?)\s*((?s:.*?))\s*\}?\}\}`)
+
+// mustacheTemplate parses `_mustache` template with partials and checks that variables and section names resolve
+// against template bindings; variables inside sections may refer to section's context and are not checked
+func (c *templateChecker) mustacheTemplate(filename, content string, kv map[string]interface{},
+	partials []templatePartial, partialOptions []mustache.Option) {
+
+	if err := mustache.New(partialOptions...).ParseString(content); err != nil {
+		c.problem(filename, 0, "Unable to parse mustache template: %v", err)
+		return
+	}
+	sections := 0
+	for _, match := range mustacheTag.FindAllStringSubmatchIndex(content, -1) {
+		line := lineAt(content, match[0])
+		sigil, name := content[match[4]:match[5]], content[match[6]:match[7]]
+		switch sigil {
+		case "!":
+			continue
+		case "=":
+			return // custom delimiters are not tracked
+		case ">":
+			found := false
+			for _, partial := range partials {
+				if partial.name == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				c.problem(filename, line, "Unknown partial `%s`", name)
+			}
+			continue
+		case "/":
+			if sections > 0 {
+				sections--
+			}
+			continue
+		}
+		if sections == 0 && name != "." {
+			// dotted name is a lookup into the value of the first segment
+			if _, exist := kv[strings.SplitN(name, ".", 2)[0]]; !exist {
+				c.problem(filename, line, "Unknown substitution `%s`", name)
+			}
+		}
+		if sigil == "#" || sigil == "^" {
+			sections++
+		}
+	}
+}
+
+// goTemplate parses Go template with partials and checks that `.field` and `$.field` chains resolve
+// against template bindings; fields inside `range` and `with` are relative to a different dot and are not checked
+func (c *templateChecker) goTemplate(filename, content string, kv map[string]interface{}, base *gotemplate.Template) {
+	set, err := base.Clone()
+	if err != nil {
+		c.problem(filename, 0, "%v", err)
+		return
+	}
+	tmpl, err := set.New(filename).Parse(content)
+	if err != nil {
+		c.problem(filename, 0, "%v", err)
+		return
+	}
+	if tmpl.Tree == nil {
+		return
+	}
+	check := func(node parse.Node, fields []string) {
+		if len(fields) == 0 {
+			return
+		}
+		var value interface{} = kv
+		for i, field := range fields {
+			dict, ok := value.(map[string]interface{})
+			if !ok {
+				return // a leaf value may hold structure unknown until deploy
+			}
+			value, ok = dict[field]
+			if !ok {
+				c.problem(filename, lineAt(content, int(node.Position())), "Unknown field `.%s`",
+					strings.Join(fields[:i+1], "."))
+				return
+			}
+		}
+	}
+	var walk func(node parse.Node, rootDot bool)
+	walk = func(node parse.Node, rootDot bool) {
+		switch n := node.(type) {
+		case nil:
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child, rootDot)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe, rootDot)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd, rootDot)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg, rootDot)
+			}
+		case *parse.FieldNode:
+			if rootDot {
+				check(n, n.Ident)
+			}
+		case *parse.VariableNode:
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				check(n, n.Ident[1:])
+			}
+		case *parse.IfNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, rootDot)
+			walk(n.ElseList, rootDot)
+		case *parse.RangeNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, false)
+			walk(n.ElseList, rootDot)
+		case *parse.WithNode:
+			walk(n.Pipe, rootDot)
+			walk(n.List, false)
+			walk(n.ElseList, rootDot)
+		case *parse.TemplateNode:
+			walk(n.Pipe, rootDot)
+		}
+	}
+	walk(tmpl.Tree.Root, true)
+}
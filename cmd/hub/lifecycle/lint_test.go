@@ -0,0 +1,57 @@
+package lifecycle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+func TestCheckMustacheTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFiles(t, dir, map[string]string{
+		"partials/labels": "app: {{name}}\n",
+		"values.yaml.template": `{{! comment {{unknown}} }}
+name: {{name}}
+host: {{{component_app_host}}}
+{{> labels}}
+{{#items}}
+- {{item_name}}
+{{/items}}
+port: {{component_app_port}}
+{{> missing}}
+`,
+	})
+
+	component := &manifest.ComponentRef{Name: "app"}
+	setup := &manifest.TemplateSetup{Kind: trueMustacheKind, Files: []string{"values.yaml.template"},
+		Partials: []string{"partials"}}
+	kv := map[string]interface{}{
+		"name":               "web",
+		"component.app.host": "app.local",
+		"items":              "",
+		"hub.componentName":  "app",
+	}
+	var c templateChecker
+	c.component(component, setup, kv, dir)
+
+	filename := filepath.Join(dir, "values.yaml.template")
+	want := []templateProblem{
+		{File: filename, Line: 8, Message: "Unknown substitution `component_app_port`"},
+		{File: filename, Line: 9, Message: "Unknown partial `missing`"},
+	}
+	if len(c.problems) != len(want) {
+		t.Fatalf("problems = %v, want %v", c.problems, want)
+	}
+	for i := range want {
+		if c.problems[i] != want[i] {
+			t.Errorf("problem = %v, want %v", c.problems[i], want[i])
+		}
+	}
+}
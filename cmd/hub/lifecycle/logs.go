@@ -0,0 +1,491 @@
+package lifecycle
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	envVarNameLogDir = "HUB_LOG_DIR"
+	defaultLogDir    = ".hub/logs"
+	// --log-dir value to disable log files
+	noLogDir = "-"
+
+	logFileTimestampFormat = "20060102T150405"
+)
+
+type componentLog struct {
+	mu   sync.Mutex
+	file *os.File
+	name string
+}
+
+func (l *componentLog) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Write(b)
+}
+
+// operationLogs writes combined output of every component verb into a separate file
+// under <log dir>/<operation id>/ and optionally uploads it next to the remote state
+type operationLogs struct {
+	mu     sync.Mutex
+	dir    string
+	remote []string // remote state operation logs locations
+	open   map[string]*componentLog
+}
+
+var activeLogs *operationLogs
+
+// LogDir returns directory to write per-component log files to, or empty string if disabled
+func LogDir(requested, stackBaseDir string) string {
+	dir := util.Value(requested, os.Getenv(envVarNameLogDir))
+	if dir == noLogDir {
+		return ""
+	}
+	if dir == "" {
+		dir = filepath.Join(stackBaseDir, defaultLogDir)
+	}
+	return util.MustAbs(dir)
+}
+
+func startOperationLogs(request *Request, stackBaseDir, operationId string) *operationLogs {
+	logDir := LogDir(request.LogDir, stackBaseDir)
+	// simulated operation logs are kept only in explicitly requested --log-dir, ie. by component test
+	if logDir == "" || activeLogs != nil || (activeSimulation != nil && request.LogDir == "") {
+		return nil
+	}
+	dir := filepath.Join(logDir, operationId)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		util.Warn("Unable to create log directory `%s`: %v", dir, err)
+		return nil
+	}
+	remote := make([]string, 0)
+	if request.UploadLogs {
+		for _, stateFile := range storage.RemoteStoragePaths(request.StateFilenames) {
+			remote = append(remote, fmt.Sprintf("%s.logs/%s", stateFile, operationId))
+		}
+		if len(remote) == 0 {
+			util.Warn("No remote state file specified, logs are kept in `%s` only", dir)
+		}
+	}
+	if config.Debug {
+		log.Printf("Writing component logs to %s", dir)
+	}
+	activeLogs = &operationLogs{
+		dir:    dir,
+		remote: remote,
+		open:   make(map[string]*componentLog),
+	}
+	return activeLogs
+}
+
+// Open creates log file for component verb, the name of the file is returned
+func (l *operationLogs) Open(componentName, verb string) string {
+	if l == nil {
+		return ""
+	}
+	l.Close(componentName)
+	name := fmt.Sprintf("%s-%s-%s.log", componentName, verb, util.Now().Format(logFileTimestampFormat))
+	path := filepath.Join(l.dir, name)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		util.Warn("Unable to open `%s` log file: %v", path, err)
+		return ""
+	}
+	l.mu.Lock()
+	l.open[componentName] = &componentLog{file: file, name: name}
+	l.mu.Unlock()
+	return name
+}
+
+func (l *operationLogs) writer(componentName string) io.Writer {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if componentLog, exist := l.open[componentName]; exist {
+		return componentLog
+	}
+	return nil
+}
+
+// Close closes component log file and uploads it to remote location
+func (l *operationLogs) Close(componentName string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	componentLog, exist := l.open[componentName]
+	delete(l.open, componentName)
+	l.mu.Unlock()
+	if !exist {
+		return
+	}
+	path := componentLog.file.Name()
+	if err := componentLog.file.Close(); err != nil {
+		util.Warn("Unable to close `%s` log file: %v", path, err)
+		return
+	}
+	if len(l.remote) > 0 {
+		l.upload(path, componentLog.name)
+	}
+}
+
+func (l *operationLogs) upload(path, name string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		util.Warn("Unable to read `%s` log file: %v", path, err)
+		return
+	}
+	remote := make([]string, 0, len(l.remote))
+	for _, dir := range l.remote {
+		remote = append(remote, fmt.Sprintf("%s/%s", dir, name))
+	}
+	files, errs := storage.Check(remote, "log")
+	if len(errs) == 0 {
+		_, errs = storage.Write(data, files)
+	}
+	if len(errs) > 0 {
+		util.Warn("Unable to upload `%s` log file: %s", name, util.Errors2(errs...))
+	} else if config.Debug {
+		log.Printf("Uploaded log file to %v", remote)
+	}
+}
+
+// Stop closes all open log files
+func (l *operationLogs) Stop() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	names := make([]string, 0, len(l.open))
+	for name := range l.open {
+		names = append(names, name)
+	}
+	l.mu.Unlock()
+	for _, name := range names {
+		l.Close(name)
+	}
+	activeLogs = nil
+}
+
+func (l *operationLogs) Dir() string {
+	if l == nil {
+		return ""
+	}
+	return l.dir
+}
+
+func (l *operationLogs) Remote() []string {
+	if l == nil || len(l.remote) == 0 {
+		return nil
+	}
+	return l.remote
+}
+
+// logFileOf matches log file name to component name taking into account that both
+// component name and verb, ie. deploy-test, may contain dashes
+func logFileOf(name, componentName string) bool {
+	if !strings.HasPrefix(name, componentName+"-") {
+		return false
+	}
+	rest := strings.TrimPrefix(name, componentName+"-")
+	for _, verb := range []string{"deploy", "undeploy", "backup"} {
+		for _, suffix := range []string{"", "-test"} {
+			if strings.HasPrefix(rest, verb+suffix+"-") &&
+				!strings.Contains(strings.TrimPrefix(rest, verb+suffix+"-"), "-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type LogsRequest struct {
+	LogDir         string
+	StateFilenames []string
+	OperationId    string
+	Component      string
+	List           bool
+	ShowSecrets    bool
+}
+
+const logsRedacted = "(redacted)"
+
+var logsAssignmentRegexp = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_.-]*)(["']?\s*[:=]\s*["']?)([^\s"',;]+)`)
+
+type logsOperation struct {
+	id      string
+	dir     string
+	modTime time.Time
+	op      *state.LifecycleOperation
+}
+
+// ShowLogs prints component log files of lifecycle operation written by deploy, undeploy, and backup
+func ShowLogs(request *LogsRequest) {
+	var stateManifest *state.StateManifest
+	if len(request.StateFilenames) > 0 {
+		stateFiles, errs := storage.Check(request.StateFilenames, "state")
+		if len(errs) > 0 {
+			util.MaybeFatalf("Unable to check state files: %s", util.Errors2(errs...))
+		}
+		parsed, err := state.ParseState(stateFiles)
+		if err != nil {
+			if err != os.ErrNotExist {
+				util.Warn("Unable to load state %v: %v", request.StateFilenames, err)
+			}
+		} else {
+			stateManifest = parsed
+		}
+	}
+
+	logDir := ""
+	if request.LogDir != "" {
+		logDir = LogDir(request.LogDir, ".")
+	} else if stateManifest != nil {
+		for i := len(stateManifest.Operations) - 1; i >= 0; i-- {
+			if dir := stateManifest.Operations[i].LogDir; dir != "" {
+				logDir = filepath.Dir(dir)
+				break
+			}
+		}
+	}
+	if logDir == "" {
+		logDir = LogDir("", ".")
+	}
+	if logDir == "" {
+		log.Fatal("Log directory is not set")
+	}
+
+	operations := findLogsOperations(logDir, stateManifest)
+	if len(operations) == 0 {
+		log.Fatalf("No operation logs found in %s", logDir)
+	}
+
+	if request.List {
+		for _, operation := range operations {
+			verb := ""
+			status := ""
+			if operation.op != nil {
+				verb = operation.op.Operation
+				status = operation.op.Status
+			}
+			files, _ := logsOperationFiles(operation, "")
+			fmt.Printf("%s  %s  %-10s %-11s %d %s\n", operation.id, operation.modTime.Format(time.RFC3339),
+				verb, status, len(files), util.Plural(len(files), "log"))
+		}
+		return
+	}
+
+	var operation *logsOperation
+	if request.OperationId != "" && request.Component == "" {
+		// hub logs --local component
+		latest := operations[len(operations)-1]
+		if files, _ := logsOperationFiles(latest, request.OperationId); len(files) > 0 {
+			request.Component = request.OperationId
+			request.OperationId = ""
+		}
+	}
+	if request.OperationId == "" {
+		operation = operations[len(operations)-1]
+	} else {
+		found := make([]*logsOperation, 0, 1)
+		for _, candidate := range operations {
+			if strings.HasPrefix(candidate.id, request.OperationId) {
+				found = append(found, candidate)
+			}
+		}
+		if len(found) == 0 {
+			log.Fatalf("Operation `%s` logs not found in %s", request.OperationId, logDir)
+		}
+		if len(found) > 1 {
+			log.Fatalf("Operation `%s` is ambiguous, %d operations match", request.OperationId, len(found))
+		}
+		operation = found[0]
+	}
+
+	files, err := logsOperationFiles(operation, request.Component)
+	if err != nil {
+		log.Fatalf("Unable to read %s: %v", operation.dir, err)
+	}
+	if len(files) == 0 {
+		if request.Component != "" {
+			log.Fatalf("No `%s` component logs found in %s", request.Component, operation.dir)
+		}
+		log.Fatalf("No logs found in %s", operation.dir)
+	}
+
+	secrets := make([]string, 0)
+	if !request.ShowSecrets && stateManifest != nil {
+		secrets = stateSecretValues(stateManifest)
+	}
+	for _, file := range files {
+		path := filepath.Join(operation.dir, file)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			util.Warn("Unable to read `%s`: %v", path, err)
+			continue
+		}
+		if config.Verbose {
+			fmt.Printf("%s\n", util.HighlightColor(fmt.Sprintf("=== %s", path)))
+		}
+		text := string(data)
+		if !request.ShowSecrets {
+			text = redactLog(text, secrets)
+		}
+		fmt.Print(text)
+		if len(text) > 0 && !strings.HasSuffix(text, "\n") {
+			fmt.Print("\n")
+		}
+	}
+}
+
+// findLogsOperations returns operations log directories sorted by time
+func findLogsOperations(logDir string, stateManifest *state.StateManifest) []*logsOperation {
+	entries, err := ioutil.ReadDir(logDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		log.Fatalf("Unable to read %s: %v", logDir, err)
+	}
+	operations := make([]*logsOperation, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		operation := &logsOperation{
+			id:      entry.Name(),
+			dir:     filepath.Join(logDir, entry.Name()),
+			modTime: entry.ModTime(),
+		}
+		if stateManifest != nil {
+			for i, op := range stateManifest.Operations {
+				if op.Id == operation.id {
+					operation.op = &stateManifest.Operations[i]
+					operation.modTime = op.Timestamp
+					break
+				}
+			}
+		}
+		operations = append(operations, operation)
+	}
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].modTime.Before(operations[j].modTime)
+	})
+	return operations
+}
+
+// logsOperationFiles returns operation log files in execution order
+func logsOperationFiles(operation *logsOperation, componentName string) ([]string, error) {
+	entries, err := ioutil.ReadDir(operation.dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	phaseFiles := make(map[string]string)
+	if operation.op != nil {
+		for _, phase := range operation.op.Phases {
+			if phase.LogFile != "" {
+				phaseFiles[phase.LogFile] = phase.Phase
+			}
+		}
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		if componentName != "" {
+			if phase, exist := phaseFiles[name]; exist {
+				if phase != componentName {
+					continue
+				}
+			} else if !logFileOf(name, componentName) {
+				continue
+			}
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// stateSecretValues collects values of secret parameters and outputs to mask them in logs
+func stateSecretValues(stateManifest *state.StateManifest) []string {
+	secrets := make([]string, 0)
+	add := func(value interface{}) {
+		secrets = appendSecretValue(secrets, value)
+	}
+	addParameters := func(params []parameters.LockedParameter) {
+		for _, p := range params {
+			if util.LooksLikeSecret(p.Name) {
+				add(p.Value)
+			}
+		}
+	}
+	addOutputs := func(outputs []parameters.CapturedOutput) {
+		for _, o := range outputs {
+			if strings.HasPrefix(o.Kind, "secret") || util.LooksLikeSecret(o.Name) {
+				add(o.Value)
+			}
+		}
+	}
+	addParameters(stateManifest.StackParameters)
+	addOutputs(stateManifest.CapturedOutputs)
+	for _, o := range stateManifest.StackOutputs {
+		if strings.HasPrefix(o.Kind, "secret") || util.LooksLikeSecret(o.Name) {
+			add(o.Value)
+		}
+	}
+	for _, step := range stateManifest.Components {
+		addParameters(step.Parameters)
+		addOutputs(step.CapturedOutputs)
+	}
+	// longest first so that a secret containing another one is masked completely
+	sort.SliceStable(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+func appendSecretValue(secrets []string, value interface{}) []string {
+	str := util.String(value)
+	// short values are too generic to be masked
+	if len(str) >= 4 && !util.Contains(secrets, str) {
+		secrets = append(secrets, str)
+	}
+	return secrets
+}
+
+func redactLog(text string, secrets []string) string {
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, logsRedacted)
+	}
+	return logsAssignmentRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		parts := logsAssignmentRegexp.FindStringSubmatch(match)
+		key := parts[1]
+		if parts[3] == logsRedacted ||
+			!(util.LooksLikeSecret(key) || util.LooksLikeSecret("_"+strings.ToLower(key))) {
+			return match
+		}
+		return parts[1] + parts[2] + logsRedacted
+	})
+}
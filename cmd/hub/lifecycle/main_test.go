@@ -0,0 +1,18 @@
+package lifecycle_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/cmd"
+)
+
+// TestMain turns test binary into Hub CLI when it is invoked by component test harness
+// as nested `hub elaborate`, `hub deploy`, etc.
+func TestMain(m *testing.M) {
+	if os.Getenv("HUB_TEST_NESTED_CLI") != "" {
+		cmd.Execute()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,426 @@
+package lifecycle
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	notifySuccess = "success"
+	notifyFailure = "failure"
+	notifyInput   = "input"
+
+	notificationWebhook = "webhook"
+	notificationSlack   = "slack"
+	notificationSmtp    = "smtp"
+
+	notificationLogTailLines = 20
+	notificationTimeout      = 10 * time.Second
+)
+
+var (
+	notificationEvents = []string{notifySuccess, notifyFailure, notifyInput}
+	notificationKinds  = []string{notificationWebhook, notificationSlack, notificationSmtp}
+
+	// ConfigNotifications are loaded from `notifications` list in $HOME/.hub-config
+	ConfigNotifications []manifest.Notification
+)
+
+// notificationData is the default webhook body and the data for body and subject templates
+type notificationData struct {
+	Event     string    `json:"event"`
+	Stack     string    `json:"stack"`
+	Verb      string    `json:"verb"`
+	Operation string    `json:"operation"`
+	Component string    `json:"component,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Log       string    `json:"log,omitempty"`
+	Host      string    `json:"host,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// notifications are sent once per operation on success or failure, and once on first prompt for input
+type notifications struct {
+	targets   []manifest.Notification
+	operation string
+	verb      string
+	stack     string
+	ended     bool
+	awaiting  bool
+	tail      *operationTail
+	secrets   []string
+}
+
+// operationTail keeps last lines of lifecycle operation output written by component verbs and hooks
+type operationTail struct {
+	mu      sync.Mutex
+	lines   []string
+	partial []byte
+}
+
+var activeNotifications *notifications
+
+func startNotifications(manifestNotifications []manifest.Notification, operationId, verb, stackName string) *notifications {
+	if activeNotifications != nil || activeSimulation != nil {
+		return nil
+	}
+	targets := make([]manifest.Notification, 0, len(ConfigNotifications)+len(manifestNotifications))
+	targets = append(targets, ConfigNotifications...)
+	targets = append(targets, manifestNotifications...)
+	if len(targets) == 0 {
+		return nil
+	}
+	for i := range targets {
+		target := &targets[i]
+		if target.Kind == "" {
+			target.Kind = notificationWebhook
+			if target.Host != "" {
+				target.Kind = notificationSmtp
+			}
+		}
+		if !util.Contains(notificationKinds, target.Kind) {
+			log.Fatalf("Notification kind `%s` is not one of %v", target.Kind, notificationKinds)
+		}
+		for _, on := range target.On {
+			if !util.Contains(notificationEvents, on) {
+				log.Fatalf("Notification `on: %s` is not one of %v", on, notificationEvents)
+			}
+		}
+		if target.Kind == notificationSmtp {
+			if target.Host == "" || len(target.To) == 0 {
+				log.Fatal("SMTP notification must specify `host` and `to`")
+			}
+		} else if target.URL == "" {
+			log.Fatalf("%s notification must specify `url`", strings.Title(target.Kind))
+		}
+	}
+	activeNotifications = &notifications{
+		targets:   targets,
+		operation: operationId,
+		verb:      verb,
+		stack:     stackName,
+		tail:      &operationTail{},
+	}
+	n := activeNotifications
+	util.AtDone(func() <-chan struct{} {
+		n.Stop()
+		return nil
+	})
+	return n
+}
+
+// Stop detaches notifications from lifecycle operation so that next operation starts afresh
+func (n *notifications) Stop() {
+	if n == nil || activeNotifications != n {
+		return
+	}
+	activeNotifications = nil
+}
+
+// output returns a writer to capture operation output tail, nil if notifications are not active
+func (n *notifications) output() io.Writer {
+	if n == nil {
+		return nil
+	}
+	return n.tail
+}
+
+// Secrets adds values of secret parameters and outputs to be masked in the log tail
+func (n *notifications) Secrets(params []parameters.LockedParameter, outputs ...parameters.CapturedOutputs) {
+	if n == nil {
+		return
+	}
+	for _, p := range params {
+		if util.LooksLikeSecret(p.Name) {
+			n.secrets = appendSecretValue(n.secrets, p.Value)
+		}
+	}
+	for _, captured := range outputs {
+		for _, o := range captured {
+			if strings.HasPrefix(o.Kind, "secret") || util.LooksLikeSecret(o.Name) {
+				n.secrets = appendSecretValue(n.secrets, o.Value)
+			}
+		}
+	}
+	sort.SliceStable(n.secrets, func(i, j int) bool { return len(n.secrets[i]) > len(n.secrets[j]) })
+}
+
+func (n *notifications) Success() {
+	if n == nil || n.ended {
+		return
+	}
+	n.ended = true
+	n.send(notificationData{Event: notifySuccess})
+}
+
+// Failure sends failed component name, the message, and the tail of operation output
+func (n *notifications) Failure(componentName, message string) {
+	if n == nil || n.ended {
+		return
+	}
+	n.ended = true
+	n.send(notificationData{Event: notifyFailure, Component: componentName, Message: message,
+		Log: redactLog(n.tail.String(), n.secrets)})
+}
+
+func (n *notifications) AwaitingInput(prompt string) {
+	if n == nil || n.awaiting {
+		return
+	}
+	n.awaiting = true
+	n.send(notificationData{Event: notifyInput, Message: prompt})
+}
+
+func (t *operationTail) Write(b []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := strings.Split(string(append(t.partial, b...)), "\n")
+	t.lines = append(t.lines, lines[:len(lines)-1]...)
+	t.partial = []byte(lines[len(lines)-1])
+	if len(t.lines) > notificationLogTailLines {
+		t.lines = t.lines[len(t.lines)-notificationLogTailLines:]
+	}
+	return len(b), nil
+}
+
+func (t *operationTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := t.lines
+	if len(t.partial) > 0 {
+		lines = append(append([]string{}, lines...), string(t.partial))
+		if len(lines) > notificationLogTailLines {
+			lines = lines[1:]
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func (n *notifications) send(data notificationData) {
+	data.Stack = n.stack
+	data.Verb = n.verb
+	data.Operation = n.operation
+	data.Time = time.Now()
+	data.Host, _ = os.Hostname()
+	for _, target := range n.targets {
+		if len(target.On) > 0 && !util.Contains(target.On, data.Event) {
+			continue
+		}
+		var err error
+		switch target.Kind {
+		case notificationWebhook:
+			err = sendWebhookNotification(target, data)
+		case notificationSlack:
+			err = sendSlackNotification(target, data)
+		case notificationSmtp:
+			err = sendSmtpNotification(target, data)
+		}
+		if err != nil {
+			util.Warn("Unable to send %s notification: %v", target.Kind, err)
+		} else if config.Debug {
+			log.Printf("Sent %s notification to %s", data.Event, target.Kind)
+		}
+	}
+}
+
+func notificationTitle(data notificationData) string {
+	what := "succeeded"
+	switch data.Event {
+	case notifyFailure:
+		what = "failed"
+	case notifyInput:
+		what = "is awaiting input"
+	}
+	return fmt.Sprintf("Stack %s %s %s", data.Stack, data.Verb, what)
+}
+
+func notificationText(data notificationData) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Operation: %s\n", data.Operation)
+	if data.Component != "" {
+		fmt.Fprintf(&text, "Component: %s\n", data.Component)
+	}
+	if data.Host != "" {
+		fmt.Fprintf(&text, "Host: %s\n", data.Host)
+	}
+	if data.Message != "" {
+		fmt.Fprintf(&text, "\n%s\n", data.Message)
+	}
+	if data.Log != "" {
+		fmt.Fprintf(&text, "\n%s\n", data.Log)
+	}
+	return text.String()
+}
+
+func executeNotificationTemplate(kind, text string, data notificationData) (string, error) {
+	tmpl, err := template.New(kind).Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			bytes, err := json.Marshal(v)
+			return string(bytes), err
+		},
+		"title": func() string { return notificationTitle(data) },
+		"text":  func() string { return notificationText(data) },
+	}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse %s template: %v", kind, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("Unable to execute %s template: %v", kind, err)
+	}
+	return out.String(), nil
+}
+
+func postNotification(target manifest.Notification, body []byte) error {
+	req, err := http.NewRequest("POST", os.ExpandEnv(target.URL), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for header, value := range target.Headers {
+		req.Header.Set(header, os.ExpandEnv(value))
+	}
+	resp, err := util.RobustHttpClient(notificationTimeout, false).Do(req)
+	if err != nil {
+		return err
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned HTTP status %d: %s", req.URL.Host, resp.StatusCode, util.Trim(string(respBody)))
+	}
+	return nil
+}
+
+func sendWebhookNotification(target manifest.Notification, data notificationData) error {
+	var body []byte
+	if target.Body == "" {
+		bytes, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		body = bytes
+	} else {
+		text, err := executeNotificationTemplate("webhook body", target.Body, data)
+		if err != nil {
+			return err
+		}
+		body = []byte(text)
+		if !json.Valid(body) {
+			return fmt.Errorf("Webhook body template produced invalid JSON: %s", util.Trim(text))
+		}
+	}
+	return postNotification(target, body)
+}
+
+// sendSlackNotification posts to Slack-compatible incoming webhook: Slack, Mattermost, Rocket.Chat
+func sendSlackNotification(target manifest.Notification, data notificationData) error {
+	icon := ":white_check_mark:"
+	switch data.Event {
+	case notifyFailure:
+		icon = ":x:"
+	case notifyInput:
+		icon = ":hourglass:"
+	}
+	text := fmt.Sprintf("%s *%s*\nOperation: `%s`", icon, notificationTitle(data), data.Operation)
+	if data.Component != "" {
+		text += fmt.Sprintf("\nComponent: `%s`", data.Component)
+	}
+	if data.Message != "" {
+		text += "\n" + data.Message
+	}
+	if data.Log != "" {
+		text += "\n```\n" + data.Log + "\n```"
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	return postNotification(target, body)
+}
+
+func sendSmtpNotification(target manifest.Notification, data notificationData) error {
+	addr := os.ExpandEnv(target.Host)
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+		addr = net.JoinHostPort(addr, "25")
+	}
+	from := target.From
+	if from == "" {
+		from = fmt.Sprintf("hub@%s", util.Value(data.Host, "localhost"))
+	}
+	subject := notificationTitle(data)
+	if target.Subject != "" {
+		subject, err = executeNotificationTemplate("subject", target.Subject, data)
+		if err != nil {
+			return err
+		}
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n",
+		from, strings.Join(target.To, ", "), strings.TrimSpace(subject), data.Time.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(notificationText(data), "\n", "\r\n"))
+
+	conn, err := net.DialTimeout("tcp", addr, notificationTimeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(notificationTimeout))
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if target.Username != "" {
+		auth := smtp.PlainAuth("", os.ExpandEnv(target.Username), os.ExpandEnv(target.Password), host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range target.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg.Bytes())
+	err2 := w.Close()
+	if err != nil || err2 != nil {
+		return errors.New(util.Errors2(err, err2))
+	}
+	return client.Quit()
+}
@@ -0,0 +1,263 @@
+package lifecycle
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+)
+
+type testWebhook struct {
+	mu      sync.Mutex
+	server  *httptest.Server
+	bodies  [][]byte
+	headers []http.Header
+}
+
+func startTestWebhook() *testWebhook {
+	w := &testWebhook{}
+	w.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.mu.Lock()
+		w.bodies = append(w.bodies, body)
+		w.headers = append(w.headers, r.Header)
+		w.mu.Unlock()
+	}))
+	return w
+}
+
+type testSmtpServer struct {
+	listener net.Listener
+	done     chan struct{}
+	from     string
+	to       []string
+	data     string
+}
+
+// startTestSmtpServer accepts a single SMTP session without STARTTLS and AUTH extensions
+func startTestSmtpServer(t *testing.T) *testSmtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testSmtpServer{listener: listener, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+		reply("220 localhost ESMTP test")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimRight(line, "\r\n")
+			verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+			switch verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				s.from = command
+				reply("250 OK")
+			case "RCPT":
+				s.to = append(s.to, command)
+				reply("250 OK")
+			case "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+	return s
+}
+
+func testNotifications(targets ...manifest.Notification) *notifications {
+	activeNotifications = nil
+	n := startNotifications(targets, "op-1", "deploy", "test-stack")
+	n.Stop()
+	return n
+}
+
+func TestWebhookNotification(t *testing.T) {
+	webhook := startTestWebhook()
+	defer webhook.server.Close()
+
+	n := testNotifications(manifest.Notification{
+		URL:     webhook.server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	n.Success()
+	n.Failure("app", "not sent twice")
+
+	if len(webhook.bodies) != 1 {
+		t.Fatalf("webhook received %d requests, want 1", len(webhook.bodies))
+	}
+	var data notificationData
+	if err := json.Unmarshal(webhook.bodies[0], &data); err != nil {
+		t.Fatalf("unable to unmarshal webhook body: %v", err)
+	}
+	if data.Event != notifySuccess || data.Stack != "test-stack" || data.Verb != "deploy" || data.Operation != "op-1" {
+		t.Errorf("unexpected webhook body %+v", data)
+	}
+	if auth := webhook.headers[0].Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("Authorization = `%s`", auth)
+	}
+}
+
+func TestWebhookNotificationTemplate(t *testing.T) {
+	webhook := startTestWebhook()
+	defer webhook.server.Close()
+
+	n := testNotifications(manifest.Notification{
+		URL:  webhook.server.URL,
+		On:   []string{notifyFailure},
+		Body: `{"summary": {{ title | json }}, "component": "{{ .Component }}", "details": {{ text | json }}}`,
+	})
+	n.Failure("app", "Failed to deploy app")
+
+	if len(webhook.bodies) != 1 {
+		t.Fatalf("webhook received %d requests, want 1", len(webhook.bodies))
+	}
+	var body map[string]string
+	if err := json.Unmarshal(webhook.bodies[0], &body); err != nil {
+		t.Fatalf("unable to unmarshal webhook body: %v: %s", err, webhook.bodies[0])
+	}
+	if body["summary"] != "Stack test-stack deploy failed" {
+		t.Errorf("summary = `%s`", body["summary"])
+	}
+	if body["component"] != "app" {
+		t.Errorf("component = `%s`", body["component"])
+	}
+	if !strings.Contains(body["details"], "Operation: op-1") || !strings.Contains(body["details"], "Failed to deploy app") {
+		t.Errorf("details = `%s`", body["details"])
+	}
+}
+
+func TestSlackNotification(t *testing.T) {
+	webhook := startTestWebhook()
+	defer webhook.server.Close()
+
+	n := testNotifications(manifest.Notification{Kind: notificationSlack, URL: webhook.server.URL})
+	n.AwaitingInput("Enter password")
+	n.AwaitingInput("Enter password")
+
+	if len(webhook.bodies) != 1 {
+		t.Fatalf("slack webhook received %d requests, want 1", len(webhook.bodies))
+	}
+	var body map[string]string
+	if err := json.Unmarshal(webhook.bodies[0], &body); err != nil {
+		t.Fatalf("unable to unmarshal slack body: %v", err)
+	}
+	text := body["text"]
+	if !strings.HasPrefix(text, ":hourglass: *Stack test-stack deploy is awaiting input*") ||
+		!strings.Contains(text, "Enter password") {
+		t.Errorf("text = `%s`", text)
+	}
+}
+
+func TestSmtpNotification(t *testing.T) {
+	server := startTestSmtpServer(t)
+	defer server.listener.Close()
+
+	n := testNotifications(manifest.Notification{
+		Host:    server.listener.Addr().String(),
+		From:    "hub@example.com",
+		To:      []string{"ops@example.com", "dev@example.com"},
+		Subject: "[{{ .Event }}] {{ .Stack }}",
+	})
+	n.Failure("app", "Failed to deploy app")
+	<-server.done
+
+	if server.from != "MAIL FROM:<hub@example.com>" {
+		t.Errorf("from = `%s`", server.from)
+	}
+	if len(server.to) != 2 || server.to[1] != "RCPT TO:<dev@example.com>" {
+		t.Errorf("to = %v", server.to)
+	}
+	for _, want := range []string{"Subject: [failure] test-stack\r\n", "To: ops@example.com, dev@example.com\r\n",
+		"Component: app\r\n", "Failed to deploy app\r\n"} {
+		if !strings.Contains(server.data, want) {
+			t.Errorf("message does not contain `%s`:\n%s", strings.TrimSpace(want), server.data)
+		}
+	}
+}
+
+func TestFailureNotificationLogTail(t *testing.T) {
+	webhook := startTestWebhook()
+	defer webhook.server.Close()
+
+	n := testNotifications(manifest.Notification{URL: webhook.server.URL})
+	n.Secrets([]parameters.LockedParameter{
+		{Name: "component.db.password", Value: "s3cr3t-value"},
+		{Name: "component.db.user", Value: "admin"},
+	})
+	out := n.output()
+	for i := 0; i < notificationLogTailLines+5; i++ {
+		fmt.Fprintf(out, "line %d\n", i)
+	}
+	fmt.Fprint(out, "connecting as admin with s3cr3t-value\nrequest rejected, API_TOKEN=abcdef1234")
+	n.Failure("", "Failed to deploy")
+
+	var data notificationData
+	if err := json.Unmarshal(webhook.bodies[0], &data); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(data.Log, "\n")
+	if len(lines) != notificationLogTailLines {
+		t.Errorf("log tail has %d lines, want %d", len(lines), notificationLogTailLines)
+	}
+	if strings.Contains(data.Log, "s3cr3t-value") || strings.Contains(data.Log, "abcdef1234") {
+		t.Errorf("secret is not masked in log tail:\n%s", data.Log)
+	}
+	if !strings.Contains(data.Log, "connecting as admin") || !strings.HasPrefix(lines[len(lines)-1], "request rejected, API_TOKEN=") {
+		t.Errorf("unexpected log tail:\n%s", data.Log)
+	}
+}
+
+func TestNotificationsStop(t *testing.T) {
+	activeNotifications = nil
+	n := startNotifications([]manifest.Notification{{URL: "http://localhost"}}, "op-1", "deploy", "test-stack")
+	if activeNotifications != n {
+		t.Fatal("notifications are not active")
+	}
+	n.Stop()
+	if activeNotifications != nil {
+		t.Error("notifications are still active after Stop")
+	}
+	next := startNotifications([]manifest.Notification{{URL: "http://localhost"}}, "op-2", "undeploy", "test-stack")
+	if next == nil || next.operation != "op-2" {
+		t.Errorf("next operation notifications = %+v", next)
+	}
+	next.Stop()
+}
@@ -2,10 +2,16 @@ package lifecycle
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/toolchain"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -23,6 +29,8 @@ var (
 	noTfOsEnv = []string{
 		"TF_VAR_*",
 	}
+
+	toolchainPath []string
 )
 
 func initOsEnv(mode string) ([]string, error) {
@@ -79,3 +87,47 @@ func mergeOsEnviron(toMerge ...[]string) []string {
 	}
 	return res
 }
+
+func setToolchain(stackToolchain manifest.Toolchain, stackBaseDir string) {
+	dirs, missing := toolchain.Path(stackToolchain, stackBaseDir)
+	if len(missing) > 0 && activeSimulation != nil {
+		if config.Verbose {
+			log.Printf("Toolchain is not installed: %s; ignored in simulation", toolchain.ToolsNames(missing))
+		}
+	} else if len(missing) > 0 {
+		util.MaybeFatalf("Toolchain is not installed: %s; run `hub tools install`", toolchain.ToolsNames(missing))
+	}
+	if config.Debug && len(dirs) > 0 {
+		log.Printf("Toolchain PATH: %s", strings.Join(dirs, string(os.PathListSeparator)))
+	}
+	toolchainPath = dirs
+}
+
+// toolchainEnv prepends stack toolchain directories to PATH
+func toolchainEnv(osEnv []string) []string {
+	if len(toolchainPath) == 0 {
+		return nil
+	}
+	path := strings.Join(toolchainPath, string(os.PathListSeparator))
+	for _, v := range osEnv {
+		if strings.HasPrefix(v, "PATH=") {
+			if current := v[5:]; current != "" {
+				path = path + string(os.PathListSeparator) + current
+			}
+			break
+		}
+	}
+	return []string{"PATH=" + path}
+}
+
+func toolchainLookPath(name string) (string, error) {
+	if !strings.ContainsRune(name, os.PathSeparator) {
+		for _, dir := range toolchainPath {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return exec.LookPath(name)
+}
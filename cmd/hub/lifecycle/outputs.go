@@ -13,6 +13,7 @@ import (
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -27,6 +28,9 @@ func captureOutputs(componentName, componentDir string, componentManifest *manif
 	componentParameters parameters.LockedParameters,
 	textOutput []byte, random []byte) (parameters.RawOutputs, parameters.CapturedOutputs, []string, []error) {
 
+	span := tracing.Start("capture outputs", "hub.component", componentName)
+	defer span.End()
+
 	tfOutputs := parseTextOutput(textOutput)
 	secrets := extractSecrets(tfOutputs, random)
 	if len(secrets) > 0 {
@@ -69,6 +73,7 @@ func expandRequestedOutputs(componentName, componentDir string,
 	outputs := make(parameters.CapturedOutputs)
 	errs := make([]error, 0)
 	for _, requestedOutput := range requestedOutputs {
+		errsBefore := len(errs)
 		output := parameters.CapturedOutput{
 			Component: componentName,
 			Name:      requestedOutput.Name,
@@ -76,7 +81,7 @@ func expandRequestedOutputs(componentName, componentDir string,
 			Kind:      requestedOutput.Kind,
 		}
 		if requestedOutput.FromTfVar != "" {
-			variable, encodings := valueEncodings(requestedOutput.FromTfVar)
+			variable, encodings := parameters.SplitEncodings(requestedOutput.FromTfVar)
 			value, exist := tfOutputs[variable]
 			if !exist {
 				errs = append(errs, fmt.Errorf("Unable to capture raw output `%s` for component `%s` output `%s`",
@@ -168,6 +173,12 @@ func expandRequestedOutputs(componentName, componentDir string,
 		}
 		outputs[output.QName()] = output
 		kv[requestedOutput.Name] = output.Value
+		// do not pile assertion errors on top of capture errors
+		if requestedOutput.Assert != nil && len(errs) == errsBefore {
+			errs = append(errs, parameters.AssertOutput(
+				fmt.Sprintf("Component `%s` output `%s`", componentName, requestedOutput.Name),
+				requestedOutput.Assert, output.Value, componentName, nil, kv)...)
+		}
 	}
 	return outputs, errs
 }
@@ -23,7 +23,7 @@ var hubToSkaffoldVerbs = map[string]string{
 func findImplementation(dir string, verb string) (*exec.Cmd, error) {
 	makefile, err := probeMakefile(dir, verb)
 	if makefile {
-		binMake, err := exec.LookPath("make")
+		binMake, err := toolchainLookPath("make")
 		if err != nil {
 			binMake = "/usr/bin/make"
 			util.WarnOnce("Unable to lookup `make` in PATH: %v; trying `%s`", err, binMake)
@@ -44,7 +44,7 @@ func findImplementation(dir string, verb string) (*exec.Cmd, error) {
 	}
 	skaffold, err5 := probeSkaffold(dir, verb)
 	if skaffold {
-		binSkaffold, err := exec.LookPath("skaffold")
+		binSkaffold, err := toolchainLookPath("skaffold")
 		if err != nil {
 			binSkaffold = "/usr/local/bin/skaffold"
 			util.WarnOnce("Unable to lookup `skaffold` in PATH: %v; trying `%s`", err, binSkaffold)
@@ -63,6 +63,9 @@ func findImplementation(dir string, verb string) (*exec.Cmd, error) {
 }
 
 func probeImplementation(dir string, verb string) (bool, error) {
+	if activeSimulation != nil {
+		return true, nil
+	}
 	makefile, err := probeMakefile(dir, verb)
 	if makefile {
 		return true, nil
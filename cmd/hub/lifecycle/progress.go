@@ -0,0 +1,406 @@
+package lifecycle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	progressPending = "pending"
+	progressRunning = "running"
+	progressDone    = "done"
+	progressFailed  = "failed"
+	progressSkipped = "skipped"
+
+	progressTailLines   = 8
+	progressRefresh     = 250 * time.Millisecond
+	progressDefaultRows = 24
+	progressDefaultCols = 80
+)
+
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+type componentProgress struct {
+	name    string
+	status  string
+	start   time.Time
+	end     time.Time
+	retries int
+}
+
+type progressTail struct {
+	lines   []string
+	partial []byte
+}
+
+// progress is a full-screen view of lifecycle operation redrawn at the bottom of the terminal;
+// Hub CLI log messages are printed above it, sub-process output is shown as last lines of the
+// running components only
+type progress struct {
+	mu         sync.Mutex
+	out        *os.File
+	title      string
+	start      time.Time
+	components []*componentProgress
+	index      map[string]*componentProgress
+	tails      map[string]*progressTail
+	active     []string // names of executing sub-processes, including hooks
+	frameLines int
+	dirty      bool
+	logOutput  io.Writer
+	stop       chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
+}
+
+var activeProgress *progress
+
+// startProgress activates progress view when running in terminal, otherwise plain output is kept
+func startProgress(verb, stackName string, order []string) *progress {
+	if !config.Tty || config.Debug || activeProgress != nil {
+		return nil
+	}
+	out := lifecycleStdout
+	if !isatty.IsTerminal(out.Fd()) {
+		if isatty.IsTerminal(os.Stderr.Fd()) {
+			out = os.Stderr
+		} else if !config.TtyForced {
+			return nil
+		}
+	}
+	p := &progress{
+		out:        out,
+		title:      fmt.Sprintf("%s %s", verb, stackName),
+		start:      time.Now(),
+		components: make([]*componentProgress, 0, len(order)),
+		index:      make(map[string]*componentProgress),
+		tails:      make(map[string]*progressTail),
+		logOutput:  log.Writer(),
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	for _, name := range order {
+		c := &componentProgress{name: name, status: progressPending}
+		p.components = append(p.components, c)
+		p.index[name] = c
+	}
+	log.SetOutput(progressLog{p})
+	activeProgress = p
+	go p.refresh()
+	// fatal exits must not leave the frame mid-screen
+	util.AtDone(func() <-chan struct{} {
+		p.Stop()
+		return nil
+	})
+	return p
+}
+
+func (p *progress) refresh() {
+	ticker := time.NewTicker(progressRefresh)
+	defer ticker.Stop()
+	defer close(p.stopped)
+	lastDraw := time.Now()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			if p.dirty || now.Sub(lastDraw) >= time.Second {
+				p.redraw()
+				lastDraw = now
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Stop draws final frame and restores plain output
+func (p *progress) Stop() {
+	if p == nil {
+		return
+	}
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		<-p.stopped
+		p.mu.Lock()
+		p.active = nil
+		p.redraw()
+		p.mu.Unlock()
+		log.SetOutput(p.logOutput)
+		activeProgress = nil
+	})
+}
+
+func (p *progress) setStatus(name, status string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c, exist := p.index[name]
+	if !exist {
+		return
+	}
+	now := time.Now()
+	switch status {
+	case progressRunning:
+		c.start = now
+		c.end = time.Time{}
+	case progressDone, progressFailed:
+		if c.start.IsZero() {
+			c.start = now
+		}
+		c.end = now
+	}
+	c.status = status
+	p.clear()
+	if status == progressFailed {
+		// keep last lines of failed component output on screen
+		if t, exist := p.tails[name]; exist && len(t.lines) > 0 {
+			fmt.Fprintf(p.logOutput, "%s\n", util.ErrorColor(fmt.Sprintf("--- %s", name)))
+			for _, line := range t.lines {
+				fmt.Fprintf(p.logOutput, "  %s\n", line)
+			}
+		}
+	}
+	p.draw()
+}
+
+func (p *progress) Start(name string) { p.setStatus(name, progressRunning) }
+func (p *progress) Done(name string)  { p.setStatus(name, progressDone) }
+func (p *progress) Fail(name string)  { p.setStatus(name, progressFailed) }
+func (p *progress) Skip(name string)  { p.setStatus(name, progressSkipped) }
+
+func (p *progress) Retry(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, exist := p.index[name]; exist {
+		c.retries++
+		p.dirty = true
+	}
+}
+
+// execOutput returns sub-process output writer and a function to call when sub-process exits
+func (p *progress) execOutput(name string) (io.Writer, func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tails[name] = &progressTail{}
+	p.active = append(p.active, name)
+	p.dirty = true
+	return progressExecOutput{p, name}, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for i, active := range p.active {
+			if active == name {
+				p.active = append(p.active[:i], p.active[i+1:]...)
+				break
+			}
+		}
+		p.redraw()
+	}
+}
+
+type progressExecOutput struct {
+	p    *progress
+	name string
+}
+
+func (w progressExecOutput) Write(b []byte) (int, error) {
+	w.p.mu.Lock()
+	defer w.p.mu.Unlock()
+	t := w.p.tails[w.name]
+	data := append(t.partial, b...)
+	lines := bytes.Split(data, []byte{'\n'})
+	for _, line := range lines[:len(lines)-1] {
+		t.lines = append(t.lines, cleanProgressLine(line))
+	}
+	t.partial = append([]byte{}, lines[len(lines)-1]...)
+	if len(t.lines) > progressTailLines {
+		t.lines = t.lines[len(t.lines)-progressTailLines:]
+	}
+	w.p.dirty = true
+	return len(b), nil
+}
+
+type progressLog struct {
+	p *progress
+}
+
+// Write prints log message above the frame; the frame is redrawn by refresh() on next tick so that
+// a message followed by log.Fatal exit does not leave the frame drawn below the error
+func (w progressLog) Write(b []byte) (int, error) {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clear()
+	n, err := p.logOutput.Write(b)
+	p.dirty = true
+	return n, err
+}
+
+func cleanProgressLine(line []byte) string {
+	line = ansiEscapeRegexp.ReplaceAll(line, nil)
+	if i := bytes.LastIndexByte(line, '\r'); i >= 0 {
+		if i == len(line)-1 {
+			line = line[:i]
+		} else {
+			line = line[i+1:]
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return ' '
+		}
+		if r < ' ' {
+			return -1
+		}
+		return r
+	}, string(line))
+}
+
+func truncateProgressLine(line string, cols int) string {
+	runes := []rune(line)
+	if len(runes) >= cols {
+		return string(runes[:cols-1])
+	}
+	return line
+}
+
+func formatProgressDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+func (p *progress) clear() {
+	if p.frameLines > 0 {
+		fmt.Fprintf(p.out, "\r\033[%dA\033[0J", p.frameLines)
+		p.frameLines = 0
+	}
+}
+
+func (p *progress) redraw() {
+	p.clear()
+	p.draw()
+}
+
+func (p *progress) draw() {
+	rows, cols := terminalSize(p.out)
+	if rows <= 0 {
+		rows = progressDefaultRows
+	}
+	if cols <= 0 {
+		cols = progressDefaultCols
+	}
+
+	tail := make([]string, 0)
+	for _, name := range p.active {
+		t := p.tails[name]
+		tail = append(tail, util.HighlightColor(truncateProgressLine(fmt.Sprintf("--- %s", name), cols)))
+		lines := t.lines
+		if len(t.partial) > 0 {
+			lines = append(append([]string{}, lines...), cleanProgressLine(t.partial))
+		}
+		for _, line := range lines {
+			tail = append(tail, truncateProgressLine("  "+line, cols))
+		}
+	}
+	// keep at least header and a few components visible on a small screen
+	if max := rows - 6; len(tail) > max {
+		if max < 0 {
+			max = 0
+		}
+		tail = tail[len(tail)-max:]
+	}
+
+	completed := 0
+	firstActive := -1
+	for i, c := range p.components {
+		switch c.status {
+		case progressDone, progressFailed, progressSkipped:
+			completed++
+		case progressRunning:
+			if firstActive < 0 {
+				firstActive = i
+			}
+		}
+	}
+	frame := make([]string, 0, len(p.components)+len(tail)+1)
+	frame = append(frame, truncateProgressLine(fmt.Sprintf("%s [%d/%d] %s",
+		p.title, completed, len(p.components), formatProgressDuration(time.Since(p.start))), cols))
+
+	// window of components around the running one when the list does not fit the screen
+	room := rows - 2 - len(tail)
+	if room < 1 {
+		room = 1
+	}
+	from, to := 0, len(p.components)
+	if to > room {
+		if firstActive < 0 {
+			firstActive = completed
+		}
+		from = firstActive - 2
+		if from < 0 {
+			from = 0
+		}
+		if from+room > to {
+			from = to - room
+		}
+		to = from + room
+	}
+	for _, c := range p.components[from:to] {
+		frame = append(frame, p.componentLine(c, cols))
+	}
+	frame = append(frame, tail...)
+
+	for _, line := range frame {
+		fmt.Fprintf(p.out, "%s\n", line)
+	}
+	p.frameLines = len(frame)
+	p.dirty = false
+}
+
+func (p *progress) componentLine(c *componentProgress, cols int) string {
+	mark := " "
+	color := func(s string) string { return s }
+	elapsed := ""
+	switch c.status {
+	case progressRunning:
+		mark = ">"
+		color = util.HighlightColor
+		elapsed = formatProgressDuration(time.Since(c.start))
+	case progressDone:
+		mark = "+"
+		elapsed = formatProgressDuration(c.end.Sub(c.start))
+	case progressFailed:
+		mark = "x"
+		color = util.ErrorColor
+		elapsed = formatProgressDuration(c.end.Sub(c.start))
+	case progressSkipped:
+		mark = "-"
+	}
+	retries := ""
+	if c.retries > 0 {
+		retries = fmt.Sprintf("  %d %s", c.retries, util.Plural(c.retries, "retry", "retries"))
+	}
+	line := truncateProgressLine(fmt.Sprintf(" %s %-30s %-8s %7s%s", mark, c.name, c.status, elapsed, retries), cols)
+	return color(line)
+}
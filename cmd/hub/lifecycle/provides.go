@@ -1,6 +1,7 @@
 package lifecycle
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -41,6 +42,34 @@ func mergePlatformProvides(provides map[string][]string, platformProvides []stri
 	}
 }
 
+// checkProvidedOutputs verifies the component emitted non-empty outputs required
+// via stack `lifecycle.requires.outputs` for capabilities it provides
+func checkProvidedOutputs(requiredOutputs map[string][]string, componentName string, componentProvides []string,
+	componentOutputs ...parameters.CapturedOutputs) []error {
+
+	errs := make([]error, 0)
+	for _, prov := range componentProvides {
+		for _, reqOutput := range requiredOutputs[prov] {
+			qName := parameters.OutputQualifiedName(reqOutput, componentName)
+			var output parameters.CapturedOutput
+			exist := false
+			for _, outputs := range componentOutputs {
+				if output, exist = outputs[qName]; exist {
+					break
+				}
+			}
+			if !exist {
+				errs = append(errs, fmt.Errorf("Component `%s` provides `%s` but no `%s` output found",
+					componentName, prov, reqOutput))
+			} else if util.Empty(output.Value) {
+				errs = append(errs, fmt.Errorf("Component `%s` provides `%s` but `%s` output is empty",
+					componentName, prov, reqOutput))
+			}
+		}
+	}
+	return errs
+}
+
 func mergeProvides(provides map[string][]string, componentName string, componentProvides []string,
 	componentOutputs parameters.CapturedOutputs) {
 
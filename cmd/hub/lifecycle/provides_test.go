@@ -0,0 +1,47 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+)
+
+func TestCheckProvidedOutputs(t *testing.T) {
+	required := map[string][]string{
+		"postgresql": {"db.host", "db.port"},
+		"redis":      {"redis.url"},
+	}
+	outputs := parameters.CapturedOutputs{
+		"db:db.host": {Component: "db", Name: "db.host", Value: "db.local"},
+		"db:db.port": {Component: "db", Name: "db.port", Value: ""},
+	}
+	complexOutputs := parameters.CapturedOutputs{
+		"db:redis.url": {Component: "db", Name: "redis.url", Value: "redis://db.local"},
+	}
+	tests := []struct {
+		name     string
+		provides []string
+		outputs  []parameters.CapturedOutputs
+		errors   []string
+	}{
+		{"nothing required", []string{"kubernetes"}, []parameters.CapturedOutputs{outputs}, nil},
+		{"empty and missing", []string{"postgresql", "redis"}, []parameters.CapturedOutputs{outputs}, []string{
+			"Component `db` provides `postgresql` but `db.port` output is empty",
+			"Component `db` provides `redis` but no `redis.url` output found",
+		}},
+		{"complex outputs", []string{"redis"}, []parameters.CapturedOutputs{outputs, complexOutputs}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := checkProvidedOutputs(required, "db", test.provides, test.outputs...)
+			if len(errs) != len(test.errors) {
+				t.Fatalf("errors = %v, want %v", errs, test.errors)
+			}
+			for i := range test.errors {
+				if errs[i].Error() != test.errors[i] {
+					t.Errorf("error = `%v`, want `%s`", errs[i], test.errors[i])
+				}
+			}
+		})
+	}
+}
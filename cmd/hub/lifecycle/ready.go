@@ -2,81 +2,307 @@ package lifecycle
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
+	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
+const (
+	defaultReadyConditionWaitSeconds     = 1200
+	defaultReadyConditionIntervalSeconds = 10
+
+	readyConditionReady   = "ready"
+	readyConditionFailed  = "failed"
+	readyConditionTimeout = "timeout"
+)
+
+var errReadyConditionTimeout = errors.New("timeout")
+
+type readyCheck struct {
+	condition string // reported into state file, ie. `url:http://host/health`
+	waiting   string
+	timeout   string
+	once      bool // no polling, ie. CEL expression over outputs that won't change
+	check     func(context.Context) (bool, string, error)
+}
+
+// waitForReadyConditions checks conditions in order, returns status of every checked condition
+// to be recorded into state; error is returned on first failed condition
 func waitForReadyConditions(ctx context.Context, conditions []manifest.ReadyCondition,
-	parameters parameters.LockedParameters, outputs parameters.CapturedOutputs, componentDepends []string) error {
+	ectx *execContext) ([]state.ReadyConditionStatus, error) {
 
-	for _, condition := range conditions {
-		err := waitForReadyCondition(ctx, condition, parameters, outputs, componentDepends)
+	statuses := make([]state.ReadyConditionStatus, 0)
+	if len(conditions) == 0 {
+		return statuses, nil
+	}
+	span := tracing.Start("ready conditions", "hub.component", ectx.name)
+	defer span.End()
+	for i, condition := range conditions {
+		conditionStatuses, err := waitForReadyCondition(ctx, i, condition, ectx)
+		statuses = append(statuses, conditionStatuses...)
 		if err != nil {
-			return err
+			span.SetError(err)
+			return statuses, err
 		}
 	}
-	return nil
+	return statuses, nil
 }
 
 func expandReadyConditionParameter(what string, value string, componentDepends []string, kv map[string]interface{}) string {
+	if value == "" {
+		return value
+	}
 	piggy := manifest.Parameter{Name: fmt.Sprintf("lifecycle.readyCondition.%s", what), Value: value}
 	parameters.ExpandParameter(&piggy, componentDepends, kv)
 	return util.String(piggy.Value)
 }
 
-const defaultReadyConditionWaitSeconds = 1200
+func waitForReadyCondition(ctx context.Context, index int, condition manifest.ReadyCondition,
+	ectx *execContext) ([]state.ReadyConditionStatus, error) {
 
-func waitForReadyCondition(ctx context.Context, condition manifest.ReadyCondition,
-	params parameters.LockedParameters, outputs parameters.CapturedOutputs, componentDepends []string) error {
+	wait, interval := readyConditionTimings(condition)
+	kv := parameters.ParametersAndOutputsKV(ectx.parameters, ectx.outputs, nil)
+	checks, err := readyChecks(index, condition, interval, ectx, kv)
+	if err != nil {
+		return nil, err
+	}
+	if sim := activeSimulation; sim != nil {
+		for i := range checks {
+			check := &checks[i]
+			name, checkCondition := ectx.name, check.condition
+			check.once = true
+			check.check = func(context.Context) (bool, string, error) { return sim.ready(name, checkCondition) }
+		}
+		condition.PauseSeconds = 0
+	}
 
 	if condition.PauseSeconds > 0 {
 		why := ""
 		if config.Verbose {
-			if condition.DNS != "" || condition.URL != "" {
+			if len(checks) > 0 {
 				why = " before checking for ready condition(s)"
 			}
 			log.Printf("Sleeping %d seconds%s", condition.PauseSeconds, why)
 		}
 		select {
 		case <-ctx.Done():
-			return context.Canceled
+			return nil, context.Canceled
 		case <-time.After(time.Duration(condition.PauseSeconds) * time.Second):
 		}
 	}
 
-	if condition.DNS == "" && condition.URL == "" {
-		return nil
+	statuses := make([]state.ReadyConditionStatus, 0, len(checks))
+	for _, check := range checks {
+		if config.Verbose {
+			log.Print(check.waiting)
+		}
+		start := time.Now()
+		err := pollReadyCheck(ctx, check, wait, interval,
+			func() {
+				activeProgress.Retry(ectx.name)
+				activeEvents.Retry(ectx.name, check.condition)
+				metrics.Count("hub_ready_retries_total", 1, "component", ectx.name)
+			})
+		status := state.ReadyConditionStatus{
+			Condition: check.condition,
+			Status:    readyConditionReady,
+			Timestamp: util.Now(),
+			Seconds:   int(time.Since(start).Seconds()),
+		}
+		if err != nil {
+			status.Status = readyConditionFailed
+			if err == errReadyConditionTimeout {
+				status.Status = readyConditionTimeout
+				err = errors.New(check.timeout)
+			}
+			status.Message = err.Error()
+		}
+		statuses = append(statuses, status)
+		if err != nil {
+			return statuses, err
+		}
 	}
+	return statuses, nil
+}
 
+// readyConditionTimings returns overall wait and polling interval of the condition;
+// the interval is also a timeout of a single check attempt
+func readyConditionTimings(condition manifest.ReadyCondition) (time.Duration, time.Duration) {
 	wait := condition.WaitSeconds
 	if wait <= 0 {
 		wait = defaultReadyConditionWaitSeconds
 	}
-	kv := parameters.ParametersAndOutputsKV(params, outputs, nil)
-	if condition.DNS != "" {
-		fqdn := expandReadyConditionParameter("DNS", condition.DNS, componentDepends, kv)
-		err := waitForFqdn(ctx, maybeStripPort(fqdn), wait)
+	interval := condition.IntervalSeconds
+	if interval <= 0 {
+		interval = defaultReadyConditionIntervalSeconds
+	}
+	if interval > wait {
+		interval = wait
+	}
+	return time.Duration(wait) * time.Second, time.Duration(interval) * time.Second
+}
+
+func pollReadyCheck(ctx context.Context, check readyCheck, wait, interval time.Duration, retry func()) error {
+	start := time.Now()
+	lastMsg := ""
+	for {
+		ready, msg, err := check.check(ctx)
+		if msg != "" && (config.Debug || (config.Verbose && lastMsg != msg)) {
+			log.Print(msg)
+			lastMsg = msg
+		}
 		if err != nil {
 			return err
 		}
+		if ready {
+			return nil
+		}
+		if check.once {
+			return fmt.Errorf("%s: %s", check.condition, msg)
+		}
+		if time.Since(start)+interval > wait {
+			return errReadyConditionTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case <-time.After(interval):
+		}
+		retry()
+	}
+}
+
+func readyChecks(index int, condition manifest.ReadyCondition, attempt time.Duration,
+	ectx *execContext, kv map[string]interface{}) ([]readyCheck, error) {
+
+	expand := func(what, value string) string {
+		return expandReadyConditionParameter(fmt.Sprintf("%d.%s", index, what), value, ectx.depends, kv)
+	}
+	checks := make([]readyCheck, 0)
+
+	if condition.DNS != "" {
+		fqdn := maybeStripPort(expand("dns", condition.DNS))
+		checks = append(checks, readyCheck{
+			condition: "dns:" + fqdn,
+			waiting:   fmt.Sprintf("Waiting for `%s` in DNS to resolve to an accessible address", fqdn),
+			timeout:   fmt.Sprintf("Timeout waiting for `%s` to resolve", fqdn),
+			check:     fqdnCheck(fqdn),
+		})
+	}
+	if condition.TCP != "" {
+		address := expand("tcp", condition.TCP)
+		checks = append(checks, readyCheck{
+			condition: "tcp:" + address,
+			waiting:   fmt.Sprintf("Waiting for `%s` to accept TCP connections", address),
+			timeout:   fmt.Sprintf("Timeout waiting for `%s` to accept TCP connections", address),
+			check:     tcpCheck(address, attempt),
+		})
+	}
+	if condition.TLS != "" {
+		address := expand("tls", condition.TLS)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, "443")
+		}
+		checks = append(checks, readyCheck{
+			condition: "tls:" + address,
+			waiting:   fmt.Sprintf("Waiting for `%s` to present a valid TLS certificate", address),
+			timeout:   fmt.Sprintf("Timeout waiting for `%s` to present a valid TLS certificate", address),
+			check:     tlsCheck(address, condition.TLSValidDays, condition.Insecure, attempt),
+		})
 	}
 	if condition.URL != "" {
-		url := expandReadyConditionParameter("URL", condition.URL, componentDepends, kv)
-		err := waitForUrl(ctx, url, wait)
-		if err != nil {
-			return err
+		url := expand("url", condition.URL)
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("Only HTTP and HTTPS is supported in lifecycle.readyCondition.URL, expanded to `%s`", url)
 		}
+		var body *regexp.Regexp
+		if condition.Body != "" {
+			var err error
+			body, err = regexp.Compile(expand("body", condition.Body))
+			if err != nil {
+				return nil, fmt.Errorf("Unable to compile lifecycle.readyCondition.body regexp: %v", err)
+			}
+		}
+		checks = append(checks, readyCheck{
+			condition: "url:" + url,
+			waiting:   fmt.Sprintf("Waiting for `%s` to respond", url),
+			timeout:   fmt.Sprintf("Timeout waiting for `%s` to respond", url),
+			check: urlCheck(url, condition.Status, body,
+				expand("jsonPath", condition.JSONPath), expand("jsonValue", condition.JSONValue), attempt),
+		})
+	}
+	if k8s := condition.Kubernetes; k8s != nil {
+		resource := expand("kubernetes.resource", k8s.Resource)
+		if resource == "" {
+			return nil, errors.New("lifecycle.readyCondition.kubernetes.resource is not set")
+		}
+		forCondition := expand("kubernetes.for", k8s.For)
+		if forCondition == "" {
+			forCondition = "condition=Available"
+			if strings.HasPrefix(resource, "job") {
+				forCondition = "condition=Complete"
+			}
+		}
+		args := kubectlWaitArgs(resource, forCondition,
+			expand("kubernetes.namespace", k8s.Namespace), expand("kubernetes.selector", k8s.Selector), attempt)
+		checks = append(checks, readyCheck{
+			condition: fmt.Sprintf("kubernetes:%s %s", resource, forCondition),
+			waiting:   fmt.Sprintf("Waiting for Kubernetes `%s` to meet `%s`", resource, forCondition),
+			timeout:   fmt.Sprintf("Timeout waiting for Kubernetes `%s` to meet `%s`", resource, forCondition),
+			check:     commandCheck("kubectl", args, ectx),
+		})
+	}
+	if condition.Command != "" {
+		command := expand("command", condition.Command)
+		checks = append(checks, readyCheck{
+			condition: "command:" + command,
+			waiting:   fmt.Sprintf("Waiting for `%s` to exit with zero code", command),
+			timeout:   fmt.Sprintf("Timeout waiting for `%s` to exit with zero code", command),
+			check:     commandCheck("sh", []string{"-c", command}, ectx),
+		})
+	}
+	if condition.CEL != "" {
+		checks = append(checks, readyCheck{
+			condition: "cel:" + condition.CEL,
+			waiting:   fmt.Sprintf("Evaluating `%s`", condition.CEL),
+			once:      true,
+			check:     celCheck(condition.CEL, ectx, kv),
+		})
+	}
+	return checks, nil
+}
+
+func kubectlWaitArgs(resource, forCondition, namespace, selector string, timeout time.Duration) []string {
+	seconds := int(timeout.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	args := []string{"wait", "--for=" + forCondition, resource, fmt.Sprintf("--timeout=%ds", seconds)}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	if selector != "" {
+		args = append(args, "--selector", selector)
 	}
-	return nil
+	return args
 }
 
 func maybeStripPort(fqdn string) string {
@@ -87,89 +313,226 @@ func maybeStripPort(fqdn string) string {
 	return fqdn
 }
 
-func waitForFqdn(ctx context.Context, fqdn string, waitSeconds int) error {
-	if config.Verbose {
-		log.Printf("Waiting for `%s` in DNS to resolve to an accessible address", fqdn)
-	}
-	start := time.Now()
-	lastMsg := ""
-	for time.Since(start) < time.Duration(waitSeconds)*time.Second {
+func fqdnCheck(fqdn string) func(context.Context) (bool, string, error) {
+	return func(ctx context.Context) (bool, string, error) {
 		addrs, err := net.DefaultResolver.LookupHost(ctx, fqdn)
-		if config.Verbose {
-			msg := ""
-			if err != nil {
-				msg = fmt.Sprintf("%v", err)
-			} else {
-				msg = fmt.Sprintf("Resolved `%s` into: %v", fqdn, addrs)
-			}
-			if config.Debug || (config.Verbose && lastMsg != msg) {
-				log.Print(msg)
-				lastMsg = msg
+		if err != nil {
+			if util.ContextCanceled(err) {
+				return false, "", err
 			}
+			return false, fmt.Sprintf("%v", err), nil
 		}
-		if util.ContextCanceled(err) {
-			return err
-		}
-		if err == nil && len(addrs) > 0 {
+		msg := fmt.Sprintf("Resolved `%s` into: %v", fqdn, addrs)
+		if len(addrs) > 0 {
 			addr := addrs[0]
 			if len(addr) >= 7 && addr != "127.0.0.1" && addr != "1.0.0.1" {
-				return nil
+				return true, msg, nil
 			}
 		}
-		time.Sleep(10 * time.Second)
+		return false, msg, nil
 	}
-	return fmt.Errorf("Timeout waiting for `%s` to resolve", fqdn)
 }
 
-func waitForUrl(ctx context.Context, url string, waitSeconds int) error {
-	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-		return fmt.Errorf("Only HTTP and HTTPS is supported in lifecycle.readyCondition.URL, expanded to `%s`", url)
+func tcpCheck(address string, timeout time.Duration) func(context.Context) (bool, string, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	return func(ctx context.Context) (bool, string, error) {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			if util.ContextCanceled(err) {
+				return false, "", err
+			}
+			return false, fmt.Sprintf("%v", err), nil
+		}
+		conn.Close()
+		return true, fmt.Sprintf("Connected to `%s`", address), nil
 	}
-	if config.Verbose {
-		log.Printf("Waiting for `%s` to respond", url)
+}
+
+func tlsCheck(address string, validDays int, insecure bool, timeout time.Duration) func(context.Context) (bool, string, error) {
+	host, _, _ := net.SplitHostPort(address)
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config:    &tls.Config{ServerName: host, InsecureSkipVerify: insecure},
+	}
+	return func(ctx context.Context) (bool, string, error) {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			if util.ContextCanceled(err) {
+				return false, "", err
+			}
+			return false, fmt.Sprintf("%v", err), nil
+		}
+		defer conn.Close()
+		certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return false, fmt.Sprintf("`%s` presented no TLS certificate", address), nil
+		}
+		cert := certs[0]
+		now := time.Now()
+		if now.Before(cert.NotBefore) {
+			return false, fmt.Sprintf("`%s` TLS certificate is not valid before %v", address, cert.NotBefore), nil
+		}
+		if now.Add(time.Duration(validDays) * 24 * time.Hour).After(cert.NotAfter) {
+			return false, fmt.Sprintf("`%s` TLS certificate expires at %v - less than %d day(s) from now",
+				address, cert.NotAfter, validDays), nil
+		}
+		return true, fmt.Sprintf("`%s` TLS certificate `%s` is valid until %v",
+			address, cert.Subject.CommonName, cert.NotAfter), nil
 	}
-	interval := time.Duration(10) * time.Second
-	client := util.RobustHttpClient(interval, true)
+}
+
+func urlCheck(url string, status []int, body *regexp.Regexp, jsonPath, jsonValue string,
+	timeout time.Duration) func(context.Context) (bool, string, error) {
+
+	client := util.RobustHttpClient(timeout, true)
 	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 		return http.ErrUseLastResponse
 	}
-	start := time.Now()
-	lastMsg := ""
-	for time.Since(start) < time.Duration(waitSeconds)*time.Second {
+	strict := len(status) > 0 || body != nil || jsonPath != ""
+	return func(ctx context.Context) (bool, string, error) {
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
-			return err
+			return false, "", err
 		}
 		response, err := client.Do(req)
-		if config.Verbose {
-			msg := ""
+		if err != nil {
+			if util.ContextCanceled(err) {
+				return false, "", err
+			}
+			return false, fmt.Sprintf("%v", err), nil
+		}
+		defer response.Body.Close()
+		msg := fmt.Sprintf("`%s` responded with: %s", url, response.Status)
+		if config.Trace {
+			msg = fmt.Sprintf("`%s` responded with:\n\t%+v", url, response)
+		}
+		if !strict {
+			return response.StatusCode >= 100 && response.StatusCode < 500, msg, nil
+		}
+		if len(status) > 0 {
+			if !containsInt(status, response.StatusCode) {
+				return false, msg, nil
+			}
+		} else if response.StatusCode < 200 || response.StatusCode >= 300 {
+			return false, msg, nil
+		}
+		if body == nil && jsonPath == "" {
+			return true, msg, nil
+		}
+		bytes, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return false, fmt.Sprintf("Unable to read `%s` response: %v", url, err), nil
+		}
+		if body != nil && !body.Match(bytes) {
+			return false, fmt.Sprintf("`%s` response body does not match `%s`", url, body.String()), nil
+		}
+		if jsonPath != "" {
+			var doc interface{}
+			err := json.Unmarshal(bytes, &doc)
 			if err != nil {
-				msg = fmt.Sprintf("%v", err)
-			} else {
-				if config.Trace {
-					msg = fmt.Sprintf("`%s` responded with:\n\t%+v", url, response)
-				} else {
-					msg = fmt.Sprintf("`%s` responded with: %s", url, response.Status)
-				}
+				return false, fmt.Sprintf("Unable to unmarshal `%s` response as JSON: %v", url, err), nil
 			}
-			if config.Debug || (config.Verbose && lastMsg != msg) {
-				log.Print(msg)
-				lastMsg = msg
+			value, exist := jsonPathValue(doc, jsonPath)
+			if !exist || util.Empty(value) {
+				return false, fmt.Sprintf("`%s` response has no `%s`", url, jsonPath), nil
+			}
+			if jsonValue != "" && util.String(value) != jsonValue {
+				return false, fmt.Sprintf("`%s` response `%s` = `%s` while `%s` is expected",
+					url, jsonPath, util.String(value), jsonValue), nil
 			}
 		}
-		if util.ContextCanceled(err) {
-			return err
+		return true, msg, nil
+	}
+}
+
+func containsInt(list []int, value int) bool {
+	for _, v := range list {
+		if v == value {
+			return true
 		}
-		if err == nil {
-			response.Body.Close()
-			if response.StatusCode >= 100 && response.StatusCode < 500 {
-				return nil
+	}
+	return false
+}
+
+// jsonPathValue resolves dot-separated path with optional array indexes, ie. `items[0].status.phase`
+func jsonPathValue(doc interface{}, path string) (interface{}, bool) {
+	value := doc
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if segment == "" {
+			continue
+		}
+		key := segment
+		indexes := make([]int, 0)
+		if i := strings.Index(segment, "["); i >= 0 {
+			key = segment[:i]
+			for _, index := range strings.Split(strings.TrimSuffix(segment[i+1:], "]"), "][") {
+				n, err := strconv.Atoi(index)
+				if err != nil {
+					return nil, false
+				}
+				indexes = append(indexes, n)
 			}
-			if config.Verbose {
-				log.Printf("`%s` responded with: %s", url, response.Status)
+		}
+		if key != "" {
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			value, ok = obj[key]
+			if !ok {
+				return nil, false
+			}
+		}
+		for _, index := range indexes {
+			array, ok := value.([]interface{})
+			if !ok || index < 0 || index >= len(array) {
+				return nil, false
 			}
+			value = array[index]
+		}
+	}
+	return value, true
+}
+
+func commandCheck(name string, args []string, ectx *execContext) func(context.Context) (bool, string, error) {
+	var env []string
+	return func(ctx context.Context) (bool, string, error) {
+		if env == nil {
+			env = mergeOsEnviron(ectx.osEnv, toolchainEnv(ectx.osEnv), ectx.parametersInEnv())
+		}
+		cmd := exec.CommandContext(ctx, name, args...)
+		if path, err := toolchainLookPath(name); err == nil {
+			cmd.Path = path
+		}
+		cmd.Dir = ectx.dir
+		cmd.Env = env
+		out, err := cmd.CombinedOutput()
+		if ctx.Err() != nil {
+			return false, "", context.Canceled
+		}
+		if err != nil {
+			if _, isExit := err.(*exec.ExitError); !isExit {
+				return false, "", fmt.Errorf("Unable to run `%s`: %v", name, err)
+			}
+			return false, fmt.Sprintf("%s %v: %v%s", name, args, err, formatStdoutStderr(out, nil)), nil
+		}
+		return true, fmt.Sprintf("%s %v succeeded", name, args), nil
+	}
+}
+
+func celCheck(expr string, ectx *execContext, kv map[string]interface{}) func(context.Context) (bool, string, error) {
+	component := ""
+	if ectx.component {
+		component = ectx.name
+	}
+	return func(context.Context) (bool, string, error) {
+		result, err := parameters.CelEval(expr, component, ectx.depends, kv)
+		if err != nil {
+			return false, "", err
+		}
+		if result != "true" {
+			return false, fmt.Sprintf("`%s` evaluated to `%s`", expr, result), nil
 		}
-		time.Sleep(interval)
+		return true, fmt.Sprintf("`%s` evaluated to `true`", expr), nil
 	}
-	return fmt.Errorf("Timeout waiting for `%s` to respond", url)
 }
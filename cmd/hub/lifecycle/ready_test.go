@@ -0,0 +1,186 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+const testReadyTimeout = 2 * time.Second
+
+func testReadyServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			fmt.Fprint(w, `{"status": {"phase": "Running", "ready": true}, "items": [{"name": "a"}, {"name": "b"}]}`)
+		case "/starting":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"status": {"phase": "Pending"}}`)
+		case "/teapot":
+			w.WriteHeader(http.StatusTeapot)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestUrlCheck(t *testing.T) {
+	server := testReadyServer()
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		status    []int
+		body      string
+		jsonPath  string
+		jsonValue string
+		ready     bool
+	}{
+		{name: "any non-5xx", path: "/starting", ready: false},
+		{name: "default", path: "/teapot", ready: true},
+		{name: "2xx by default", path: "/health", body: "Running", ready: true},
+		{name: "2xx required", path: "/teapot", body: ".*", ready: false},
+		{name: "status", path: "/teapot", status: []int{418}, ready: true},
+		{name: "status mismatch", path: "/health", status: []int{201, 204}, ready: false},
+		{name: "body", path: "/health", body: `"phase":\s*"Running"`, ready: true},
+		{name: "body mismatch", path: "/health", body: "Pending", ready: false},
+		{name: "jsonPath", path: "/health", jsonPath: "status.ready", ready: true},
+		{name: "jsonPath index", path: "/health", jsonPath: "items[1].name", jsonValue: "b", ready: true},
+		{name: "jsonPath missing", path: "/health", jsonPath: "status.message", ready: false},
+		{name: "jsonValue mismatch", path: "/health", jsonPath: ".status.phase", jsonValue: "Failed", ready: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body *regexp.Regexp
+			if test.body != "" {
+				body = regexp.MustCompile(test.body)
+			}
+			check := urlCheck(server.URL+test.path, test.status, body, test.jsonPath, test.jsonValue, testReadyTimeout)
+			ready, msg, err := check(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ready != test.ready {
+				t.Errorf("ready = %v, want %v: %s", ready, test.ready, msg)
+			}
+		})
+	}
+}
+
+func TestUrlCheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	ready, _, err := urlCheck(server.URL, nil, nil, "", "", 200*time.Millisecond)(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ready {
+		t.Error("ready = true for a server that never responds")
+	}
+	if elapsed := time.Since(start); elapsed > testReadyTimeout {
+		t.Errorf("check took %v, attempt timeout was not applied", elapsed)
+	}
+}
+
+func TestTcpCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	ready, msg, err := tcpCheck(address, testReadyTimeout)(context.Background())
+	if err != nil || !ready {
+		t.Errorf("listening `%s`: ready = %v, err = %v: %s", address, ready, err, msg)
+	}
+
+	listener.Close()
+	ready, _, err = tcpCheck(address, testReadyTimeout)(context.Background())
+	if err != nil || ready {
+		t.Errorf("closed `%s`: ready = %v, err = %v", address, ready, err)
+	}
+}
+
+func TestTlsCheck(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	address := server.Listener.Addr().String()
+	notAfter := server.Certificate().NotAfter
+	daysLeft := int(time.Until(notAfter).Hours() / 24)
+
+	tests := []struct {
+		name      string
+		validDays int
+		insecure  bool
+		ready     bool
+	}{
+		{name: "self-signed", validDays: 0, insecure: false, ready: false},
+		{name: "valid", validDays: 0, insecure: true, ready: true},
+		{name: "valid for days", validDays: daysLeft - 1, insecure: true, ready: true},
+		{name: "expires soon", validDays: daysLeft + 1, insecure: true, ready: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, msg, err := tlsCheck(address, test.validDays, test.insecure, testReadyTimeout)(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ready != test.ready {
+				t.Errorf("ready = %v, want %v: %s", ready, test.ready, msg)
+			}
+		})
+	}
+}
+
+func TestReadyConditionTimings(t *testing.T) {
+	tests := []struct {
+		condition manifest.ReadyCondition
+		wait      time.Duration
+		interval  time.Duration
+	}{
+		{manifest.ReadyCondition{},
+			defaultReadyConditionWaitSeconds * time.Second, defaultReadyConditionIntervalSeconds * time.Second},
+		{manifest.ReadyCondition{WaitSeconds: 60, IntervalSeconds: 3}, 60 * time.Second, 3 * time.Second},
+		{manifest.ReadyCondition{WaitSeconds: 5}, 5 * time.Second, 5 * time.Second},
+	}
+	for _, test := range tests {
+		wait, interval := readyConditionTimings(test.condition)
+		if wait != test.wait || interval != test.interval {
+			t.Errorf("%+v: wait, interval = %v, %v; want %v, %v",
+				test.condition, wait, interval, test.wait, test.interval)
+		}
+	}
+}
+
+func TestKubectlWaitArgs(t *testing.T) {
+	_, interval := readyConditionTimings(manifest.ReadyCondition{IntervalSeconds: 42})
+	args := kubectlWaitArgs("deployment/app", "condition=Available", "apps", "", interval)
+	want := "wait --for=condition=Available deployment/app --timeout=42s --namespace apps"
+	if got := strings.Join(args, " "); got != want {
+		t.Errorf("args = `%s`, want `%s`", got, want)
+	}
+}
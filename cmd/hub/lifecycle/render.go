@@ -13,7 +13,7 @@ import (
 )
 
 func Render(manifestFilenames, stateFilenames []string, componentName,
-	templateKind, additionalParametersStr string, templates []string) {
+	templateKind, additionalParametersStr string, templates []string, scratchDir string) {
 
 	var stackManifest *manifest.Manifest
 	var componentsManifests []manifest.Manifest
@@ -61,6 +61,10 @@ func Render(manifestFilenames, stateFilenames []string, componentName,
 	var params parameters.LockedParameters
 	var outputs parameters.CapturedOutputs
 
+	if stackManifest != nil {
+		stackBaseDir := util.Basedir(manifestFilenames)
+		setTemplateComponentsDirs(stackManifest.Components, stackBaseDir, stackBaseDir)
+	}
 	if stackManifest != nil && componentName != "" {
 		manifest.CheckComponentsExist(stackManifest.Components, componentName)
 		component := manifest.ComponentRefByName(stackManifest.Components, componentName)
@@ -123,6 +127,10 @@ func Render(manifestFilenames, stateFilenames []string, componentName,
 	if componentName == "" {
 		componentName = "*stack*"
 	}
+	if scratchDir != "" {
+		templateScratchDir = util.MustAbs(scratchDir)
+		defer func() { templateScratchDir = "" }()
+	}
 	ref := &manifest.ComponentRef{Name: componentName}
 	errs = processTemplates(ref, &templateSetup, params, outputs, dir)
 	if len(errs) > 0 {
@@ -0,0 +1,456 @@
+package lifecycle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/state"
+	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	envVarNameRenderedDir = "HUB_RENDERED_DIR"
+	defaultRenderedDir    = ".hub/rendered"
+	// --rendered-dir value to disable rendered templates cache
+	noRenderedDir = "-"
+
+	renderedIndexFilename = "index.yaml"
+	renderedFilesDir      = "files"
+	renderedStackName     = "stack"
+	// number of operations to keep rendered templates of, per component
+	renderedKeepOperations = 5
+)
+
+// RenderedFile is an entry of rendered templates cache index
+type RenderedFile struct {
+	Path   string // relative to component source directory
+	Sha256 string
+}
+
+// renderedCache keeps a copy of component's rendered templates under
+// <rendered dir>/<operation id>/<component>/ to compare with on `hub render --diff`;
+// rendered templates may contain secrets so the cache is kept out of Git and pruned
+type renderedCache struct {
+	root  string
+	dir   string
+	index map[string][]RenderedFile
+}
+
+var (
+	activeRendered *renderedCache
+
+	// templateScratchDir, if set, receives rendered templates instead of component source directory
+	templateScratchDir string
+)
+
+// RenderedDir returns rendered templates cache directory, or empty string if disabled
+func RenderedDir(requested, stackBaseDir string) string {
+	dir := util.Value(requested, os.Getenv(envVarNameRenderedDir))
+	if dir == noRenderedDir {
+		return ""
+	}
+	if dir == "" {
+		dir = filepath.Join(stackBaseDir, defaultRenderedDir)
+	}
+	return util.MustAbs(dir)
+}
+
+func startRenderedCache(request *Request, stackBaseDir, operationId string) *renderedCache {
+	renderedDir := RenderedDir(request.RenderedDir, stackBaseDir)
+	// simulated deploy must not become the last deploy to diff against in the default cache,
+	// an explicitly requested --rendered-dir is used by component test
+	if renderedDir == "" || activeRendered != nil || (activeSimulation != nil && request.RenderedDir == "") {
+		return nil
+	}
+	activeRendered = &renderedCache{
+		root:  renderedDir,
+		dir:   filepath.Join(renderedDir, operationId),
+		index: make(map[string][]RenderedFile),
+	}
+	if config.Debug {
+		log.Printf("Keeping rendered templates in %s", activeRendered.dir)
+	}
+	return activeRendered
+}
+
+func (c *renderedCache) Stop() {
+	if c == nil {
+		return
+	}
+	components := make([]string, 0, len(c.index))
+	for componentName := range c.index {
+		components = append(components, componentName)
+	}
+	sort.Strings(components)
+	for _, componentName := range components {
+		c.prune(componentName)
+	}
+	activeRendered = nil
+}
+
+// prune removes component's rendered templates of all but the last renderedKeepOperations operations
+func (c *renderedCache) prune(componentName string) {
+	operations, err := ioutil.ReadDir(c.root)
+	if err != nil {
+		util.Warn("Unable to prune rendered templates cache: %v", err)
+		return
+	}
+	type cached struct {
+		dir     string
+		modTime int64
+	}
+	kept := make([]cached, 0, len(operations))
+	for _, operation := range operations {
+		if !operation.IsDir() {
+			continue
+		}
+		dir := filepath.Join(c.root, operation.Name(), componentName)
+		info, err := os.Stat(filepath.Join(dir, renderedIndexFilename))
+		if err != nil {
+			continue
+		}
+		kept = append(kept, cached{dir: dir, modTime: info.ModTime().UnixNano()})
+	}
+	if len(kept) <= renderedKeepOperations {
+		return
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].modTime > kept[j].modTime })
+	for _, old := range kept[renderedKeepOperations:] {
+		if filepath.Dir(old.dir) == c.dir {
+			continue
+		}
+		if config.Debug {
+			log.Printf("Pruning rendered templates in %s", old.dir)
+		}
+		if err := os.RemoveAll(old.dir); err != nil {
+			util.Warn("Unable to prune `%s` rendered templates cache: %v", componentName, err)
+			continue
+		}
+		// operation directory is removed once all its components are pruned
+		os.Remove(filepath.Dir(old.dir))
+	}
+}
+
+// ignore writes .gitignore into cache root to keep rendered templates, and secrets in them, out of Git
+func (c *renderedCache) ignore() {
+	gitignore := filepath.Join(c.root, ".gitignore")
+	if _, err := os.Stat(gitignore); err == nil {
+		return
+	}
+	if err := ioutil.WriteFile(gitignore, []byte("*\n"), 0600); err != nil {
+		util.Warn("Unable to write `%s`: %v", gitignore, err)
+	}
+}
+
+func (c *renderedCache) Reset(componentName string) {
+	if c == nil {
+		return
+	}
+	delete(c.index, componentName)
+	if err := os.RemoveAll(filepath.Join(c.dir, componentName)); err != nil {
+		util.Warn("Unable to clean `%s` rendered templates cache: %v", componentName, err)
+	}
+}
+
+// Add stores a copy of rendered template output and updates component's index
+func (c *renderedCache) Add(componentName, relPath, content string) {
+	if c == nil {
+		return
+	}
+	componentDir := filepath.Join(c.dir, componentName)
+	path := filepath.Join(componentDir, renderedFilesDir, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		util.Warn("Unable to create rendered templates cache directory: %v", err)
+		return
+	}
+	c.ignore()
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		util.Warn("Unable to write rendered template copy `%s`: %v", path, err)
+		return
+	}
+	sum := sha256.Sum256([]byte(content))
+	files := c.index[componentName]
+	found := false
+	for i := range files {
+		if files[i].Path == relPath {
+			files[i].Sha256 = hex.EncodeToString(sum[:])
+			found = true
+		}
+	}
+	if !found {
+		files = append(files, RenderedFile{Path: relPath, Sha256: hex.EncodeToString(sum[:])})
+	}
+	c.index[componentName] = files
+	bytes, err := yaml.Marshal(files)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(componentDir, renderedIndexFilename), bytes, 0600)
+	}
+	if err != nil {
+		util.Warn("Unable to write `%s` rendered templates index: %v", componentName, err)
+	}
+}
+
+// renderedRelPath returns template output path relative to component directory;
+// outputs outside of the component directory have `..` replaced by `_up`
+func renderedRelPath(componentDir, outPath string) string {
+	rel, err := filepath.Rel(util.MustAbs(componentDir), util.MustAbs(outPath))
+	if err != nil {
+		return filepath.Base(outPath)
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i, part := range parts {
+		if part == ".." {
+			parts[i] = "_up"
+		}
+	}
+	return filepath.Join(parts...)
+}
+
+func renderedComponentDirName(componentName string) string {
+	if componentName == "*stack*" {
+		return renderedStackName
+	}
+	return componentName
+}
+
+// scratchOutputPath relocates template output into scratch directory if one is set
+func scratchOutputPath(componentName, componentDir, outPath string) string {
+	if templateScratchDir == "" {
+		return outPath
+	}
+	return filepath.Join(templateScratchDir, renderedComponentDirName(componentName),
+		renderedRelPath(componentDir, outPath))
+}
+
+func readRenderedIndex(dir string) ([]RenderedFile, error) {
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, renderedIndexFilename))
+	if err != nil {
+		return nil, err
+	}
+	var files []RenderedFile
+	err = yaml.Unmarshal(bytes, &files)
+	return files, err
+}
+
+// lastDeployedRendered finds the latest deploy operation recorded in state with the component phase succeeded
+// and rendered templates cached
+func lastDeployedRendered(stateManifest *state.StateManifest, renderedDir, componentName string) (string, []RenderedFile) {
+	for i := len(stateManifest.Operations) - 1; i >= 0; i-- {
+		op := stateManifest.Operations[i]
+		if op.Operation != "deploy" {
+			continue
+		}
+		succeeded := false
+		for _, phase := range op.Phases {
+			if phase.Phase == componentName && phase.Status == "success" {
+				succeeded = true
+				break
+			}
+		}
+		if !succeeded {
+			continue
+		}
+		dir := filepath.Join(renderedDir, op.Id, componentName)
+		files, err := readRenderedIndex(dir)
+		if err != nil {
+			if config.Debug {
+				log.Printf("No rendered templates of `%s` for operation %s: %v", componentName, op.Id, err)
+			}
+			continue
+		}
+		return op.Id, files
+	}
+	return "", nil
+}
+
+// RenderDiff renders templates of components with current parameters and state outputs into a scratch directory
+// and prints unified diff against files rendered by the last successful deploy; the number of changed files is returned
+func RenderDiff(manifestFilenames, stateFilenames []string, componentsBaseDir, renderedDir string,
+	componentNames []string) int {
+
+	stackManifest, componentsManifests, _, err := manifest.ParseManifest(manifestFilenames)
+	if err != nil {
+		log.Fatalf("Unable to parse: %v", err)
+	}
+	stateFiles, errs := storage.Check(stateFilenames, "state")
+	if len(errs) > 0 {
+		log.Fatalf("Unable to check state files: %s", util.Errors2(errs...))
+	}
+	stateManifest, err := state.ParseState(stateFiles)
+	if err != nil {
+		log.Fatalf("Unable to load state: %v", err)
+	}
+
+	stackBaseDir := util.Basedir(manifestFilenames)
+	if componentsBaseDir == "" {
+		componentsBaseDir = stackBaseDir
+	}
+	renderedDir = RenderedDir(renderedDir, stackBaseDir)
+	if renderedDir == "" {
+		log.Fatal("Rendered templates cache is disabled")
+	}
+	if len(componentNames) > 0 {
+		manifest.CheckComponentsExist(stackManifest.Components, componentNames...)
+	}
+	setTemplateComponentsDirs(stackManifest.Components, stackBaseDir, componentsBaseDir)
+
+	scratch, err := ioutil.TempDir("", "hub-render-")
+	if err != nil {
+		log.Fatalf("Unable to create scratch directory: %v", err)
+	}
+	defer os.RemoveAll(scratch)
+	templateScratchDir = scratch
+	defer func() { templateScratchDir = "" }()
+
+	stackParameters := renderDiffStackParameters(stackManifest, stateManifest)
+
+	changed := 0
+	for _, componentName := range stackManifest.Lifecycle.Order {
+		if len(componentNames) > 0 && !util.Contains(componentNames, componentName) {
+			continue
+		}
+		component := manifest.ComponentRefByName(stackManifest.Components, componentName)
+		componentManifest := manifest.ComponentManifestByRef(componentsManifests, component)
+		if componentManifest == nil {
+			continue
+		}
+		templates := componentManifest.Templates
+		if len(templates.Files) == 0 && len(templates.Directories) == 0 && len(templates.Extra) == 0 {
+			continue
+		}
+
+		outputs := make(parameters.CapturedOutputs)
+		state.MergeParsedStateOutputs(stateManifest,
+			componentName, component.Depends, stackManifest.Lifecycle.Order, true,
+			outputs)
+		expanded, errs := parameters.ExpandParameters(componentName, componentManifest.Meta.Kind, component.Depends,
+			stackParameters, outputs,
+			manifest.FlattenParameters(componentManifest.Parameters, componentManifest.Meta.Name))
+		if len(errs) > 0 {
+			util.MaybeFatalf("Component `%s` parameters expansion failed:\n\t%s",
+				componentName, util.Errors("\n\t", errs...))
+		}
+		expanded = addHubProvides(expanded, stateManifest.Provides)
+		componentParameters := parameters.MergeParameters(make(parameters.LockedParameters), expanded)
+
+		dir := manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
+		errs = processTemplates(component, &componentManifest.Templates, componentParameters, outputs, dir)
+		if len(errs) > 0 {
+			util.MaybeFatalf("Failed to process `%s` templates:\n\t%s",
+				componentName, util.Errors("\n\t", errs...))
+		}
+
+		changed += printRenderedDiff(stateManifest, renderedDir, componentName,
+			filepath.Join(scratch, renderedComponentDirName(componentName)))
+	}
+	if changed == 0 {
+		fmt.Print("No changes\n")
+	}
+	return changed
+}
+
+// renderDiffStackParameters locks current stack parameters without asking for input:
+// user-level parameters without value are taken from state
+func renderDiffStackParameters(stackManifest *manifest.Manifest, stateManifest *state.StateManifest) parameters.LockedParameters {
+	stateParameters := parameters.ParametersFromList(stateManifest.StackParameters)
+	deploymentId := ""
+	if p, exist := stateParameters["hub.deploymentId"]; exist {
+		deploymentId = util.String(p.Value)
+	}
+	plainStackName := util.PlainName(stackManifest.Meta.Name)
+	extra := []manifest.Parameter{
+		{Name: "hub.deploymentId", Value: deploymentId},
+		{Name: "hub.stackName", Value: plainStackName},
+	}
+	stackParameters, errs := parameters.LockParameters(
+		manifest.FlattenParameters(stackManifest.Parameters, stackManifest.Meta.Name),
+		extra,
+		func(parameter manifest.Parameter) (interface{}, error) {
+			if p, exist := stateParameters[parameter.QName()]; exist {
+				return p.Value, nil
+			}
+			if parameter.Env != "" {
+				if value, exist := os.LookupEnv(parameter.Env); exist {
+					return value, nil
+				}
+			}
+			return parameter.Default, nil
+		})
+	if len(errs) > 0 {
+		util.MaybeFatalf("Failed to lock stack parameters:\n\t%s", util.Errors("\n\t", errs...))
+	}
+	state.MergeParsedStateParametersAndProvides(stateManifest, stackParameters, nil)
+	addLockedParameter(stackParameters, "hub.deploymentId", "DEPLOYMENT_ID", deploymentId)
+	addLockedParameter(stackParameters, "hub.stackName", "STACK_NAME", plainStackName)
+	return stackParameters
+}
+
+func printRenderedDiff(stateManifest *state.StateManifest, renderedDir, componentName, scratchDir string) int {
+	operationId, deployed := lastDeployedRendered(stateManifest, renderedDir, componentName)
+	if operationId == "" {
+		util.Warn("No rendered templates of `%s` found for a successful deploy; showing all files as new", componentName)
+	}
+	oldDir := filepath.Join(renderedDir, operationId, componentName, renderedFilesDir)
+
+	deployedPaths := renderedPaths(deployed)
+	paths := append([]string{}, deployedPaths...)
+	filepath.Walk(scratchDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			if rel, err := filepath.Rel(scratchDir, path); err == nil && !util.Contains(paths, rel) {
+				paths = append(paths, rel)
+			}
+		}
+		return nil
+	})
+	sort.Strings(paths)
+
+	changed := 0
+	for _, path := range paths {
+		oldName, newName := "/dev/null", "/dev/null"
+		var oldContent, newContent string
+		if util.Contains(deployedPaths, path) {
+			bytes, err := ioutil.ReadFile(filepath.Join(oldDir, path))
+			if err != nil {
+				util.Warn("Unable to read rendered template copy: %v", err)
+				continue
+			}
+			oldContent = string(bytes)
+			oldName = fmt.Sprintf("a/%s/%s\t(operation %s)", componentName, path, operationId)
+		}
+		if bytes, err := ioutil.ReadFile(filepath.Join(scratchDir, path)); err == nil {
+			newContent = string(bytes)
+			newName = fmt.Sprintf("b/%s/%s", componentName, path)
+		}
+		diff := util.UnifiedDiff(oldName, newName, oldContent, newContent, 3)
+		if diff == "" && (oldName == "/dev/null") != (newName == "/dev/null") {
+			// empty file added or removed
+			diff = fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName)
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			changed++
+		}
+	}
+	return changed
+}
+
+func renderedPaths(files []RenderedFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
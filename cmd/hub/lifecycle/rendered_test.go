@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRenderedCachePrune(t *testing.T) {
+	root, err := ioutil.TempDir("", "hub-rendered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	start := time.Now().Add(-time.Hour)
+	operations := renderedKeepOperations + 2
+	for i := 0; i < operations; i++ {
+		activeRendered = nil
+		cache := startRenderedCache(&Request{RenderedDir: root}, root, fmt.Sprintf("op-%d", i))
+		cache.Add("app", "values.yaml", "password: secret")
+		if i == 0 {
+			cache.Add("db", "values.yaml", "password: secret")
+		}
+		// deploy operations are minutes apart, filesystem timestamps might be not
+		modTime := start.Add(time.Duration(i) * time.Minute)
+		for _, component := range []string{"app", "db"} {
+			os.Chtimes(filepath.Join(root, fmt.Sprintf("op-%d", i), component, renderedIndexFilename), modTime, modTime)
+		}
+		cache.Stop()
+	}
+
+	for i := 0; i < operations; i++ {
+		_, err := os.Stat(filepath.Join(root, fmt.Sprintf("op-%d", i), "app", renderedIndexFilename))
+		if kept := i >= operations-renderedKeepOperations; kept != (err == nil) {
+			t.Errorf("op-%d app rendered templates kept = %v, want %v", i, err == nil, kept)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "op-0", "db", renderedIndexFilename)); err != nil {
+		t.Errorf("db rendered templates of its only operation are pruned: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "op-1")); !os.IsNotExist(err) {
+		t.Errorf("empty operation directory is not removed: %v", err)
+	}
+	if gitignore, err := ioutil.ReadFile(filepath.Join(root, ".gitignore")); err != nil || string(gitignore) != "*\n" {
+		t.Errorf(".gitignore = %q, %v", gitignore, err)
+	}
+}
+
+func TestRenderedCacheSimulation(t *testing.T) {
+	root, err := ioutil.TempDir("", "hub-rendered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	activeRendered = nil
+	activeSimulation = &simulation{}
+	defer func() { activeSimulation = nil }()
+
+	if cache := startRenderedCache(&Request{}, root, "op-1"); cache != nil {
+		cache.Stop()
+		t.Error("simulated deploy keeps rendered templates in default cache directory")
+	}
+	cache := startRenderedCache(&Request{RenderedDir: root}, root, "op-1")
+	if cache == nil {
+		t.Fatal("simulated deploy does not keep rendered templates in requested directory")
+	}
+	cache.Stop()
+}
@@ -12,6 +12,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/Masterminds/semver"
+
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/kube"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
@@ -40,7 +42,14 @@ func prepareComponentRequires(provided map[string][]string, componentManifest *m
 	optionalNotProvided := make([]string, 0)
 
 	componentName := manifest.ComponentQualifiedNameFromMeta(&componentManifest.Meta)
-	for _, req := range componentRequires {
+	for _, requirement := range componentRequires {
+		req, constraint := manifest.ParseRequirement(requirement)
+		if constraint != "" {
+			if _, err := checkRequire(requirement); err != nil {
+				return optionalNotProvided, fmt.Errorf("Component `%s` requirement `%s` cannot be satisfied: %v",
+					componentName, requirement, err)
+			}
+		}
 		by, exist := provided[req]
 		if !exist || len(by) == 0 {
 			if optionalFor, exist := maybeOptional[req]; exist &&
@@ -77,7 +86,11 @@ func prepareComponentRequires(provided map[string][]string, componentManifest *m
 }
 
 func maybeOmitCloudRequires(requires, enabledClouds []string) []string {
-	if !util.ContainsAny(supportedCloudRequires, requires) {
+	names := make([]string, 0, len(requires))
+	for _, r := range requires {
+		names = append(names, manifest.RequirementName(r))
+	}
+	if !util.ContainsAny(supportedCloudRequires, names) {
 		return requires
 	}
 	if len(enabledClouds) == 0 {
@@ -92,7 +105,8 @@ func maybeOmitCloudRequires(requires, enabledClouds []string) []string {
 	}
 	modified := make([]string, 0, len(requires))
 	for _, r := range requires {
-		if !util.Contains(supportedCloudRequires, r) || util.Contains(enabledClouds, r) {
+		name := manifest.RequirementName(r)
+		if !util.Contains(supportedCloudRequires, name) || util.Contains(enabledClouds, name) {
 			modified = append(modified, r)
 		}
 	}
@@ -128,6 +142,12 @@ func guessEnabledClouds() []string {
 func setupRequirement(requirement string, provider string,
 	parameters parameters.LockedParameters, outputs parameters.CapturedOutputs) {
 
+	if activeSimulation != nil {
+		if config.Debug {
+			log.Printf("Simulating `%s` requirement setup by `%s`", requirement, provider)
+		}
+		return
+	}
 	switch requirement {
 	case "kubectl", "kubernetes":
 		kube.SetupKubernetes(parameters, provider, outputs, "", false, false)
@@ -163,6 +183,31 @@ var bins = map[string][]string{
 	"etcd":       {"etcdctl", "--version"},
 }
 
+// version commands per requirement or binary, see also `lifecycle.requires.binaries`
+var binVersionCommands = map[string][]string{
+	"aws":        {"aws", "--version"},
+	"azure":      {"az", "version"},
+	"gcp":        {"gcloud", "version"},
+	"gcs":        {"gsutil", "version"},
+	"kubectl":    {"kubectl", "version", "--client"},
+	"kubernetes": {"kubectl", "version", "--client"},
+	"helm":       {"helm", "version", "--client"},
+	"etcd":       {"etcdctl", "--version"},
+	"terraform":  {"terraform", "version"},
+	"vault":      {"vault", "version"},
+	"make":       {"make", "--version"},
+	"kustomize":  {"kustomize", "version"},
+	"skaffold":   {"skaffold", "version"},
+}
+
+var anyVersionRegexp = regexp.MustCompile(`v?(\d+\.\d+(?:\.\d+)?)`)
+
+var requiresBinaries map[string]manifest.RequiresBinary
+
+func setRequiresBinaries(binaries map[string]manifest.RequiresBinary) {
+	requiresBinaries = binaries
+}
+
 type BinVersion struct {
 	minVersion    string
 	versionRegexp *regexp.Regexp
@@ -180,27 +225,25 @@ var binVersion = map[string]BinVersion{
 func checkStackRequires(requires []string, optional, requiresOfOptionalComponents map[string][]string) map[string][]string {
 	provided := make(map[string][]string, len(requires))
 	for _, require := range requires {
+		name := manifest.RequirementName(require)
 		_, err := checkRequire(require)
 		if err == nil {
-			provided[require] = []string{providedByEnv}
+			provided[name] = []string{providedByEnv}
 			continue
 		}
-		if config.Verbose {
-			log.Printf("`%s` requirement cannot be satisfied: %v", require, err)
-		}
 		if requiredByOptional, exist := requiresOfOptionalComponents[require]; exist {
 			if config.Verbose {
-				log.Printf("Skipping requirement `%s` requested by optional components %v", require, requiredByOptional)
+				log.Printf("Skipping requirement `%s` requested by optional components %v: %v", require, requiredByOptional, err)
 			}
 			continue
 		}
-		if optionalFor, exist := optional[require]; exist {
+		if optionalFor, exist := optional[name]; exist {
 			if config.Verbose {
-				log.Printf("Skipping requirement `%s` as it is optional for %v", require, optionalFor)
+				log.Printf("Skipping requirement `%s` as it is optional for %v: %v", require, optionalFor, err)
 			}
 			continue
 		}
-		os.Exit(1)
+		log.Fatalf("`%s` requirement cannot be satisfied: %v", require, err)
 	}
 	return provided
 }
@@ -208,21 +251,30 @@ func checkStackRequires(requires []string, optional, requiresOfOptionalComponent
 var requirementsVerified = make(map[string]struct{})
 
 func checkRequire(require string) (bool, error) {
+	if activeSimulation != nil {
+		return true, nil
+	}
 	if _, exist := requirementsVerified[require]; exist {
 		return true, nil
 	}
-	switch require {
+	name, constraint := manifest.ParseRequirement(require)
+	switch name {
 	case "azure":
 		err := checkRequiresAzure()
 		if err != nil {
 			return true, err
 		}
 		setupTerraformAzureOsEnv()
+		if constraint != "" {
+			if _, err := checkRequireVersion(name, constraint); err != nil {
+				return true, err
+			}
+		}
 
 	case "aws", "gcp", "gcs", "kubectl", "kubernetes", "helm", "terraform", "vault": // "etcd"
-		bin, exist := bins[require]
+		bin, exist := bins[name]
 		if !exist {
-			bin = []string{require, "version"}
+			bin = []string{name, "version"}
 		}
 		out, err := checkRequiresBin(bin...)
 		if err != nil {
@@ -236,15 +288,25 @@ func checkRequire(require string) (bool, error) {
 					bin[0], require, err)
 			}
 		}
-		if require == "gcp" || require == "gcs" {
+		if name == "gcp" || name == "gcs" {
 			err := checkRequiresGcp()
 			if err != nil {
 				return true, err
 			}
 		}
+		if constraint != "" {
+			if _, err := checkRequireVersion(name, constraint); err != nil {
+				return true, err
+			}
+		}
 
 	default:
-		return false, errors.New("no implementation")
+		if _, declared := requiresBinaries[name]; constraint == "" && !declared {
+			return false, errors.New("no implementation")
+		}
+		if _, err := checkRequireVersion(name, constraint); err != nil {
+			return true, err
+		}
 	}
 	requirementsVerified[require] = struct{}{}
 	return true, nil
@@ -255,7 +317,10 @@ func checkRequiresBin(bin ...string) ([]byte, error) {
 		printCmd(bin)
 	}
 	cmd := exec.Command(bin[0], bin[1:]...)
-	cmd.Env = os.Environ()
+	if path, err := toolchainLookPath(bin[0]); err == nil {
+		cmd = &exec.Cmd{Path: path, Args: bin}
+	}
+	cmd.Env = mergeOsEnviron(os.Environ(), toolchainEnv(os.Environ()))
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		err = fmt.Errorf("%v: %v", bin, err)
@@ -267,21 +332,92 @@ func checkRequiresBin(bin ...string) ([]byte, error) {
 }
 
 func checkRequiresBinVersion(minVer string, verRegexp *regexp.Regexp, out []byte) error {
-	if len(out) == 0 {
-		return errors.New("no output")
+	ver, err := binVersionString(verRegexp, out)
+	if err != nil {
+		return err
 	}
-	match := verRegexp.FindSubmatch(out)
-	if len(match) != 2 {
-		return errors.New("no version string found")
+	min, err := semver.NewVersion(minVer)
+	if err != nil {
+		return fmt.Errorf("Unable to parse minimal version `%s`: %v", minVer, err)
 	}
-	ver := string(match[1])
-	// TODO here 1.6 > 1.10 which is not the case
-	if ver < minVer {
+	detected, err := semver.NewVersion(ver)
+	if err != nil {
+		return fmt.Errorf("Unable to parse version `%s`: %v", ver, err)
+	}
+	if detected.LessThan(min) {
 		return fmt.Errorf("`%s` version detected; should have at least version `%s`", ver, minVer)
 	}
 	return nil
 }
 
+func binVersionString(verRegexp *regexp.Regexp, out []byte) (string, error) {
+	if len(out) == 0 {
+		return "", errors.New("no output")
+	}
+	match := verRegexp.FindSubmatch(out)
+	if len(match) < 2 {
+		return "", errors.New("no version string found")
+	}
+	return string(match[1]), nil
+}
+
+// checkRequireVersion runs binary version command and checks the version against
+// semver constraint, ie. `>=1.3`, `~3.10`, `^2`; empty constraint only checks the binary is present
+func checkRequireVersion(name, constraint string) (string, error) {
+	cmd := []string{name, "--version"}
+	verRegexp := anyVersionRegexp
+	if versionCmd, exist := binVersionCommands[name]; exist {
+		cmd = versionCmd
+	}
+	if verReq, exist := binVersion[cmd[0]]; exist {
+		verRegexp = verReq.versionRegexp
+	}
+	if binary, exist := requiresBinaries[name]; exist {
+		if binary.Command != "" {
+			cmd = strings.Fields(binary.Command)
+		}
+		if binary.Regexp != "" {
+			re, err := regexp.Compile(binary.Regexp)
+			if err != nil {
+				return "", fmt.Errorf("Unable to compile `%s` version regexp `%s`: %v", name, binary.Regexp, err)
+			}
+			verRegexp = re
+		}
+	}
+	if _, err := toolchainLookPath(cmd[0]); err != nil {
+		return "", fmt.Errorf("`%s` not found: %v", cmd[0], err)
+	}
+	out, err := checkRequiresBin(cmd...)
+	if err != nil {
+		return "", err
+	}
+	ver, err := binVersionString(verRegexp, out)
+	if err != nil {
+		if constraint == "" {
+			return "", nil
+		}
+		return "", fmt.Errorf("`%s` version: %v", cmd[0], err)
+	}
+	if constraint == "" {
+		return ver, nil
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return ver, fmt.Errorf("Unable to parse `%s` version constraint `%s`: %v", name, constraint, err)
+	}
+	v, err := semver.NewVersion(ver)
+	if err != nil {
+		return ver, fmt.Errorf("Unable to parse `%s` version `%s`: %v", cmd[0], ver, err)
+	}
+	if !c.Check(v) {
+		return ver, fmt.Errorf("`%s` version `%s` does not satisfy `%s`", cmd[0], ver, constraint)
+	}
+	if config.Debug {
+		log.Printf("`%s` version `%s` satisfies `%s`", cmd[0], ver, constraint)
+	}
+	return ver, nil
+}
+
 func checkRequiresAzure() error {
 	out, err := checkRequiresBin("az", "storage", "account", "list", "-o", "table")
 	if err == nil {
@@ -0,0 +1,76 @@
+package lifecycle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+// fakeBinary puts a script printing `out` into toolchain path
+func fakeBinary(t *testing.T, name, out string) {
+	dir, err := ioutil.TempDir("", "hub-bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ncat <<'EOF'\n" + out + "\nEOF\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	saved := toolchainPath
+	toolchainPath = []string{dir}
+	t.Cleanup(func() {
+		toolchainPath = saved
+		os.RemoveAll(dir)
+	})
+}
+
+func TestCheckRequireVersion(t *testing.T) {
+	fakeBinary(t, "fakectl", "fakectl version v1.4.2 (build abc)")
+
+	tests := []struct {
+		constraint string
+		version    string
+		err        string
+	}{
+		{"", "1.4.2", ""},
+		{">=1.2", "1.4.2", ""},
+		{"~1.4", "1.4.2", ""},
+		{"^1", "1.4.2", ""},
+		{">=1.2, <1.4", "1.4.2", "does not satisfy `>=1.2, <1.4`"},
+		{"^2", "1.4.2", "does not satisfy `^2`"},
+		{"~1.3", "1.4.2", "does not satisfy `~1.3`"},
+		{">>1", "1.4.2", "Unable to parse `fakectl` version constraint"},
+	}
+	for _, test := range tests {
+		version, err := checkRequireVersion("fakectl", test.constraint)
+		if version != test.version {
+			t.Errorf("`%s`: version = `%s`, want `%s`", test.constraint, version, test.version)
+		}
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("`%s`: unexpected error: %v", test.constraint, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("`%s`: error = %v, want `%s`", test.constraint, err, test.err)
+		}
+	}
+}
+
+func TestCheckRequireVersionDeclaredBinary(t *testing.T) {
+	fakeBinary(t, "fakecli", "Release: 2023.10 / API 3.1.0")
+	setRequiresBinaries(map[string]manifest.RequiresBinary{
+		"fakecli": {Regexp: `API (\d+\.\d+\.\d+)`},
+	})
+	defer setRequiresBinaries(nil)
+
+	version, err := checkRequireVersion("fakecli", ">=3")
+	if err != nil || version != "3.1.0" {
+		t.Errorf("version, err = `%s`, %v; want `3.1.0`", version, err)
+	}
+	if _, err := checkRequireVersion("missingcli", ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing binary error = %v", err)
+	}
+}
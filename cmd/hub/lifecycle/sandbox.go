@@ -0,0 +1,208 @@
+package lifecycle
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const sandboxTmpDir = "/tmp"
+
+var (
+	sandboxModes = []string{"false", "true", "auto"}
+
+	sandboxMode     string
+	stackSandbox    *manifest.SandboxOptions
+	stackSandboxDir string
+
+	sandboxSystemDirs = []string{"/usr", "/bin", "/sbin", "/lib", "/lib32", "/lib64", "/etc", "/opt", "/nix"}
+	// variables that point to nested Hub CLI and its manifests
+	sandboxHubFileEnv = []string{"HUB", "HUB_ELABORATE", "HUB_STATE"}
+)
+
+func setSandbox(mode string, options *manifest.SandboxOptions, baseDir string) {
+	if mode == "" {
+		mode = "false"
+	}
+	if !util.Contains(sandboxModes, mode) {
+		log.Fatalf("Sandbox mode `%s` is not one of %v", mode, sandboxModes)
+	}
+	sandboxMode = mode
+	stackSandbox = options
+	stackSandboxDir = baseDir
+}
+
+// sandboxOptions merges stack-level sandbox defaults with component overrides;
+// relative files are resolved against stack or component dir, respectively
+func sandboxOptions(componentName string, component *manifest.SandboxOptions, componentDir string) (bool, bool, []string, []string) {
+	enabled := sandboxMode != "false"
+	network := false
+	files := make([]string, 0)
+	writable := make([]string, 0)
+	for i, options := range []*manifest.SandboxOptions{stackSandbox, component} {
+		if options == nil {
+			continue
+		}
+		if options.Enabled != nil {
+			enabled = *options.Enabled
+		}
+		if options.Network != nil {
+			network = *options.Network
+		}
+		baseDir := stackSandboxDir
+		if i > 0 {
+			baseDir = componentDir
+		}
+		for _, file := range options.Files {
+			files = append(files, resolveSandboxPath(componentName, file, baseDir))
+		}
+		writable = append(writable, options.Writable...)
+	}
+	if config.Debug && enabled != (sandboxMode != "false") {
+		log.Printf("Component `%s` sandbox is set to %v by manifest", componentName, enabled)
+	}
+	return enabled, network, files, writable
+}
+
+func expandSandboxPath(path string) string {
+	path = os.ExpandEnv(path)
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+func resolveSandboxPath(componentName, file, baseDir string) string {
+	path := expandSandboxPath(file)
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	abs, err := filepath.Abs(filepath.Join(baseDir, path))
+	if err != nil {
+		util.Warn("Component `%s` sandbox file `%s` cannot be resolved: %v", componentName, file, err)
+		return ""
+	}
+	return abs
+}
+
+func underSystemDir(path string) bool {
+	for _, dir := range sandboxSystemDirs {
+		if path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sandboxImplementation wraps component verb implementation into bubblewrap exposing read-only system
+// directories, component dir, private /tmp, declared credential files and writable directories;
+// network is unshared unless declared
+func sandboxImplementation(impl *exec.Cmd, componentName string, options *manifest.SandboxOptions) (*exec.Cmd, error) {
+	enabled, network, files, writable := sandboxOptions(componentName, options, impl.Dir)
+	if !enabled {
+		return impl, nil
+	}
+	unavailable := func(format string, v ...interface{}) (*exec.Cmd, error) {
+		if sandboxMode == "auto" {
+			util.WarnOnce("Sandbox is not available, running on host: %s", fmt.Sprintf(format, v...))
+			return impl, nil
+		}
+		return nil, fmt.Errorf("Sandbox is not available: "+format, v...)
+	}
+	if runtime.GOOS != "linux" {
+		return unavailable("%s is not supported, Linux is required", runtime.GOOS)
+	}
+	bin, err := exec.LookPath("bwrap")
+	if err != nil {
+		return unavailable("install bubblewrap: %v", err)
+	}
+
+	dir, err := filepath.Abs(impl.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine absolute path of `%s`: %v", impl.Dir, err)
+	}
+
+	args := []string{"bwrap", "--die-with-parent", "--unshare-all"}
+	if network {
+		args = append(args, "--share-net")
+	}
+	for _, systemDir := range sandboxSystemDirs {
+		args = append(args, "--ro-bind-try", systemDir, systemDir)
+	}
+	args = append(args, "--proc", "/proc", "--dev", "/dev", "--tmpfs", sandboxTmpDir)
+
+	roBind := func(path string) {
+		if path != "" && filepath.IsAbs(path) && !underSystemDir(path) {
+			args = append(args, "--ro-bind-try", path, path)
+		}
+	}
+	env := make([]string, 0, len(impl.Env)+2)
+	for _, v := range impl.Env {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch {
+		case kv[0] == "PATH":
+			// binaries outside of system dirs, ie. toolchain, are exposed read-only
+			for _, pathDir := range filepath.SplitList(kv[1]) {
+				roBind(pathDir)
+			}
+		case kv[0] == "HOME" || kv[0] == "TMPDIR":
+			continue
+		case util.Contains(sandboxHubFileEnv, kv[0]):
+			roBind(kv[1])
+		}
+		env = append(env, v)
+	}
+	env = append(env, "HOME="+sandboxTmpDir, "TMPDIR="+sandboxTmpDir)
+
+	command := impl.Path
+	if !filepath.IsAbs(command) {
+		if len(impl.Args) == 0 { // script in component dir
+			command = filepath.Join(dir, command)
+		} else if abs, err := filepath.Abs(command); err == nil {
+			command = abs
+		}
+	}
+	if !strings.HasPrefix(command, dir+string(os.PathSeparator)) {
+		roBind(filepath.Dir(command))
+	}
+
+	for _, file := range files {
+		roBind(file)
+	}
+	for _, writableDir := range writable {
+		path := expandSandboxPath(writableDir)
+		if filepath.IsAbs(path) {
+			args = append(args, "--bind", path, path)
+		} else {
+			util.Warn("Component `%s` sandbox writable path `%s` must be absolute", componentName, writableDir)
+		}
+	}
+	args = append(args, "--bind", dir, dir, "--chdir", dir, "--")
+
+	args = append(args, command)
+	if len(impl.Args) > 1 {
+		args = append(args, impl.Args[1:]...)
+	}
+
+	if config.Debug {
+		log.Printf("Executing `%s` in sandbox (network: %v)", componentName, network)
+		if config.Trace {
+			log.Printf("Sandbox: %v", args)
+		}
+	}
+
+	return &exec.Cmd{Path: bin, Args: args, Dir: impl.Dir, Env: env}, nil
+}
@@ -0,0 +1,32 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+func TestSandboxOptionsFiles(t *testing.T) {
+	defer setSandbox("false", nil, "")
+	os.Setenv("HUB_TEST_SANDBOX_CREDS", "/secrets/creds.json")
+	defer os.Unsetenv("HUB_TEST_SANDBOX_CREDS")
+
+	setSandbox("true", &manifest.SandboxOptions{Files: []string{".env", "/etc/hosts"}}, "/stack")
+	enabled, _, files, _ := sandboxOptions("app",
+		&manifest.SandboxOptions{Files: []string{"../shared/ca.pem", "$HUB_TEST_SANDBOX_CREDS"}}, "/stack/components/app")
+	if !enabled {
+		t.Error("sandbox is not enabled")
+	}
+	expected := []string{
+		filepath.FromSlash("/stack/.env"),
+		"/etc/hosts",
+		filepath.FromSlash("/stack/components/shared/ca.pem"),
+		"/secrets/creds.json",
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("files = %v, want %v", files, expected)
+	}
+}
@@ -0,0 +1,201 @@
+package lifecycle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/storage"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+// SimulationFixtures describe stub component behavior in --simulate mode
+type SimulationFixtures struct {
+	Components map[string]SimulationComponent `yaml:",omitempty"` // component or stack name => fixture
+	// fixed clock and operation id make state and backup bundle reproducible
+	Time        string `yaml:",omitempty"`            // RFC3339 timestamp
+	OperationId string `yaml:"operationId,omitempty"` // also hub.deploymentId of a new deployment
+}
+
+type SimulationComponent struct {
+	Outputs  map[string]string `yaml:",omitempty"`         // raw outputs printed by deploy and backup
+	Fail     map[string]string `yaml:",omitempty"`         // verb => error message
+	NotReady []string          `yaml:"notReady,omitempty"` // ready conditions to fail, ie. `url:https://host/health`
+	Stdout   string            `yaml:",omitempty"`         // printed before outputs
+}
+
+// simulation replaces component implementations, hooks, requirements, and ready conditions
+// with stubs, so that lifecycle could be exercised without clouds; notifications and metrics are skipped,
+// operation logs and rendered templates are kept only in explicitly requested directories
+type simulation struct {
+	fixtures     SimulationFixtures
+	restoreClock func()
+}
+
+var activeSimulation *simulation
+
+func startSimulation(request *Request) *simulation {
+	if !request.Simulate || activeSimulation != nil {
+		return nil
+	}
+	sim := &simulation{}
+	if request.SimulationFixtures != "" {
+		bytes, err := ioutil.ReadFile(request.SimulationFixtures)
+		if err != nil {
+			log.Fatalf("Unable to read simulation fixtures: %v", err)
+		}
+		if err := yaml.UnmarshalStrict(bytes, &sim.fixtures); err != nil {
+			log.Fatalf("Unable to parse simulation fixtures `%s`: %v", request.SimulationFixtures, err)
+		}
+	}
+	if sim.fixtures.Time != "" {
+		fixed, err := time.Parse(time.RFC3339, sim.fixtures.Time)
+		if err != nil {
+			log.Fatalf("Unable to parse simulation fixtures time `%s`: %v", sim.fixtures.Time, err)
+		}
+		sim.restoreClock = util.FixClock(fixed)
+	}
+	if config.Verbose {
+		fixtures := ""
+		if request.SimulationFixtures != "" {
+			fixtures = fmt.Sprintf(" with fixtures from %s", request.SimulationFixtures)
+		}
+		log.Printf("Simulating %s%s", request.Verb, fixtures)
+	}
+	metrics.Disable()
+	activeSimulation = sim
+	return sim
+}
+
+// checkSimulationState refuses to simulate deploy or undeploy that would overwrite remote state
+// or sync SuperHub stack instance with simulated outputs
+func checkSimulationState(request *Request) {
+	if !request.Simulate {
+		return
+	}
+	if request.SyncStackInstance {
+		log.Fatal("--simulate cannot be used with --hub-sync")
+	}
+	if remote := storage.RemoteStoragePaths(request.StateFilenames); len(remote) > 0 {
+		log.Fatalf("--simulate cannot write remote state %s; use local state file with -s",
+			strings.Join(remote, ", "))
+	}
+}
+
+func checkSimulationBundles(request *Request, bundles []string) {
+	if !request.Simulate {
+		return
+	}
+	if remote := storage.RemoteStoragePaths(bundles); len(remote) > 0 {
+		log.Fatalf("--simulate cannot write remote backup bundle %s; use local file or stdout",
+			strings.Join(remote, ", "))
+	}
+}
+
+func (sim *simulation) Stop() {
+	if sim == nil {
+		return
+	}
+	if sim.restoreClock != nil {
+		sim.restoreClock()
+	}
+	activeSimulation = nil
+}
+
+// operationId returns fixed operation (and deployment) id from fixtures, or the generated one
+func (sim *simulation) operationId(generated string) string {
+	if sim == nil || sim.fixtures.OperationId == "" {
+		return generated
+	}
+	return sim.fixtures.OperationId
+}
+
+// delegate is a stub implementation returning outputs from fixtures, or
+// `value:` of component outputs captured via `fromTfVar:`
+func (sim *simulation) delegate(verb, componentName string, componentManifest *manifest.Manifest) ([]byte, []byte, error) {
+	verb = strings.TrimSuffix(verb, "-test")
+	fixture := sim.fixtures.Components[componentName]
+	if config.Verbose {
+		log.Printf("Simulating `%s` %s", componentName, verb)
+	}
+	if message, exist := fixture.Fail[verb]; exist {
+		return []byte(fixture.Stdout), nil, fmt.Errorf("Simulated %s failure: %s", verb, message)
+	}
+
+	var out strings.Builder
+	if fixture.Stdout != "" {
+		out.WriteString(strings.TrimSuffix(fixture.Stdout, "\n"))
+		out.WriteString("\n\n")
+	}
+	if verb == "deploy" || verb == "backup" {
+		outputs := make(map[string]string)
+		if verb == "deploy" {
+			for _, output := range componentManifest.Outputs {
+				if output.FromTfVar == "" {
+					continue
+				}
+				variable, encodings := parameters.SplitEncodings(output.FromTfVar)
+				outputs[variable] = simulatedRawOutput(variable, output.Value, encodings)
+			}
+		}
+		for name, value := range fixture.Outputs {
+			outputs[name] = value
+		}
+		if len(outputs) > 0 {
+			out.Write(outputsMarker)
+			names := make([]string, 0, len(outputs))
+			for name := range outputs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				// quoted as raw outputs parser unquotes values, ie. JSON strings
+				fmt.Fprintf(&out, "%s = \"%s\"\n", name, strings.Replace(outputs[name], "\"", "\\\"", -1))
+			}
+		}
+	}
+	stdout := []byte(out.String())
+	if config.Debug && len(stdout) > 0 {
+		log.Printf("Simulated `%s` %s output:\n%s", componentName, verb, stdout)
+	}
+	return stdout, nil, nil
+}
+
+// ready returns simulated ready condition check result
+func (sim *simulation) ready(name, condition string) (bool, string, error) {
+	fixture := sim.fixtures.Components[name]
+	if util.Contains(fixture.NotReady, condition) {
+		return false, "", fmt.Errorf("Simulated `%s` ready condition failure: %s", name, condition)
+	}
+	return true, fmt.Sprintf("Simulated `%s` ready condition: %s", name, condition), nil
+}
+
+// simulatedRawOutput encodes output value, or a placeholder, to be decoded back by outputs capture
+func simulatedRawOutput(variable string, value interface{}, encodings []string) string {
+	if util.Empty(value) {
+		value = fmt.Sprintf("simulated-%s", variable)
+	}
+	raw := util.String(value)
+	if util.Contains(encodings, "json") {
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			util.Warn("Unable to marshal simulated raw output `%s` into JSON: %v", variable, err)
+		}
+		raw = string(bytes)
+	}
+	if util.Contains(encodings, "base64") {
+		raw = base64.StdEncoding.EncodeToString([]byte(raw))
+	}
+	return raw
+}
@@ -0,0 +1,144 @@
+package lifecycle
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/compose"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// copyTestDir copies stack from testdata, as component source dirs are relative to the elaborated manifest
+func copyTestDir(t *testing.T, src, dst string) {
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dst, rel), bytes, 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readMaybeGzipped(t *testing.T, path string) string {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if util.IsGzipData(bytes) {
+		bytes, err = util.Gunzip(bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return string(bytes)
+}
+
+func assertGolden(t *testing.T, golden, actual string) {
+	if *updateGolden {
+		if err := ioutil.WriteFile(golden, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected := readTestFile(t, golden)
+	if actual != expected {
+		t.Errorf("%s mismatch; re-run with -update to accept:\n%s", golden,
+			util.UnifiedDiff(golden, "actual", expected, actual, 3))
+	}
+}
+
+func TestSimulateGoldenState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-simulate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	testdata, err := filepath.Abs(filepath.Join("testdata", "simulate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	copyTestDir(t, filepath.Join(testdata, "stack"), dir)
+	fixtures := readTestFile(t, filepath.Join(testdata, "fixtures.yaml"))
+
+	// state records command line and initiator
+	args, user := os.Args, os.Getenv("USER")
+	defer func() {
+		os.Args = args
+		os.Setenv("USER", user)
+	}()
+	os.Setenv("USER", "hub")
+
+	elaborate := filepath.Join(dir, "hub.yaml.elaborate")
+	stateFile := filepath.Join(dir, "hub.yaml.state")
+	compose.Elaborate(filepath.Join(dir, "hub.yaml"), nil, "", "", nil, false,
+		[]string{elaborate}, "", false, false, nil)
+
+	for i, verb := range []string{"deploy", "undeploy", "backup"} {
+		// distinct operation ids keep lifecycle operations history apart
+		fixturesFile := filepath.Join(dir, fmt.Sprintf("fixtures-%s.yaml", verb))
+		writeTestFiles(t, dir, map[string]string{
+			filepath.Base(fixturesFile): fmt.Sprintf("%soperationId: 00000000-0000-0000-0000-00000000000%d\n", fixtures, i+1),
+		})
+		os.Args = []string{"hub", verb, "hub.yaml.elaborate", "-s", "hub.yaml.state", "--simulate-fixtures", filepath.Base(fixturesFile)}
+		request := &Request{
+			Verb:               verb,
+			ManifestFilenames:  []string{elaborate},
+			StateFilenames:     []string{stateFile},
+			OsEnvironmentMode:  "no-tfvars",
+			Simulate:           true,
+			SimulationFixtures: fixturesFile,
+		}
+		if verb == "backup" {
+			bundle := filepath.Join(dir, "backup.yaml")
+			BackupCreate(request, []string{bundle}, false, false, nil)
+			assertGolden(t, filepath.Join(testdata, "backup.golden.yaml"), readMaybeGzipped(t, bundle))
+		} else {
+			Execute(request, nil)
+			assertGolden(t, filepath.Join(testdata, verb+".state.golden.yaml"), readMaybeGzipped(t, stateFile))
+		}
+	}
+}
+
+func TestSimulationDelegateRawOutputs(t *testing.T) {
+	sim := &simulation{fixtures: SimulationFixtures{Components: map[string]SimulationComponent{
+		"app": {Outputs: map[string]string{"list": `["a", "b"]`}},
+	}}}
+	componentManifest := &manifest.Manifest{Outputs: []manifest.Output{
+		{Name: "placeholder", FromTfVar: "placeholder/json"},
+		{Name: "config", FromTfVar: "config/json", Value: map[string]interface{}{"port": 80}},
+		{Name: "encoded", FromTfVar: "encoded/json/base64", Value: "x"},
+		{Name: "list", FromTfVar: "list/json"},
+	}}
+	stdout, _, err := sim.delegate("deploy", "app", componentManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := parameters.RawOutputs{
+		"placeholder": `"simulated-placeholder"`,
+		"config":      `{"port":80}`,
+		"encoded":     "Ingi",
+		"list":        `["a", "b"]`,
+	}
+	if raw := parseTextOutput(stdout); !reflect.DeepEqual(raw, expected) {
+		t.Errorf("raw outputs = %v, want %v", raw, expected)
+	}
+}
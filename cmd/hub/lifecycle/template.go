@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	gotemplate "text/template"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -41,6 +43,9 @@ var (
 type TemplateRef struct {
 	Filename string
 	Kind     string
+	When     string
+	Output   string
+	Mode     string
 }
 
 type OpenErr struct {
@@ -53,6 +58,9 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 	dir string) []error {
 
 	componentName := manifest.ComponentQualifiedNameFromRef(component)
+	span := tracing.Start("process templates", "hub.component", componentName)
+	defer span.End()
+
 	kv := parameters.ParametersKV(params)
 	templateSetup, err := expandParametersInTemplateSetup(templateSetup, kv)
 	if err != nil {
@@ -77,6 +85,20 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 		return nil
 	}
 
+	// during lifecycle operation `outputs` are outputs of the components this component depends on,
+	// for `hub render` - outputs loaded from state; nil outputs keep templates bound to parameters only
+	celKV := kv
+	if outputs != nil {
+		celKV = parameters.ParametersAndOutputsKV(params, outputs, nil)
+	}
+	templates, errs := filterTemplatesWhen(templates, componentName, component.Depends, celKV)
+	if len(errs) > 0 {
+		return errs
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
 	filenames := make([]string, 0, len(templates))
 	hasMustache := false
 	hasGo := false
@@ -98,8 +120,6 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 		return []error{fmt.Errorf("Unable to open `%s` component template input(s):\n%s", componentName, strings.Join(diag, "\n"))}
 	}
 
-	// during lifecycle operation `outputs` is nil - only parameters are available in templates
-	// outputs are for `hub render`
 	if outputs != nil {
 		var depends []string
 		if hasMustache || hasGo {
@@ -110,19 +130,36 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 	if config.Trace {
 		log.Printf("Template binding:\n%v", kv)
 	}
+	var partials []templatePartial
+	if hasMustache || hasGo {
+		partials, err = scanTemplatePartials(componentName, dir, templateSetup.Partials)
+		if err != nil {
+			return []error{err}
+		}
+	}
 	var mustacheKV map[string]interface{}
+	var mustachePartials []mustache.Option
 	if hasMustache {
 		mustacheKV = mustacheCompatibleBindings(kv)
 		if config.Trace {
 			log.Printf("Mustache template binding:\n%v", mustacheKV)
 		}
+		mustachePartials, err = mustacheTemplatePartials(componentName, partials)
+		if err != nil {
+			return []error{err}
+		}
 	}
 	var goKV map[string]interface{}
+	var goPartials *gotemplate.Template
 	if hasGo {
-		goKV = goTemplateBindings(kv)
+		goKV = goTemplateBindings(decodeOutputsByKind(kv, outputs))
 		if config.Trace {
 			log.Printf("Go template binding:\n%v", goKV)
 		}
+		goPartials, err = goTemplateBase(componentName, dir, partials)
+		if err != nil {
+			return []error{err}
+		}
 	}
 
 	processor := func(content, filename, kind string) (string, []error) {
@@ -137,9 +174,9 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 			outContent, errs = processReplacement(content, filename, componentName, component.Depends, kv,
 				mustacheReplacement, stripMustache)
 		case trueMustacheKind:
-			outContent, err = processMustache(content, filename, componentName, mustacheKV)
+			outContent, err = processMustache(content, filename, componentName, mustacheKV, mustachePartials)
 		case goKind:
-			outContent, err = processGo(content, filename, componentName, goKV)
+			outContent, err = processGo(content, filename, componentName, goKV, goPartials)
 		}
 		if err != nil {
 			errs = append(errs, err)
@@ -147,13 +184,46 @@ func processTemplates(component *manifest.ComponentRef, templateSetup *manifest.
 		return outContent, errs
 	}
 
-	errs := make([]error, 0)
+	activeRendered.Reset(componentName)
+	errs = make([]error, 0)
 	for _, template := range templates {
-		errs = append(errs, processTemplate(template.Filename, template.Kind, componentName, processor)...)
+		errs = append(errs, processTemplate(template, componentName, dir, processor)...)
 	}
 	return errs
 }
 
+// filterTemplatesWhen evaluates `when:` CEL conditions to skip templates
+func filterTemplatesWhen(templates []TemplateRef, componentName string, depends []string,
+	kv map[string]interface{}) ([]TemplateRef, []error) {
+
+	evaluated := make(map[string]bool)
+	filtered := make([]TemplateRef, 0, len(templates))
+	errs := make([]error, 0)
+	for _, template := range templates {
+		if template.When == "" {
+			filtered = append(filtered, template)
+			continue
+		}
+		render, exist := evaluated[template.When]
+		if !exist {
+			result, err := parameters.CelEval(template.When, componentName, depends, kv)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Unable to evaluate `%s` component template `when: %s`: %v",
+					componentName, template.When, err))
+				continue
+			}
+			render = result == "true"
+			evaluated[template.When] = render
+		}
+		if render {
+			filtered = append(filtered, template)
+		} else if config.Verbose {
+			log.Printf("Skip template `%s`: `%s` is false", template.Filename, template.When)
+		}
+	}
+	return filtered, errs
+}
+
 func maybeExpandParametersInTemplateGlob(glob string, kv map[string]interface{}, section string, index int) (string, error) {
 	if !parameters.RequireExpansion(glob) {
 		return glob, nil
@@ -178,6 +248,10 @@ func expandParametersInTemplateSetup(templateSetup *manifest.TemplateSetup,
 		Kind:        templateSetup.Kind,
 		Files:       make([]string, 0, len(templateSetup.Files)),
 		Directories: make([]string, 0, len(templateSetup.Directories)),
+		Partials:    make([]string, 0, len(templateSetup.Partials)),
+		When:        templateSetup.When,
+		Output:      templateSetup.Output,
+		Mode:        templateSetup.Mode,
 		Extra:       make([]manifest.TemplateTarget, 0, len(templateSetup.Extra)),
 	}
 
@@ -195,11 +269,21 @@ func expandParametersInTemplateSetup(templateSetup *manifest.TemplateSetup,
 		}
 		setup.Directories = append(setup.Directories, expanded)
 	}
+	for i, glob := range templateSetup.Partials {
+		expanded, err := maybeExpandParametersInTemplateGlob(glob, kv, "partials", i)
+		if err != nil {
+			return nil, err
+		}
+		setup.Partials = append(setup.Partials, expanded)
+	}
 	for j, templateExtra := range templateSetup.Extra {
 		extra := manifest.TemplateTarget{
 			Kind:        templateExtra.Kind,
 			Files:       make([]string, 0, len(templateExtra.Files)),
 			Directories: make([]string, 0, len(templateExtra.Directories)),
+			When:        templateExtra.When,
+			Output:      templateExtra.Output,
+			Mode:        templateExtra.Mode,
 		}
 
 		prefix := fmt.Sprintf("extra.%d", j)
@@ -255,17 +339,20 @@ func checkKind(kind string) (string, error) {
 func scanTemplates(componentName string, baseDir string, templateSetup *manifest.TemplateSetup) []TemplateRef {
 	templates := make([]TemplateRef, 0, 10)
 
-	templates = appendPlainFiles(templates, baseDir, templateSetup.Files, templateSetup.Kind)
-	templates = scanDirectories(componentName, templates, baseDir, templateSetup.Directories, templateSetup.Files, templateSetup.Kind)
+	target := TemplateRef{Kind: templateSetup.Kind,
+		When: templateSetup.When, Output: templateSetup.Output, Mode: templateSetup.Mode}
+	templates = appendPlainFiles(templates, baseDir, templateSetup.Files, target)
+	templates = scanDirectories(componentName, templates, baseDir, templateSetup.Directories, templateSetup.Files, target)
 
 	for _, extra := range templateSetup.Extra {
-		templates = appendPlainFiles(templates, baseDir, extra.Files, extra.Kind)
-		templates = scanDirectories(componentName, templates, baseDir, extra.Directories, extra.Files, extra.Kind)
+		target := TemplateRef{Kind: extra.Kind, When: extra.When, Output: extra.Output, Mode: extra.Mode}
+		templates = appendPlainFiles(templates, baseDir, extra.Files, target)
+		templates = scanDirectories(componentName, templates, baseDir, extra.Directories, extra.Files, target)
 	}
 	return templates
 }
 
-func appendPlainFiles(acc []TemplateRef, baseDir string, files []string, kind string) []TemplateRef {
+func appendPlainFiles(acc []TemplateRef, baseDir string, files []string, target TemplateRef) []TemplateRef {
 	for _, file := range files {
 		if !isGlob(file) {
 			filePath := path.Join(baseDir, file)
@@ -286,13 +373,16 @@ func appendPlainFiles(acc []TemplateRef, baseDir string, files []string, kind st
 					}
 				}
 			}
-			acc = append(acc, TemplateRef{Filename: filePath, Kind: kind})
+			target.Filename = filePath
+			acc = append(acc, target)
 		}
 	}
 	return acc
 }
 
-func scanDirectories(componentName string, acc []TemplateRef, baseDir string, directories []string, files []string, kind string) []TemplateRef {
+func scanDirectories(componentName string, acc []TemplateRef, baseDir string, directories []string, files []string,
+	target TemplateRef) []TemplateRef {
+
 	if len(files) == 0 && len(directories) > 0 {
 		files = []string{"*"}
 	}
@@ -312,7 +402,8 @@ func scanDirectories(componentName string, acc []TemplateRef, baseDir string, di
 				}
 				if matches != nil {
 					for _, file := range matches {
-						acc = append(acc, TemplateRef{Filename: file, Kind: kind})
+						target.Filename = file
+						acc = append(acc, target)
 					}
 				} else {
 					util.Warn("No matches found for `%s` component template glob `%s`", componentName, glob)
@@ -343,9 +434,11 @@ func checkStat(templates []string) []OpenErr {
 	return cannot
 }
 
-func processTemplate(filename, kind, componentName string,
+func processTemplate(template TemplateRef, componentName, componentDir string,
 	processor func(string, string, string) (string, []error)) []error {
 
+	filename := template.Filename
+
 	tmpl, err := os.Open(filename)
 	if err != nil {
 		return []error{fmt.Errorf("Unable to open `%s` component template input `%s`: %v", componentName, filename, err)}
@@ -362,11 +455,28 @@ func processTemplate(filename, kind, componentName string,
 	tmpl.Close()
 	content := string(byteContent)
 
-	outPath := filename
-	for _, templateSuffix := range templateSuffices {
-		if strings.HasSuffix(outPath, templateSuffix) {
-			outPath = outPath[:len(outPath)-len(templateSuffix)]
-			break
+	outPath, err := templateOutputPath(template, componentName)
+	if err != nil {
+		return []error{err}
+	}
+	relPath := renderedRelPath(componentDir, outPath)
+	outPath = scratchOutputPath(componentName, componentDir, outPath)
+	var mode os.FileMode
+	if statInfo != nil {
+		mode = statInfo.Mode()
+	}
+	if template.Mode != "" {
+		perm, err := strconv.ParseUint(template.Mode, 8, 32)
+		if err != nil {
+			return []error{fmt.Errorf("Unable to parse `%s` component template `%s` mode `%s`: %v",
+				componentName, filename, template.Mode, err)}
+		}
+		mode = os.FileMode(perm)
+	}
+	if dir := filepath.Dir(outPath); template.Output != "" || templateScratchDir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return []error{fmt.Errorf("Unable to create `%s` component template output directory `%s`: %v",
+				componentName, dir, err)}
 		}
 	}
 	out, err := os.Create(outPath)
@@ -374,28 +484,29 @@ func processTemplate(filename, kind, componentName string,
 		return []error{fmt.Errorf("Unable to open `%s` component template output `%s`: %v", componentName, outPath, err)}
 	}
 	defer out.Close()
-	if statInfo != nil {
-		err = out.Chmod(statInfo.Mode())
+	if mode != 0 {
+		err = out.Chmod(mode)
 		if err != nil {
-			util.Warn("Unable to chmod `%s` component template output `%s`: %v", componentName, filename, err)
+			util.Warn("Unable to chmod `%s` component template output `%s`: %v", componentName, outPath, err)
 		}
 	}
 
-	outContent, errs := processor(content, filename, kind)
+	outContent, errs := processor(content, filename, template.Kind)
 	if len(outContent) > 0 {
 		written, err := strings.NewReader(outContent).WriteTo(out)
 		if err != nil || written != int64(len(outContent)) {
 			errs = append(errs, fmt.Errorf("Error writting `%s` component template output `%s`: %v", componentName, outPath, err))
 		}
 	}
+	if len(errs) == 0 {
+		activeRendered.Add(componentName, relPath, outContent)
+	}
 	return errs
 }
 
 var (
 	curlyReplacement    = regexp.MustCompile("\\$\\{[a-zA-Z0-9_\\.\\|:/-]+\\}")
 	mustacheReplacement = regexp.MustCompile("\\{\\{[a-zA-Z0-9_\\.\\|:/-]+\\}\\}")
-
-	templateSubstitutionSupportedEncodings = []string{"base64", "unbase64", "json", "yaml"}
 )
 
 func stripCurly(match string) string {
@@ -406,14 +517,6 @@ func stripMustache(match string) string {
 	return match[2 : len(match)-2]
 }
 
-func valueEncodings(variable string) (string, []string) {
-	if strings.Contains(variable, "/") {
-		parts := strings.Split(variable, "/")
-		return parts[0], parts[1:]
-	}
-	return variable, nil
-}
-
 func processReplacement(content, filename, componentName string, componentDepends []string,
 	kv map[string]interface{}, replacement *regexp.Regexp, strip func(string) string) (string, []error) {
 
@@ -423,7 +526,7 @@ func processReplacement(content, filename, componentName string, componentDepend
 	outContent := replacement.ReplaceAllStringFunc(content,
 		func(variable string) string {
 			variable = strip(variable)
-			variable, encodings := valueEncodings(variable)
+			variable, encodings := parameters.SplitEncodings(variable)
 			substitution, exist := parameters.FindValue(variable, componentName, componentDepends, kv)
 			if !exist {
 				errs = append(errs, fmt.Errorf("Template `%s` refer to unknown substitution `%s`", filename, variable))
@@ -438,48 +541,21 @@ func processReplacement(content, filename, componentName string, componentDepend
 			}
 			replaced = true
 			if len(encodings) > 0 {
-				if unknown := util.OmitAll(encodings, templateSubstitutionSupportedEncodings); len(unknown) > 0 {
+				if unknown := parameters.UnknownEncoders(encodings); len(unknown) > 0 {
 					errs = append(errs, fmt.Errorf("Unknown encoding(s) %v processing template `%s` substitution `%s`",
 						unknown, filename, variable))
+					return "(unknown)"
 				}
-				if util.Contains(encodings, "json") {
-					jsonBytes, err := json.Marshal(substitution)
-					if err != nil {
-						errs = append(errs, fmt.Errorf("Unable to marshal JSON from %v while processing template `%s` substitution `%s`: %v",
-							substitution, filename, variable, err))
-					} else {
-						substitution = string(jsonBytes)
-					}
-				} else if util.Contains(encodings, "yaml") {
-					// TODO YAML fragment on a single line
-					yamlBytes, err := yaml.Marshal(substitution)
-					if err != nil {
-						errs = append(errs, fmt.Errorf("Unable to marshal YAML from %v while processing template `%s` substitution `%s`: %v",
-							substitution, filename, variable, err))
-					} else {
-						substitution = string(yamlBytes)
-					}
-				}
-				strSubstitution := util.String(substitution)
-				if util.Contains(encodings, "bcrypt") {
-					bytes, err := bcrypt.GenerateFromPassword([]byte(strSubstitution), bcrypt.DefaultCost)
-					if err != nil {
-						util.Warn("Unable to bcrypt encode `%s` value `%s`: %v", variable, util.Trim(strSubstitution), err)
-					} else {
-						strSubstitution = string(bytes)
-					}
+				if legacy := parameters.LegacyOrder(encodings); !util.Equal(legacy, encodings) {
+					util.WarnOnce("Template `%s` substitution `%s` encodings %v are applied in legacy order %v",
+						filename, variable, encodings, legacy)
+					encodings = legacy
 				}
-				if util.Contains(encodings, "base64") {
-					strSubstitution = base64.StdEncoding.EncodeToString([]byte(strSubstitution))
-				} else if util.Contains(encodings, "unbase64") {
-					bytes, err := base64.StdEncoding.DecodeString(strSubstitution)
-					if err != nil {
-						util.Warn("Unable to decode `%s` base64 value `%s`: %v", variable, util.Trim(strSubstitution), err)
-					} else {
-						strSubstitution = string(bytes)
-					}
+				encoded, err := parameters.Encode(substitution, encodings)
+				if err != nil {
+					util.Warn("Template `%s` substitution `%s`: %v", filename, variable, err)
 				}
-				return strSubstitution
+				return util.String(encoded)
 			}
 			return strings.TrimSpace(util.String(substitution))
 		})
@@ -498,8 +574,10 @@ func mustacheCompatibleBindings(kv map[string]interface{}) map[string]interface{
 	return mkv
 }
 
-func processMustache(content, filename, componentName string, kv map[string]interface{}) (string, error) {
-	template := mustache.New(mustache.SilentMiss(false))
+func processMustache(content, filename, componentName string, kv map[string]interface{},
+	partials []mustache.Option) (string, error) {
+
+	template := mustache.New(append([]mustache.Option{mustache.SilentMiss(false)}, partials...)...)
 	err := template.ParseString(content)
 	if err != nil {
 		return "", fmt.Errorf("Unable to parse mustache template `%s`: %v", filename, err)
@@ -561,14 +639,225 @@ func bcryptStr(str string) (string, error) {
 }
 
 var hubGoTemplateFuncMap = map[string]interface{}{
-	"bcrypt": bcryptStr,
+	"bcrypt":   bcryptStr,
+	"toYaml":   toYaml,
+	"fromYaml": fromYaml,
+	"fromJson": fromJson,
+}
+
+// templateComponentsDirs maps stack components to source directories for `readComponentFile`
+var templateComponentsDirs map[string]string
+
+func setTemplateComponentsDirs(components []manifest.ComponentRef, stackBaseDir, componentsBaseDir string) {
+	templateComponentsDirs = make(map[string]string, len(components))
+	for i := range components {
+		component := &components[i]
+		templateComponentsDirs[manifest.ComponentQualifiedNameFromRef(component)] =
+			manifest.ComponentSourceDirFromRef(component, stackBaseDir, componentsBaseDir)
+	}
+}
+
+func toYaml(value interface{}) (string, error) {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(bytes), "\n"), nil
+}
+
+// fromYaml and fromJson pass through values that are already structured, ie. Terraform map outputs
+func fromYaml(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	var decoded interface{}
+	if err := yaml.Unmarshal([]byte(str), &decoded); err != nil {
+		return nil, err
+	}
+	return yamlStringKeys(decoded), nil
+}
+
+func fromJson(value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(str), &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func yamlStringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		dict := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			dict[fmt.Sprintf("%v", key)] = yamlStringKeys(entry)
+		}
+		return dict
+	case []interface{}:
+		for i, entry := range v {
+			v[i] = yamlStringKeys(entry)
+		}
+	}
+	return value
 }
 
-func processGo(content, filename, componentName string, kv map[string]interface{}) (string, error) {
-	tmpl, err := gotemplate.New(filepath.Base(filename)).Funcs(sprig.TxtFuncMap()).Funcs(hubGoTemplateFuncMap).Parse(content)
+// decodeOutputsByKind decodes string outputs of `json`, `yaml`, `base64` kind, ie. `secret/json`,
+// so that Go templates can navigate into the value
+func decodeOutputsByKind(kv map[string]interface{}, outputs parameters.CapturedOutputs) map[string]interface{} {
+	if len(outputs) == 0 {
+		return kv
+	}
+	decoded := make(map[string]interface{}, len(kv))
+	for k, v := range kv {
+		decoded[k] = v
+	}
+	for _, output := range outputs {
+		str, ok := output.Value.(string)
+		if !ok || output.Kind == "" {
+			continue
+		}
+		var value interface{}
+		var err error
+		for _, kind := range strings.Split(output.Kind, "/") {
+			switch kind {
+			case "json":
+				value, err = fromJson(str)
+			case "yaml":
+				value, err = fromYaml(str)
+			case "base64":
+				var bytes []byte
+				bytes, err = base64.StdEncoding.DecodeString(str)
+				value = string(bytes)
+			default:
+				continue
+			}
+			break
+		}
+		if err != nil {
+			util.Warn("Unable to decode `%s` output of `%s` kind: %v", output.QName(), output.Kind, err)
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		for _, key := range []string{output.QName(), output.Name} {
+			if v, exist := decoded[key]; exist && v == output.Value {
+				decoded[key] = value
+			}
+		}
+	}
+	return decoded
+}
+
+type templatePartial struct {
+	name     string // file basename
+	filename string
+	content  string
+}
+
+// scanTemplatePartials reads partials shared by `_mustache` and `go` templates of the component
+func scanTemplatePartials(componentName, dir string, partialsDirs []string) ([]templatePartial, error) {
+	partials := make([]templatePartial, 0)
+	for _, partialsDir := range partialsDirs {
+		if !filepath.IsAbs(partialsDir) {
+			partialsDir = filepath.Join(dir, partialsDir)
+		}
+		matches, err := filepath.Glob(filepath.Join(partialsDir, "*"))
+		if err != nil {
+			return nil, fmt.Errorf("Unable to scan `%s` component template partials `%s`: %v", componentName, partialsDir, err)
+		}
+		if len(matches) == 0 {
+			util.Warn("No `%s` component template partials found in `%s`", componentName, partialsDir)
+		}
+		for _, partial := range matches {
+			if info, err := os.Stat(partial); err != nil || info.IsDir() {
+				continue
+			}
+			content, err := ioutil.ReadFile(partial)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to read `%s` component template partial `%s`: %v", componentName, partial, err)
+			}
+			partials = append(partials, templatePartial{name: filepath.Base(partial), filename: partial, content: string(content)})
+		}
+	}
+	return partials, nil
+}
+
+// mustacheTemplatePartials makes partials available to `_mustache` templates as {{> name}}
+func mustacheTemplatePartials(componentName string, partials []templatePartial) ([]mustache.Option, error) {
+	options := make([]mustache.Option, 0, len(partials))
+	for _, partial := range partials {
+		template := mustache.New(mustache.Name(partial.name), mustache.SilentMiss(false))
+		if err := template.ParseString(partial.content); err != nil {
+			return nil, fmt.Errorf("Unable to parse `%s` component mustache template partial `%s`: %v",
+				componentName, partial.filename, err)
+		}
+		options = append(options, mustache.Partial(template))
+	}
+	return options, nil
+}
+
+// goTemplateBase holds shared partials and functions bound to component directory
+func goTemplateBase(componentName, dir string, partials []templatePartial) (*gotemplate.Template, error) {
+	readFile := func(base, file string) (string, error) {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(base, file)
+		}
+		bytes, err := ioutil.ReadFile(file)
+		return string(bytes), err
+	}
+	base := gotemplate.New(componentName).Funcs(sprig.TxtFuncMap()).Funcs(hubGoTemplateFuncMap).Funcs(
+		map[string]interface{}{
+			"include": func(string, interface{}) (string, error) { return "", nil },
+			"readFile": func(file string) (string, error) {
+				return readFile(dir, file)
+			},
+			"readComponentFile": func(component, file string) (string, error) {
+				componentDir, exist := templateComponentsDirs[component]
+				if !exist {
+					return "", fmt.Errorf("No component `%s` in stack", component)
+				}
+				return readFile(componentDir, file)
+			},
+			"fileExists": func(file string) bool {
+				if !filepath.IsAbs(file) {
+					file = filepath.Join(dir, file)
+				}
+				_, err := os.Stat(file)
+				return err == nil
+			},
+		})
+	for _, partial := range partials {
+		if _, err := base.New(partial.name).Parse(partial.content); err != nil {
+			return nil, fmt.Errorf("Unable to parse `%s` component template partial `%s`: %v", componentName, partial.filename, err)
+		}
+	}
+	return base, nil
+}
+
+func processGo(content, filename, componentName string, kv map[string]interface{},
+	base *gotemplate.Template) (string, error) {
+
+	set, err := base.Clone()
 	if err != nil {
 		return "", err
 	}
+	tmpl, err := set.New(filepath.Base(filename)).Parse(content)
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(map[string]interface{}{
+		"include": func(name string, data interface{}) (string, error) {
+			var buffer bytes.Buffer
+			err := tmpl.ExecuteTemplate(&buffer, name, data)
+			return buffer.String(), err
+		},
+	})
 	var buffer bytes.Buffer
 	err = tmpl.Execute(&buffer, kv)
 	if err != nil {
@@ -576,3 +865,44 @@ func processGo(content, filename, componentName string, kv map[string]interface{
 	}
 	return buffer.String(), nil
 }
+
+// templateOutputPath strips template suffix or renders `output:` template relative to template directory
+func templateOutputPath(template TemplateRef, componentName string) (string, error) {
+	dir, name := filepath.Split(template.Filename)
+	for _, templateSuffix := range templateSuffices {
+		if strings.HasSuffix(name, templateSuffix) {
+			name = name[:len(name)-len(templateSuffix)]
+			break
+		}
+	}
+	if template.Output == "" {
+		return filepath.Join(dir, name), nil
+	}
+	tmpl, err := gotemplate.New("output").Parse(template.Output)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse `%s` component template `%s` output `%s`: %v",
+			componentName, template.Filename, template.Output, err)
+	}
+	ext := filepath.Ext(name)
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, map[string]string{
+		"Dir":       util.MustAbs(filepath.Clean(dir)),
+		"Name":      name,
+		"Base":      strings.TrimSuffix(name, ext),
+		"Ext":       ext,
+		"Component": componentName,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Unable to render `%s` component template `%s` output `%s`: %v",
+			componentName, template.Filename, template.Output, err)
+	}
+	outPath := strings.TrimSpace(buffer.String())
+	if outPath == "" {
+		return "", fmt.Errorf("`%s` component template `%s` output `%s` is empty",
+			componentName, template.Filename, template.Output)
+	}
+	if !filepath.IsAbs(outPath) {
+		outPath = filepath.Join(dir, outPath)
+	}
+	return outPath, nil
+}
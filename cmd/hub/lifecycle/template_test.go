@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/parameters"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func TestProcessTemplatesPartials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFiles(t, dir, map[string]string{
+		"mustache/labels":            "app: {{name}}\n",
+		"go/labels.tpl":              `{{define "labels"}}app: {{ .name }}{{end}}`,
+		"mustache.yaml.template":     "labels:\n  {{> labels}}",
+		"go.yaml.gotemplate":         `labels: {{ template "labels" . }}`,
+		"go-include.yaml.gotemplate": `labels: {{ include "labels" . | upper }}`,
+	})
+
+	component := &manifest.ComponentRef{Name: "app"}
+	params := parameters.LockedParameters{"name": {Name: "name", Value: "web"}}
+	setups := []manifest.TemplateSetup{
+		{Kind: trueMustacheKind, Files: []string{"mustache.yaml.template"}, Partials: []string{"mustache"}},
+		{Kind: goKind, Files: []string{"go.yaml.gotemplate", "go-include.yaml.gotemplate"}, Partials: []string{"go"}},
+	}
+	for _, setup := range setups {
+		if errs := processTemplates(component, &setup, params, nil, dir); len(errs) > 0 {
+			t.Fatalf("%s templates: %v", setup.Kind, errs)
+		}
+	}
+
+	for file, want := range map[string]string{
+		"mustache.yaml":   "labels:\n  app: web\n",
+		"go.yaml":         "labels: app: web",
+		"go-include.yaml": "labels: APP: WEB",
+	} {
+		if got := readTestFile(t, filepath.Join(dir, file)); got != want {
+			t.Errorf("%s = %q, want %q", file, got, want)
+		}
+	}
+}
+
+func TestProcessTemplatesDependsOutputs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFiles(t, dir, map[string]string{
+		"values.yaml.gotemplate": `host: {{ .connection.host }}
+port: {{ .connection.port }}
+url: {{ .url }}
+`,
+	})
+
+	component := &manifest.ComponentRef{Name: "app", Depends: []string{"db"}}
+	params := parameters.LockedParameters{"name|app": {Component: "app", Name: "name", Value: "web"}}
+	allOutputs := parameters.CapturedOutputs{
+		"connection|db": {Component: "db", Name: "connection", Kind: "secret/json",
+			Value: `{"host": "db.local", "port": 5432}`},
+		"url|db":    {Component: "db", Name: "url", Value: "postgres://db.local"},
+		"url|cache": {Component: "cache", Name: "url", Value: "redis://cache.local"},
+	}
+	setup := manifest.TemplateSetup{Kind: goKind, Files: []string{"values.yaml.gotemplate"}}
+	if errs := processTemplates(component, &setup, params, dependsOutputs(allOutputs, component.Depends), dir); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	want := "host: db.local\nport: 5432\nurl: postgres://db.local\n"
+	if got := readTestFile(t, filepath.Join(dir, "values.yaml")); got != want {
+		t.Errorf("values.yaml = %q, want %q", got, want)
+	}
+}
+
+func TestProcessReplacementEncodingsOrder(t *testing.T) {
+	kv := map[string]interface{}{"password": "secret"}
+	content := "legacy: ${password/base64/json}\nchained: ${password/base64/quote}\n"
+	out, errs := processReplacement(content, "test.template", "app", nil, kv, curlyReplacement, stripCurly)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	// legacy encodings keep their fixed order: json then base64
+	want := "legacy: InNlY3JldCI=\nchained: \"c2VjcmV0\"\n"
+	if out != want {
+		t.Errorf("out = %q, want %q", out, want)
+	}
+}
@@ -50,6 +50,10 @@ type windowSize struct {
 	cols uint16
 }
 
+func terminalSize(out *os.File) (int, int) {
+	return tiocgwinsz(out.Fd())
+}
+
 func tiocgwinsz(fd uintptr) (int, int) {
 	var sz windowSize
 	_, _, _ = syscall.Syscall(syscall.SYS_IOCTL,
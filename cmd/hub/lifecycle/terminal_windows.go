@@ -10,3 +10,7 @@ import (
 func newTail(out *os.File) io.WriteCloser {
 	return out
 }
+
+func terminalSize(out *os.File) (int, int) {
+	return 0, 0
+}
@@ -13,6 +13,14 @@ type Request struct {
 	LimitComponent             string   // deploy & undeploy
 	GuessComponent             bool     // undeploy
 	OsEnvironmentMode          string
+	SandboxMode                string
+	LogDir                     string
+	UploadLogs                 bool
+	RenderedDir                string
+	Simulate                   bool
+	SimulationFixtures         string
+	Events                     string
+	EventsOutput               string
 	EnvironmentOverrides       string
 	ComponentsBaseDir          string
 	GitOutputs                 bool
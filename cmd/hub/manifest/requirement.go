@@ -0,0 +1,31 @@
+package manifest
+
+import (
+	"regexp"
+	"strings"
+)
+
+var requirementConstraintRegexp = regexp.MustCompile(`^([^<>=!~^\s]+)\s*([<>=!~^].*)?$`)
+
+// ParseRequirement splits `terraform>=1.3` into requirement name and version constraint
+func ParseRequirement(requirement string) (string, string) {
+	match := requirementConstraintRegexp.FindStringSubmatch(strings.TrimSpace(requirement))
+	if len(match) != 3 {
+		return requirement, ""
+	}
+	return match[1], strings.TrimSpace(match[2])
+}
+
+func RequirementName(requirement string) string {
+	name, _ := ParseRequirement(requirement)
+	return name
+}
+
+// RequirementNames strips version constraints from requirements
+func RequirementNames(requirements []string) []string {
+	names := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		names = append(names, RequirementName(requirement))
+	}
+	return names
+}
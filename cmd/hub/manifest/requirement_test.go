@@ -0,0 +1,41 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		requirement string
+		name        string
+		constraint  string
+	}{
+		{"kubectl", "kubectl", ""},
+		{" helm ", "helm", ""},
+		{"terraform>=1.2", "terraform", ">=1.2"},
+		{"terraform >= 1.2", "terraform", ">= 1.2"},
+		{"python ~1.2", "python", "~1.2"},
+		{"node^1", "node", "^1"},
+		{"vault!=1.5.0", "vault", "!=1.5.0"},
+		{"kubectl<1.25, >=1.20", "kubectl", "<1.25, >=1.20"},
+		{"aws-cli=2.9.0", "aws-cli", "=2.9.0"},
+	}
+	for _, test := range tests {
+		name, constraint := ParseRequirement(test.requirement)
+		if name != test.name || constraint != test.constraint {
+			t.Errorf("ParseRequirement(%q) = %q, %q; want %q, %q",
+				test.requirement, name, constraint, test.name, test.constraint)
+		}
+		if name := RequirementName(test.requirement); name != test.name {
+			t.Errorf("RequirementName(%q) = %q, want %q", test.requirement, name, test.name)
+		}
+	}
+}
+
+func TestRequirementNames(t *testing.T) {
+	names := RequirementNames([]string{"postgresql>=12", "kubernetes", "helm ^3"})
+	if want := []string{"postgresql", "kubernetes", "helm"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("RequirementNames = %v, want %v", names, want)
+	}
+}
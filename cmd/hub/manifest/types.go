@@ -43,14 +43,84 @@ type ComponentRef struct {
 }
 
 type RequiresTuning struct {
-	Optional []string `yaml:",omitempty"`
+	Optional []string                  `yaml:",omitempty"`
+	Outputs  map[string][]string       `yaml:",omitempty"` // requirement => outputs the provider must emit
+	Binaries map[string]RequiresBinary `yaml:",omitempty"`
+}
+
+type RequiresBinary struct {
+	Command string `yaml:",omitempty"` // version command, default to `<binary> --version`
+	Regexp  string `yaml:",omitempty"` // regexp with version capture group
 }
 
 type ReadyCondition struct {
-	DNS          string `yaml:"dns,omitempty"`
-	URL          string `yaml:"url,omitempty"`
-	WaitSeconds  int    `yaml:"waitSeconds,omitempty"`
-	PauseSeconds int    `yaml:"pauseSeconds,omitempty"`
+	DNS             string                    `yaml:"dns,omitempty"`
+	URL             string                    `yaml:"url,omitempty"`
+	Status          []int                     `yaml:"status,omitempty"`   // url: expected HTTP status codes
+	Body            string                    `yaml:"body,omitempty"`     // url: response body regexp
+	JSONPath        string                    `yaml:"jsonPath,omitempty"` // url: path into JSON response, ie. status.ready or items[0].name
+	JSONValue       string                    `yaml:"jsonValue,omitempty"`
+	Insecure        bool                      `yaml:"insecure,omitempty"`
+	TCP             string                    `yaml:"tcp,omitempty"`
+	TLS             string                    `yaml:"tls,omitempty"`
+	TLSValidDays    int                       `yaml:"tlsValidDays,omitempty"`
+	Kubernetes      *KubernetesReadyCondition `yaml:",omitempty"`
+	Command         string                    `yaml:",omitempty"`
+	CEL             string                    `yaml:"cel,omitempty"`
+	WaitSeconds     int                       `yaml:"waitSeconds,omitempty"`
+	IntervalSeconds int                       `yaml:"intervalSeconds,omitempty"`
+	PauseSeconds    int                       `yaml:"pauseSeconds,omitempty"`
+}
+
+type KubernetesReadyCondition struct {
+	Resource  string // deployment/name, job/name
+	Namespace string `yaml:",omitempty"`
+	Selector  string `yaml:",omitempty"`
+	For       string `yaml:",omitempty"` // condition=Available
+}
+
+type LifecycleHook struct {
+	Command       string            `yaml:",omitempty"`
+	Env           map[string]string `yaml:",omitempty"`
+	FailurePolicy string            `yaml:"failurePolicy,omitempty"` // fail, warn, ignore
+}
+
+type LifecycleHooks struct {
+	PreDeploy    []LifecycleHook `yaml:"pre-deploy,omitempty"`
+	PostDeploy   []LifecycleHook `yaml:"post-deploy,omitempty"`
+	PreUndeploy  []LifecycleHook `yaml:"pre-undeploy,omitempty"`
+	PostUndeploy []LifecycleHook `yaml:"post-undeploy,omitempty"`
+	OnFailure    []LifecycleHook `yaml:"on-failure,omitempty"`
+}
+
+type ContainerOptions struct {
+	Runtime string   `yaml:",omitempty"` // docker, podman; default to $HUB_CONTAINER_RUNTIME or autodetect
+	Volumes []string `yaml:",omitempty"` // host:container[:ro]
+	Network string   `yaml:",omitempty"`
+	Options []string `yaml:",omitempty"` // extra `run` arguments
+}
+
+type SandboxOptions struct {
+	Enabled  *bool    `yaml:",omitempty"`
+	Network  *bool    `yaml:",omitempty"`
+	Files    []string `yaml:",omitempty"` // read-only credential files and directories, $VAR are expanded, relative to stack or component dir
+	Writable []string `yaml:",omitempty"` // extra writable directories
+}
+
+// Notification is sent on operation success, failure, or when Hub CLI is awaiting input;
+// $VAR in url, host, username, password are expanded from OS environment
+type Notification struct {
+	Kind     string            `yaml:",omitempty"` // webhook (default), slack, smtp
+	On       []string          `yaml:",omitempty"` // success, failure, input; default to all
+	URL      string            `yaml:"url,omitempty"`
+	Headers  map[string]string `yaml:",omitempty"`
+	Body     string            `yaml:",omitempty"` // webhook JSON body Go template
+	Host     string            `yaml:",omitempty"` // SMTP server host:port
+	Username string            `yaml:",omitempty"`
+	Password string            `yaml:",omitempty"`
+	From     string            `yaml:",omitempty"`
+	To       []string          `yaml:",omitempty"`
+	Subject  string            `yaml:",omitempty"` // SMTP subject Go template
 }
 
 type LifecycleOptions struct {
@@ -67,7 +137,27 @@ type Lifecycle struct {
 	Optional        []string          `yaml:",omitempty"`
 	Requires        RequiresTuning    `yaml:",omitempty"` // TODO use pointer?
 	ReadyConditions []ReadyCondition  `yaml:"readyConditions,omitempty"`
+	Hooks           LifecycleHooks    `yaml:",omitempty"`
+	Image           string            `yaml:",omitempty"` // run component verbs in container
+	Container       *ContainerOptions `yaml:",omitempty"`
+	Sandbox         *SandboxOptions   `yaml:",omitempty"`
 	Options         *LifecycleOptions `yaml:",omitempty"`
+	Notifications   []Notification    `yaml:",omitempty"`
+}
+
+type Toolchain struct {
+	Cache  string `yaml:",omitempty"` // default to .hub/toolchain relative to stack manifest
+	Mirror string `yaml:",omitempty"` // local directory with pre-downloaded artifacts
+	Tools  []Tool `yaml:",omitempty"`
+}
+
+type Tool struct {
+	Name      string
+	Version   string
+	URL       string            `yaml:"url,omitempty"`    // ${version}, ${os}, ${arch} are substituted
+	Binary    string            `yaml:",omitempty"`       // path to binary inside archive, installed as tool name
+	Sha256    string            `yaml:"sha256,omitempty"` // artifact checksum
+	Checksums map[string]string `yaml:",omitempty"`       // os_arch => artifact checksum
 }
 
 type Output struct {
@@ -75,9 +165,18 @@ type Output struct {
 	Brief       string `yaml:",omitempty"`
 	Description string `yaml:",omitempty"`
 
-	Value     interface{} `yaml:",omitempty"`
-	FromTfVar string      `yaml:"fromTfVar,omitempty"`
-	Kind      string      `yaml:",omitempty"`
+	Value     interface{}      `yaml:",omitempty"`
+	FromTfVar string           `yaml:"fromTfVar,omitempty"`
+	Kind      string           `yaml:",omitempty"`
+	Assert    *OutputAssertion `yaml:",omitempty"`
+}
+
+type OutputAssertion struct {
+	Required bool   `yaml:",omitempty"`
+	NonEmpty bool   `yaml:"nonEmpty,omitempty"`
+	Pattern  string `yaml:",omitempty"`
+	CEL      string `yaml:"cel,omitempty"`
+	Type     string `yaml:",omitempty"` // string, number, integer, boolean, object, array
 }
 
 type Parameter struct {
@@ -103,12 +202,19 @@ type TemplateTarget struct {
 	Kind        string   `yaml:",omitempty"`
 	Directories []string `yaml:",omitempty"`
 	Files       []string `yaml:",omitempty"`
+	When        string   `yaml:",omitempty"` // CEL condition, templates are not rendered if false
+	Output      string   `yaml:",omitempty"` // output path Go template: {{.Dir}}, {{.Name}}, {{.Base}}, {{.Ext}}, {{.Component}}
+	Mode        string   `yaml:",omitempty"` // output file permissions, ie. 0600
 }
 
 type TemplateSetup struct {
 	Kind        string           `yaml:",omitempty"`
 	Directories []string         `yaml:",omitempty"`
 	Files       []string         `yaml:",omitempty"`
+	Partials    []string         `yaml:",omitempty"` // directories with partials shared by `_mustache` and `go` templates
+	When        string           `yaml:",omitempty"`
+	Output      string           `yaml:",omitempty"`
+	Mode        string           `yaml:",omitempty"`
 	Extra       []TemplateTarget `yaml:",omitempty"`
 }
 
@@ -124,6 +230,7 @@ type Manifest struct {
 	Platform PlatformMetadata `yaml:",omitempty"`
 
 	Lifecycle  Lifecycle     `yaml:",omitempty"`
+	Toolchain  Toolchain     `yaml:",omitempty"`
 	Outputs    []Output      `yaml:",omitempty"`
 	Parameters []Parameter   `yaml:",omitempty"`
 	Templates  TemplateSetup `yaml:",omitempty"`
@@ -33,6 +33,14 @@ func MeterCommand(cmd *cobra.Command, connectStdin bool) io.WriteCloser {
 	return stdin
 }
 
+// CommandUsage counts command invocation in operational metrics sinks
+func CommandUsage(cmd *cobra.Command) {
+	if cmd.Hidden {
+		return
+	}
+	Count("hub_command_usage_total", 1, "command", commandStr(cmd))
+}
+
 func meterCommand(cmd *cobra.Command, connectStdin bool) (io.WriteCloser, error) {
 	enabled, _, err := meteringConfig()
 	if err != nil {
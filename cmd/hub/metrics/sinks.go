@@ -0,0 +1,346 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/filecache"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	sampleCounter = "counter"
+	sampleGauge   = "gauge"
+	sampleTiming  = "timing" // seconds
+
+	defaultPushgatewayJob = "hub"
+)
+
+// Sample is an operational metric data point sent to configured sinks
+type Sample struct {
+	Name      string
+	Kind      string
+	Value     float64
+	Tags      map[string]string
+	Timestamp time.Time
+}
+
+// Sink is a metrics destination: Prometheus pushgateway, StatsD, or a local file
+type Sink interface {
+	Put(samples []Sample) error
+	String() string
+}
+
+var (
+	samplesMu sync.Mutex
+	samples   []Sample
+
+	sinksOnce sync.Once
+	sinks     []Sink
+)
+
+// configuredSinks parses sink URLs from --metrics-sinks, HUB_METRICS_SINKS, .hub-config metrics-sinks,
+// or API cache file metrics.sinks:
+//
+//	pushgateway+http://host:9091?job=hub
+//	statsd://host:8125?prefix=hub
+//	file:///var/log/hub-metrics.prom
+func configuredSinks() []Sink {
+	sinksOnce.Do(func() {
+		urls := util.SplitPaths(config.MetricsSinks)
+		if len(urls) == 0 {
+			file, cache, err := filecache.ReadCache(os.O_RDONLY)
+			if file != nil {
+				file.Close()
+			}
+			if err != nil {
+				util.Warn("Unable to load metrics config: %v", err)
+			} else if cache != nil {
+				urls = cache.Metrics.Sinks
+			}
+		}
+		for _, sinkUrl := range urls {
+			sink, err := parseSink(strings.TrimSpace(sinkUrl))
+			if err != nil {
+				util.Warn("Unable to configure metrics sink: %v", err)
+				continue
+			}
+			sinks = append(sinks, sink)
+		}
+	})
+	return sinks
+}
+
+func parseSink(sinkUrl string) (Sink, error) {
+	u, err := url.Parse(sinkUrl)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse `%s`: %v", sinkUrl, err)
+	}
+	switch u.Scheme {
+	case "pushgateway+http", "pushgateway+https":
+		job := util.Value(u.Query().Get("job"), defaultPushgatewayJob)
+		endpoint := fmt.Sprintf("%s://%s%s", strings.TrimPrefix(u.Scheme, "pushgateway+"), u.Host,
+			strings.TrimSuffix(u.Path, "/"))
+		return &pushgatewaySink{endpoint: endpoint, job: job}, nil
+	case "statsd":
+		if u.Host == "" {
+			return nil, fmt.Errorf("StatsD sink `%s` must specify host:port", sinkUrl)
+		}
+		return &statsdSink{address: u.Host, prefix: u.Query().Get("prefix")}, nil
+	case "file", "":
+		path := u.Path
+		if u.Host != "" { // file://relative/path
+			path = u.Host + u.Path
+		}
+		if path == "" {
+			return nil, fmt.Errorf("File sink `%s` must specify a path", sinkUrl)
+		}
+		return &fileSink{path: path}, nil
+	}
+	return nil, fmt.Errorf("Metrics sink `%s` scheme `%s` is not one of pushgateway+http(s), statsd, file",
+		sinkUrl, u.Scheme)
+}
+
+func record(name, kind string, value float64, tags []string) {
+	if len(configuredSinks()) == 0 {
+		return
+	}
+	sample := Sample{
+		Name:      name,
+		Kind:      kind,
+		Value:     value,
+		Tags:      make(map[string]string),
+		Timestamp: time.Now(),
+	}
+	for i := 0; i+1 < len(tags); i += 2 {
+		if tags[i+1] != "" {
+			sample.Tags[tags[i]] = tags[i+1]
+		}
+	}
+	samplesMu.Lock()
+	samples = append(samples, sample)
+	samplesMu.Unlock()
+}
+
+// Disable drops recorded samples instead of sending them, ie. for simulated operations
+func Disable() {
+	sinksOnce.Do(func() {})
+	sinks = nil
+}
+
+// Count records a counter increment; tags are key, value pairs
+func Count(name string, value float64, tags ...string) {
+	record(name, sampleCounter, value, tags)
+}
+
+// Gauge records a point-in-time value
+func Gauge(name string, value float64, tags ...string) {
+	record(name, sampleGauge, value, tags)
+}
+
+// Timing records a duration in seconds
+func Timing(name string, duration time.Duration, tags ...string) {
+	record(name, sampleTiming, duration.Seconds(), tags)
+}
+
+// Flush sends recorded samples to all configured sinks
+func Flush() {
+	samplesMu.Lock()
+	batch := samples
+	samples = nil
+	samplesMu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	for _, sink := range configuredSinks() {
+		if err := sink.Put(batch); err != nil {
+			util.Warn("Unable to send metrics to %s: %v", sink, err)
+		} else if config.Debug {
+			log.Printf("Sent %d %s to %s", len(batch), util.Plural(len(batch), "metric"), sink)
+		}
+	}
+}
+
+func sortedTags(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func promName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func promLabels(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	labels := make([]string, 0, len(tags))
+	for _, key := range sortedTags(tags) {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(tags[key])
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, promName(key), value))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func promType(kind string) string {
+	if kind == sampleCounter {
+		return "counter"
+	}
+	return "gauge"
+}
+
+// Prometheus text exposition format; pushgateway does not accept timestamps
+func promText(samples []Sample, timestamps bool) []byte {
+	var buf bytes.Buffer
+	typed := make(map[string]struct{})
+	for _, sample := range samples {
+		name := promName(sample.Name)
+		if _, seen := typed[name]; !seen && !timestamps {
+			fmt.Fprintf(&buf, "# TYPE %s %s\n", name, promType(sample.Kind))
+			typed[name] = struct{}{}
+		}
+		fmt.Fprintf(&buf, "%s%s %g", name, promLabels(sample.Tags), sample.Value)
+		if timestamps {
+			fmt.Fprintf(&buf, " %d", sample.Timestamp.UnixNano()/int64(time.Millisecond))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+type pushgatewaySink struct {
+	endpoint string
+	job      string
+}
+
+func (s *pushgatewaySink) String() string {
+	return fmt.Sprintf("pushgateway %s", s.endpoint)
+}
+
+func (s *pushgatewaySink) Put(samples []Sample) error {
+	// pushgateway requires one sample per metric and labels set in a group
+	latest := make(map[string]Sample)
+	order := make([]string, 0, len(samples))
+	for _, sample := range samples {
+		key := sample.Name + promLabels(sample.Tags)
+		if existing, exist := latest[key]; exist {
+			if sample.Kind == sampleCounter {
+				sample.Value += existing.Value
+			}
+		} else {
+			order = append(order, key)
+		}
+		latest[key] = sample
+	}
+	unique := make([]Sample, 0, len(order))
+	for _, key := range order {
+		unique = append(unique, latest[key])
+	}
+	addr := fmt.Sprintf("%s/metrics/job/%s", s.endpoint, url.PathEscape(s.job))
+	req, err := http.NewRequest("POST", addr, bytes.NewReader(promText(unique, false)))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-type", "text/plain; version=0.0.4")
+	if config.Trace {
+		log.Printf(">>> %s %s", req.Method, req.URL.String())
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error during HTTP request: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if config.Trace {
+		log.Printf("<<< %s %s: %s", req.Method, req.URL.String(), resp.Status)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Pushgateway returned HTTP status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+type statsdSink struct {
+	address string
+	prefix  string
+}
+
+func (s *statsdSink) String() string {
+	return fmt.Sprintf("statsd %s", s.address)
+}
+
+// Put sends samples over UDP with DogStatsD-style tags
+func (s *statsdSink) Put(samples []Sample) error {
+	conn, err := net.Dial("udp", s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, sample := range samples {
+		name := sample.Name
+		if s.prefix != "" {
+			name = s.prefix + "." + name
+		}
+		var line string
+		switch sample.Kind {
+		case sampleCounter:
+			line = fmt.Sprintf("%s:%g|c", name, sample.Value)
+		case sampleTiming:
+			line = fmt.Sprintf("%s:%d|ms", name, int64(sample.Value*1000))
+		default:
+			line = fmt.Sprintf("%s:%g|g", name, sample.Value)
+		}
+		if len(sample.Tags) > 0 {
+			tags := make([]string, 0, len(sample.Tags))
+			for _, key := range sortedTags(sample.Tags) {
+				tags = append(tags, key+":"+sample.Tags[key])
+			}
+			line += "|#" + strings.Join(tags, ",")
+		}
+		if _, err := conn.Write([]byte(line)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) String() string {
+	return fmt.Sprintf("file %s", s.path)
+}
+
+// Put appends samples in Prometheus text format with timestamps
+func (s *fileSink) Put(samples []Sample) error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(promText(samples, true))
+	if err2 := file.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package parameters
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+var OutputAssertionTypes = []string{"string", "number", "integer", "boolean", "object", "array"}
+
+// AssertOutput checks output value against declared assertion, `value` is bound in CEL
+// expression in addition to parameters and outputs already in kv
+func AssertOutput(what string, assert *manifest.OutputAssertion, value interface{},
+	component string, depends []string, kv map[string]interface{}) []error {
+
+	if assert == nil {
+		return nil
+	}
+	errs := make([]error, 0)
+	if assert.NonEmpty && util.Empty(value) {
+		errs = append(errs, fmt.Errorf("%s must not be empty", what))
+	}
+	if assert.Type != "" {
+		if !util.Contains(OutputAssertionTypes, assert.Type) {
+			errs = append(errs, fmt.Errorf("%s assertion type `%s` is not one of %v", what, assert.Type, OutputAssertionTypes))
+		} else if !valueOfType(value, assert.Type) {
+			errs = append(errs, fmt.Errorf("%s = `%s` is not of type %s", what, util.Trim(util.String(value)), assert.Type))
+		}
+	}
+	if assert.Pattern != "" {
+		re, err := regexp.Compile(assert.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s assertion pattern `%s` is not valid: %v", what, assert.Pattern, err))
+		} else if !re.MatchString(util.String(value)) {
+			errs = append(errs, fmt.Errorf("%s = `%s` does not match `%s`", what, util.Trim(util.String(value)), assert.Pattern))
+		}
+	}
+	if assert.CEL != "" {
+		kvValue := make(map[string]interface{}, len(kv)+1)
+		for k, v := range kv {
+			kvValue[k] = v
+		}
+		kvValue["value"] = value
+		result, err := CelEval(assert.CEL, component, depends, kvValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s assertion: %v", what, err))
+		} else if result != "true" {
+			errs = append(errs, fmt.Errorf("%s = `%s` assertion `%s` evaluated to `%s`",
+				what, util.Trim(util.String(value)), assert.CEL, result))
+		}
+	}
+	return errs
+}
+
+// AssertRequestedOutputs checks expanded stack outputs against assertions declared on stack outputs
+func AssertRequestedOutputs(parameters LockedParameters, outputs CapturedOutputs,
+	requestedOutputs []manifest.Output, expanded []ExpandedOutput) []error {
+
+	kv := ParametersAndOutputsKV(parameters, outputs, nil)
+	errs := make([]error, 0)
+	for _, requestedOutput := range requestedOutputs {
+		if requestedOutput.Assert == nil {
+			continue
+		}
+		what := fmt.Sprintf("Stack output `%s`", requestedOutput.Name)
+		var output *ExpandedOutput
+		for i := range expanded {
+			if expanded[i].Name == requestedOutput.Name {
+				output = &expanded[i]
+				break
+			}
+		}
+		if output == nil {
+			if requestedOutput.Assert.Required {
+				errs = append(errs, fmt.Errorf("%s is required but not found", what))
+			}
+			continue
+		}
+		errs = append(errs, AssertOutput(what, requestedOutput.Assert, output.Value, "", nil, kv)...)
+	}
+	return errs
+}
+
+func valueOfType(value interface{}, kind string) bool {
+	switch kind {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number", "integer":
+		switch v := value.(type) {
+		case int, int64, uint, uint64:
+			return true
+		case float64:
+			return kind == "number" || v == float64(int64(v))
+		case string:
+			if kind == "integer" {
+				_, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+				return err == nil
+			}
+			_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return err == nil
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return true
+		case string:
+			_, err := strconv.ParseBool(strings.TrimSpace(v))
+			return err == nil
+		}
+	case "object":
+		switch value.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			return true
+		}
+	case "array":
+		switch value.(type) {
+		case []interface{}, []string:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package parameters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+func TestAssertOutput(t *testing.T) {
+	kv := map[string]interface{}{"dns.domain": "example.com", "replicas": 3}
+	tests := []struct {
+		name   string
+		assert *manifest.OutputAssertion
+		value  interface{}
+		errors []string
+	}{
+		{"no assertion", nil, "", nil},
+		{"nonEmpty", &manifest.OutputAssertion{NonEmpty: true}, "x", nil},
+		{"nonEmpty empty", &manifest.OutputAssertion{NonEmpty: true}, "", []string{"must not be empty"}},
+		{"nonEmpty nil", &manifest.OutputAssertion{NonEmpty: true}, nil, []string{"must not be empty"}},
+		{"string", &manifest.OutputAssertion{Type: "string"}, "x", nil},
+		{"string mismatch", &manifest.OutputAssertion{Type: "string"}, 1, []string{"is not of type string"}},
+		{"integer", &manifest.OutputAssertion{Type: "integer"}, "42", nil},
+		{"integer float", &manifest.OutputAssertion{Type: "integer"}, 4.0, nil},
+		{"integer mismatch", &manifest.OutputAssertion{Type: "integer"}, "4.2", []string{"is not of type integer"}},
+		{"number", &manifest.OutputAssertion{Type: "number"}, "4.2", nil},
+		{"boolean", &manifest.OutputAssertion{Type: "boolean"}, "true", nil},
+		{"boolean mismatch", &manifest.OutputAssertion{Type: "boolean"}, "yes", []string{"is not of type boolean"}},
+		{"object", &manifest.OutputAssertion{Type: "object"}, map[string]interface{}{"a": 1}, nil},
+		{"array", &manifest.OutputAssertion{Type: "array"}, []interface{}{"a"}, nil},
+		{"array mismatch", &manifest.OutputAssertion{Type: "array"}, "a,b", []string{"is not of type array"}},
+		{"unknown type", &manifest.OutputAssertion{Type: "date"}, "x", []string{"assertion type `date` is not one of"}},
+		{"pattern", &manifest.OutputAssertion{Pattern: "^https://"}, "https://api", nil},
+		{"pattern mismatch", &manifest.OutputAssertion{Pattern: "^https://"}, "http://api", []string{"does not match `^https://`"}},
+		{"pattern invalid", &manifest.OutputAssertion{Pattern: "("}, "x", []string{"is not valid"}},
+		{"cel", &manifest.OutputAssertion{CEL: "value.endsWith(dns.domain)"}, "api.example.com", nil},
+		{"cel value", &manifest.OutputAssertion{CEL: "int(value) <= replicas"}, "2", nil},
+		{"cel false", &manifest.OutputAssertion{CEL: "value.endsWith(dns.domain)"}, "api.example.org",
+			[]string{"evaluated to `false`"}},
+		{"cel error", &manifest.OutputAssertion{CEL: "value.endsWith("}, "x", []string{"assertion:"}},
+		{"all", &manifest.OutputAssertion{NonEmpty: true, Type: "integer", Pattern: "^[0-9]+$"}, "",
+			[]string{"must not be empty", "is not of type integer", "does not match"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := AssertOutput("Output `x`", test.assert, test.value, "", nil, kv)
+			if len(errs) != len(test.errors) {
+				t.Fatalf("errors = %v, want %d matching %v", errs, len(test.errors), test.errors)
+			}
+			for i, want := range test.errors {
+				if !strings.HasPrefix(errs[i].Error(), "Output `x`") || !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error = `%v`, want `%s`", errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAssertRequestedOutputs(t *testing.T) {
+	params := LockedParameters{"dns.domain": {Name: "dns.domain", Value: "example.com"}}
+	requested := []manifest.Output{
+		{Name: "url", Assert: &manifest.OutputAssertion{CEL: "value.endsWith(dns.domain)"}},
+		{Name: "token", Assert: &manifest.OutputAssertion{Required: true}},
+		{Name: "optional", Assert: &manifest.OutputAssertion{NonEmpty: true}},
+		{Name: "plain"},
+	}
+	expanded := []ExpandedOutput{
+		{Name: "url", Value: "https://api.example.org"},
+		{Name: "plain", Value: ""},
+	}
+	errs := AssertRequestedOutputs(params, nil, requested, expanded)
+	want := []string{
+		"Stack output `url` = `https://api.example.org` assertion `value.endsWith(dns.domain)` evaluated to `false`",
+		"Stack output `token` is required but not found",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("errors = %v, want %v", errs, want)
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Errorf("error = `%v`, want `%s`", errs[i], want[i])
+		}
+	}
+}
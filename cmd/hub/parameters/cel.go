@@ -28,7 +28,7 @@ func CelEval(expr string, component string, depends []string, kv map[string]inte
 	if issues != nil && issues.Err() != nil {
 		return "(parse error)", fmt.Errorf("CEL parse error: %v", issues.Err())
 	}
-	program, err := CEL.Program(ast)
+	program, err := CEL.Program(ast, cel.Functions(celEncoderFunctions()...))
 	if err != nil {
 		return "(program error)", fmt.Errorf("CEL program construction error `%s`: %v", expr, err)
 	}
@@ -0,0 +1,446 @@
+package parameters
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/interpreter/functions"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v2"
+
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+// Encoders are applied to substitutions written as `${name/encoder1/encoder2/...}`
+// in parameter values, and as `${name/...}` and `{{name/...}}` in curly and mustache templates.
+//
+// Chaining: encoders are applied left to right, the first one receives the value as is -
+// a string, a number, or a structure (a list or a map) for outputs of `json` or `yaml` kind, -
+// every next encoder receives the result of the previous one. Structure encoders marshal the
+// value; text encoders convert non-string value to a string first. For example,
+// `${component.tags/yaml/indent-4}` renders YAML block to be placed at 4th column.
+//
+//	json          JSON
+//	yaml          YAML document, multi-line
+//	yaml-inline   YAML on a single line: flow style for structures, quoted scalar if necessary
+//	toml          TOML value: basic string, number, boolean, array, inline table
+//	hcl           HCL value for .tfvars: string with `${` and `%{` escaped, number, bool, list, map
+//	indent-N      indent all lines but the first by N spaces; trailing newline is removed
+//	quote         double-quoted string with JSON escapes
+//	shell         single-quoted string safe for POSIX shell
+//	urlencode     URL query escaping
+//	base64        base64 encode
+//	unbase64      base64 decode
+//	sha256        hex SHA-256 digest
+//	bcrypt        bcrypt hash
+//	htpasswd      bcrypt htpasswd entry; `user:password` value produces `user:hash`
+//	lower         lower case
+//	upper         upper case
+//	trim          trim leading and trailing whitespace
+//
+// Templates written for legacy curly and mustache substitutions, that supported only `json`, `yaml`,
+// `bcrypt`, `base64`, and `unbase64` in a fixed order, are rendered as before, see LegacyOrder.
+//
+// The same encoders are available to `#{}` CEL expressions as functions with names in lower camel case:
+// `yamlInline(x)`, `urlencode(x)`, `indent(x, 4)`; and as a chain `encode(x, "yaml/indent-4")`.
+type Encoder func(value interface{}) (interface{}, error)
+
+var (
+	encoders = map[string]Encoder{
+		"json":        encodeJson,
+		"yaml":        encodeYaml,
+		"yaml-inline": encodeYamlInline,
+		"toml":        encodeToml,
+		"hcl":         encodeHcl,
+		"quote":       textEncoder(quote),
+		"shell":       textEncoder(shellQuote),
+		"urlencode":   textEncoder(url.QueryEscape),
+		"base64":      textEncoder(func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }),
+		"unbase64":    encodeUnbase64,
+		"sha256":      textEncoder(sha256Hex),
+		"bcrypt":      encodeBcrypt,
+		"htpasswd":    encodeHtpasswd,
+		"lower":       textEncoder(strings.ToLower),
+		"upper":       textEncoder(strings.ToUpper),
+		"trim":        textEncoder(strings.TrimSpace),
+	}
+
+	legacyEncodings = []string{"json", "yaml", "bcrypt", "base64", "unbase64"}
+
+	indentEncoder = regexp.MustCompile("^indent-([0-9]+)$")
+	hclIdentifier = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_-]*$")
+)
+
+// RegisterEncoder adds (or replaces) an encoder available to substitutions and CEL expressions
+func RegisterEncoder(name string, encoder Encoder) {
+	encoders[name] = encoder
+}
+
+// EncoderNames returns sorted names of registered encoders
+func EncoderNames() []string {
+	names := make([]string, 0, len(encoders)+1)
+	for name := range encoders {
+		names = append(names, name)
+	}
+	names = append(names, "indent-N")
+	sort.Strings(names)
+	return names
+}
+
+func findEncoder(name string) (Encoder, bool) {
+	if encoder, exist := encoders[name]; exist {
+		return encoder, true
+	}
+	if match := indentEncoder.FindStringSubmatch(name); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		return textEncoder(func(s string) string { return indent(s, n) }), true
+	}
+	return nil, false
+}
+
+// UnknownEncoders returns encodings that has no registered encoder
+func UnknownEncoders(encodings []string) []string {
+	unknown := make([]string, 0)
+	for _, encoding := range encodings {
+		if _, exist := findEncoder(encoding); !exist {
+			unknown = append(unknown, encoding)
+		}
+	}
+	return unknown
+}
+
+// SplitEncodings splits `name/encoder1/encoder2` substitution into name and encodings
+func SplitEncodings(substitution string) (string, []string) {
+	if strings.Contains(substitution, "/") {
+		parts := strings.Split(substitution, "/")
+		return parts[0], parts[1:]
+	}
+	return substitution, nil
+}
+
+// LegacyOrder returns encodings in the fixed order curly and mustache template substitutions applied them
+// before encoders were chained: `json` or `yaml`, then `bcrypt`, then `base64` or `unbase64`; the first of
+// mutually exclusive encodings wins as before. Encodings are returned as is if any of them is not legacy.
+func LegacyOrder(encodings []string) []string {
+	for _, encoding := range encodings {
+		if !util.Contains(legacyEncodings, encoding) {
+			return encodings
+		}
+	}
+	ordered := make([]string, 0, len(encodings))
+	for _, group := range [][]string{{"json", "yaml"}, {"bcrypt"}, {"base64", "unbase64"}} {
+		for _, encoding := range group {
+			if util.Contains(encodings, encoding) {
+				ordered = append(ordered, encoding)
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+// Encode applies encoders left to right
+func Encode(value interface{}, encodings []string) (interface{}, error) {
+	for _, encoding := range encodings {
+		encoder, exist := findEncoder(encoding)
+		if !exist {
+			return value, fmt.Errorf("Unknown encoding `%s`; supported %v", encoding, EncoderNames())
+		}
+		encoded, err := encoder(plainStructure(value))
+		if err != nil {
+			return value, fmt.Errorf("Unable to encode with `%s`: %v", encoding, err)
+		}
+		value = encoded
+	}
+	return value, nil
+}
+
+func textEncoder(encode func(string) string) Encoder {
+	return func(value interface{}) (interface{}, error) {
+		return encode(util.String(value)), nil
+	}
+}
+
+// plainStructure converts YAML maps with interface{} keys to maps with string keys
+func plainStructure(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		dict := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			dict[fmt.Sprintf("%v", key)] = plainStructure(entry)
+		}
+		return dict
+	case map[string]interface{}:
+		dict := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			dict[key] = plainStructure(entry)
+		}
+		return dict
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, entry := range v {
+			list = append(list, plainStructure(entry))
+		}
+		return list
+	}
+	return value
+}
+
+func marshalJson(value interface{}) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+func encodeJson(value interface{}) (interface{}, error) {
+	return marshalJson(value)
+}
+
+func encodeYaml(value interface{}) (interface{}, error) {
+	bytes, err := yaml.Marshal(value)
+	return string(bytes), err
+}
+
+func encodeYamlInline(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		// JSON is YAML flow style
+		return marshalJson(value)
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	scalar := strings.TrimSuffix(string(bytes), "\n")
+	if strings.Contains(scalar, "\n") {
+		return quote(util.String(value)), nil
+	}
+	return scalar, nil
+}
+
+func quote(str string) string {
+	quoted, _ := marshalJson(str)
+	return quoted
+}
+
+func shellQuote(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", `'\''`) + "'"
+}
+
+func indent(str string, n int) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimSuffix(str, "\n"), "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = pad + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sha256Hex(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func encodeUnbase64(value interface{}) (interface{}, error) {
+	bytes, err := base64.StdEncoding.DecodeString(util.String(value))
+	return string(bytes), err
+}
+
+func encodeBcrypt(value interface{}) (interface{}, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(util.String(value)), bcrypt.DefaultCost)
+	return string(bytes), err
+}
+
+func encodeHtpasswd(value interface{}) (interface{}, error) {
+	str := util.String(value)
+	user := ""
+	if i := strings.Index(str, ":"); i >= 0 {
+		user, str = str[:i], str[i+1:]
+	}
+	bytes, err := bcrypt.GenerateFromPassword([]byte(str), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	// Apache htpasswd marks bcrypt hash with $2y$
+	hash := strings.Replace(string(bytes), "$2a$", "$2y$", 1)
+	if user != "" {
+		return user + ":" + hash, nil
+	}
+	return hash, nil
+}
+
+func sortedKeys(dict map[string]interface{}) []string {
+	keys := make([]string, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func encodeToml(value interface{}) (interface{}, error) {
+	return tomlValue(value)
+}
+
+func tomlValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return `""`, nil
+	case string:
+		return quote(v), nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprintf("%v", v), nil
+	case []interface{}:
+		elements := make([]string, 0, len(v))
+		for _, entry := range v {
+			element, err := tomlValue(entry)
+			if err != nil {
+				return "", err
+			}
+			elements = append(elements, element)
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case map[string]interface{}:
+		entries := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			element, err := tomlValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			if !hclIdentifier.MatchString(key) {
+				key = quote(key)
+			}
+			entries = append(entries, key+" = "+element)
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("Unsupported TOML value type %T", value)
+}
+
+func encodeHcl(value interface{}) (interface{}, error) {
+	return hclValue(value)
+}
+
+func hclValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		str := strings.ReplaceAll(quote(v), "${", "$${")
+		return strings.ReplaceAll(str, "%{", "%%{"), nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprintf("%v", v), nil
+	case []interface{}:
+		elements := make([]string, 0, len(v))
+		for _, entry := range v {
+			element, err := hclValue(entry)
+			if err != nil {
+				return "", err
+			}
+			elements = append(elements, element)
+		}
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case map[string]interface{}:
+		entries := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			element, err := hclValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			if !hclIdentifier.MatchString(key) {
+				key = quote(key)
+			}
+			entries = append(entries, key+" = "+element)
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("Unsupported HCL value type %T", value)
+}
+
+// celNative converts CEL value to plain Go value
+func celNative(value ref.Val) interface{} {
+	switch v := value.(type) {
+	case traits.Lister:
+		list := make([]interface{}, 0)
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			list = append(list, celNative(it.Next()))
+		}
+		return list
+	case traits.Mapper:
+		dict := make(map[string]interface{})
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			dict[fmt.Sprintf("%v", key.Value())] = celNative(v.Get(key))
+		}
+		return dict
+	}
+	return plainStructure(value.Value())
+}
+
+func celEncode(value ref.Val, encodings []string) ref.Val {
+	if types.IsError(value) {
+		return value
+	}
+	encoded, err := Encode(celNative(value), encodings)
+	if err != nil {
+		return types.NewErr("%v", err)
+	}
+	return types.String(util.String(encoded))
+}
+
+// celFunctionName converts `yaml-inline` into `yamlInline`
+func celFunctionName(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func celEncoderFunctions() []*functions.Overload {
+	overloads := make([]*functions.Overload, 0, len(encoders)+2)
+	for name := range encoders {
+		encoding := name
+		overloads = append(overloads, &functions.Overload{
+			Operator: celFunctionName(name),
+			Unary: func(value ref.Val) ref.Val {
+				return celEncode(value, []string{encoding})
+			},
+		})
+	}
+	overloads = append(overloads,
+		&functions.Overload{
+			Operator: "indent",
+			Binary: func(value, n ref.Val) ref.Val {
+				return celEncode(value, []string{fmt.Sprintf("indent-%v", n.Value())})
+			},
+		},
+		&functions.Overload{
+			Operator: "encode",
+			Binary: func(value, encodings ref.Val) ref.Val {
+				_, chain := SplitEncodings("/" + util.String(encodings.Value()))
+				return celEncode(value, chain)
+			},
+		})
+	return overloads
+}
@@ -0,0 +1,53 @@
+package parameters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeChain(t *testing.T) {
+	tests := []struct {
+		value     interface{}
+		encodings []string
+		want      string
+	}{
+		{"secret", []string{"base64", "json"}, `"c2VjcmV0"`},
+		{"secret", []string{"json", "base64"}, "InNlY3JldCI="},
+		{"c2VjcmV0", []string{"unbase64", "upper"}, "SECRET"},
+		{map[string]interface{}{"a": []interface{}{"b", "c"}}, []string{"yaml", "indent-2"}, "a:\n  - b\n  - c"},
+		{"<a & b>", []string{"json"}, `"<a & b>"`},
+	}
+	for _, test := range tests {
+		encoded, err := Encode(test.value, test.encodings)
+		if err != nil {
+			t.Errorf("%v %v: %v", test.value, test.encodings, err)
+			continue
+		}
+		if encoded != test.want {
+			t.Errorf("%v %v = %q, want %q", test.value, test.encodings, encoded, test.want)
+		}
+	}
+}
+
+func TestLegacyOrder(t *testing.T) {
+	tests := []struct {
+		encodings []string
+		want      []string
+	}{
+		// legacy: json or yaml, then bcrypt, then base64 or unbase64
+		{[]string{"base64", "json"}, []string{"json", "base64"}},
+		{[]string{"base64", "bcrypt"}, []string{"bcrypt", "base64"}},
+		{[]string{"yaml", "json"}, []string{"json"}},
+		{[]string{"unbase64", "base64"}, []string{"base64"}},
+		{[]string{"json", "base64"}, []string{"json", "base64"}},
+		{[]string{"unbase64"}, []string{"unbase64"}},
+		// any new encoder in the chain - left to right
+		{[]string{"base64", "quote"}, []string{"base64", "quote"}},
+		{[]string{"unbase64", "yaml", "indent-4"}, []string{"unbase64", "yaml", "indent-4"}},
+	}
+	for _, test := range tests {
+		if got := LegacyOrder(test.encodings); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("LegacyOrder(%v) = %v, want %v", test.encodings, got, test.want)
+		}
+	}
+}
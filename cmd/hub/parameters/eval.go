@@ -134,10 +134,19 @@ func expandValue(parameter *manifest.Parameter, value string, componentDepends [
 				substitution = evaluated
 			} else {
 				mask = mask || util.LooksLikeSecret(expr)
-				found, exist := FindValue(expr, parameter.Component, componentDepends, kv)
+				name, encodings := SplitEncodings(expr)
+				found, exist := FindValue(name, parameter.Component, componentDepends, kv)
+				if exist && len(encodings) > 0 {
+					encoded, err := Encode(found, encodings)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("Parameter `%s` value `%s` substitution `%s`: %v",
+							parameter.QName(), parameter.Value, expr, err))
+					}
+					found = encoded
+				}
 				if !exist {
 					errs = append(errs, fmt.Errorf("Parameter `%s` value `%s` refer to unknown substitution `%s` at depth %d",
-						parameter.QName(), parameter.Value, expr, depth))
+						parameter.QName(), parameter.Value, name, depth))
 					substitution = "(unknown)"
 				} else {
 					if found == nil {
@@ -41,11 +41,21 @@ type StateStep struct {
 	Parameters      []parameters.LockedParameter `yaml:",omitempty"`
 	RawOutputs      []parameters.RawOutput       `yaml:"rawOutputs,omitempty"`
 	CapturedOutputs []parameters.CapturedOutput  `yaml:"capturedOutputs,omitempty"`
+	ReadyConditions []ReadyConditionStatus       `yaml:"readyConditions,omitempty"`
+}
+
+type ReadyConditionStatus struct {
+	Condition string
+	Status    string    // ready, failed, timeout
+	Message   string    `yaml:",omitempty"`
+	Timestamp time.Time `yaml:",omitempty"`
+	Seconds   int       `yaml:",omitempty"`
 }
 
 type LifecyclePhase struct {
-	Phase  string `yaml:",omitempty"`
-	Status string `yaml:",omitempty"`
+	Phase   string `yaml:",omitempty"`
+	Status  string `yaml:",omitempty"`
+	LogFile string `yaml:"logFile,omitempty"` // relative to operation logDir
 }
 
 type LifecycleOperation struct {
@@ -57,6 +67,8 @@ type LifecycleOperation struct {
 	Description string                 `yaml:",omitempty"`
 	Initiator   string                 `yaml:",omitempty"`
 	Logs        string                 `yaml:",omitempty"`
+	LogDir      string                 `yaml:"logDir,omitempty"`
+	LogRemote   []string               `yaml:"logRemote,omitempty"`
 	Phases      []LifecyclePhase       `yaml:",omitempty"`
 }
 
@@ -71,6 +83,7 @@ type StateManifest struct {
 	StackParameters []parameters.LockedParameter `yaml:"stackParameters,omitempty"`
 	CapturedOutputs []parameters.CapturedOutput  `yaml:"capturedOutputs,omitempty"`
 	StackOutputs    []parameters.ExpandedOutput  `yaml:"stackOutputs,omitempty"`
+	ReadyConditions []ReadyConditionStatus       `yaml:"readyConditions,omitempty"`
 	Provides        map[string][]string          `yaml:",omitempty"`
 	Components      map[string]*StateStep        `yaml:",omitempty"`
 	Operations      []LifecycleOperation         `yaml:",omitempty"`
@@ -12,6 +12,7 @@ import (
 
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/metrics"
 	"github.com/agilestacks/hub/cmd/hub/parameters"
 	"github.com/agilestacks/hub/cmd/hub/storage"
 	"github.com/agilestacks/hub/cmd/hub/util"
@@ -106,7 +107,7 @@ func UpdateState(manifest *StateManifest, componentName string,
 	provides map[string][]string,
 	final bool) *StateManifest {
 
-	now := time.Now()
+	now := util.Now()
 
 	manifest = maybeInitState(manifest)
 	componentState := maybeInitComponentState(manifest, componentName)
@@ -132,7 +133,7 @@ func UpdateState(manifest *StateManifest, componentName string,
 func UpdateStackStatus(manifest *StateManifest, status, message string) *StateManifest {
 	manifest = maybeInitState(manifest)
 	if status != "" {
-		manifest.Timestamp = time.Now()
+		manifest.Timestamp = util.Now()
 		manifest.Status = status
 		manifest.Message = message
 		if config.Debug {
@@ -148,7 +149,7 @@ func UpdateStackStatus(manifest *StateManifest, status, message string) *StateMa
 func UpdateComponentStartTimestamp(manifest *StateManifest, name string) *StateManifest {
 	manifest = maybeInitState(manifest)
 	componentState := maybeInitComponentState(manifest, name)
-	componentState.Timestamps.Start = time.Now()
+	componentState.Timestamps.Start = util.Now()
 	return manifest
 }
 
@@ -170,7 +171,7 @@ func UpdateComponentStatus(manifest *StateManifest, name string, meta *manifest.
 			Maturity:    meta.Maturity,
 			Icon:        icon,
 		}
-		now := time.Now()
+		now := util.Now()
 		componentState.Timestamp = now
 		componentState.Timestamps.End = now
 		componentState.Status = status
@@ -185,6 +186,22 @@ func UpdateComponentStatus(manifest *StateManifest, name string, meta *manifest.
 	return manifest
 }
 
+func UpdateReadyConditions(manifest *StateManifest, name string, conditions []ReadyConditionStatus) *StateManifest {
+	manifest = maybeInitState(manifest)
+	if name == "" {
+		manifest.ReadyConditions = conditions
+	} else {
+		componentState := maybeInitComponentState(manifest, name)
+		componentState.ReadyConditions = conditions
+	}
+	if config.Debug {
+		for _, condition := range conditions {
+			log.Printf("State ready condition `%s` status: %s", condition.Condition, condition.Status)
+		}
+	}
+	return manifest
+}
+
 func EraseComponentEmptyState(manifest *StateManifest, name string) *StateManifest {
 	manifest = maybeInitState(manifest)
 	componentState := manifest.Components[name]
@@ -206,7 +223,7 @@ func UpdateOperation(manifest *StateManifest, id, operation, status string, opti
 	op := LifecycleOperation{
 		Id:        id,
 		Operation: operation,
-		Timestamp: time.Now(),
+		Timestamp: util.Now(),
 		Status:    status,
 		Options:   options,
 		Initiator: os.Getenv("USER"),
@@ -216,6 +233,8 @@ func UpdateOperation(manifest *StateManifest, id, operation, status string, opti
 			op.Options = ops[found].Options
 		}
 		op.Logs = ops[found].Logs
+		op.LogDir = ops[found].LogDir
+		op.LogRemote = ops[found].LogRemote
 		op.Phases = ops[found].Phases
 		ops[found] = op
 	} else {
@@ -278,6 +297,7 @@ func UpdatePhase(manifest *StateManifest, opId, name, status string) *StateManif
 	}
 	phase := LifecyclePhase{Phase: name, Status: status}
 	if foundPhase >= 0 {
+		phase.LogFile = phases[foundPhase].LogFile
 		phases[foundPhase] = phase
 	} else {
 		manifest.Operations[foundOp].Phases = append(phases, phase)
@@ -288,6 +308,34 @@ func UpdatePhase(manifest *StateManifest, opId, name, status string) *StateManif
 	return manifest
 }
 
+func UpdateOperationLogDir(manifest *StateManifest, id, dir string, remote []string) *StateManifest {
+	foundOp := findOperation(manifest, id)
+	if foundOp == -1 {
+		return manifest
+	}
+	manifest.Operations[foundOp].LogDir = dir
+	manifest.Operations[foundOp].LogRemote = remote
+	return manifest
+}
+
+func UpdatePhaseLogFile(manifest *StateManifest, opId, name, logFile string) *StateManifest {
+	foundOp := findOperation(manifest, opId)
+	if foundOp == -1 {
+		return manifest
+	}
+	phases := manifest.Operations[foundOp].Phases
+	for i, phase := range phases {
+		if phase.Phase == name {
+			phases[i].LogFile = logFile
+			break
+		}
+	}
+	if config.Debug {
+		log.Printf("State lifecycle phase `%s` log file: %s", name, logFile)
+	}
+	return manifest
+}
+
 func WriteState(manifest *StateManifest, stateFiles *storage.Files) error {
 	manifest.Version = 1
 	manifest.Kind = "state"
@@ -297,7 +345,13 @@ func WriteState(manifest *StateManifest, stateFiles *storage.Files) error {
 		return fmt.Errorf("Unable to marshal state into YAML: %v", err)
 	}
 
+	start := time.Now()
 	written, errs := storage.Write(yamlBytes, stateFiles)
+	status := "success"
+	if !written {
+		status = "error"
+	}
+	metrics.Timing("hub_state_write_seconds", time.Since(start), "status", status)
 	if len(errs) > 0 {
 		msg := fmt.Sprintf("Unable to write state: %s", util.Errors2(errs...))
 		if !written {
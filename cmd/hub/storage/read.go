@@ -14,6 +14,7 @@ import (
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/crypto"
 	"github.com/agilestacks/hub/cmd/hub/gcp"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
@@ -240,6 +241,9 @@ func chooseFile(files *Files) (*File, error) {
 }
 
 func readFile(file *File) ([]byte, error) {
+	span := tracing.StartClient("storage read", "hub.storage.kind", file.Kind, "hub.storage.path", file.Path)
+	defer span.End()
+
 	var data []byte
 	var err error
 
@@ -257,6 +261,7 @@ func readFile(file *File) ([]byte, error) {
 		data, err = azure.ReadStorageBlob(file.Path)
 	}
 	if err != nil {
+		span.SetError(err)
 		return nil, fmt.Errorf("Unable to read `%s`: %v", file.Path, err)
 	}
 	return data, nil
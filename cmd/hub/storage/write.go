@@ -5,16 +5,26 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/agilestacks/hub/cmd/hub/aws"
 	"github.com/agilestacks/hub/cmd/hub/azure"
 	"github.com/agilestacks/hub/cmd/hub/config"
 	"github.com/agilestacks/hub/cmd/hub/crypto"
 	"github.com/agilestacks/hub/cmd/hub/gcp"
+	"github.com/agilestacks/hub/cmd/hub/tracing"
 	"github.com/agilestacks/hub/cmd/hub/util"
 )
 
 func Write(data []byte, files *Files) (bool, []error) {
+	paths := make([]string, 0, len(files.Files))
+	for _, file := range files.Files {
+		paths = append(paths, file.Path)
+	}
+	span := tracing.StartClient("storage write", "hub.storage.kind", files.Kind,
+		"hub.storage.path", strings.Join(paths, ","))
+	defer span.End()
+
 	// write remote files encrypted
 	encrypt := false
 	if config.Encrypted {
@@ -101,6 +111,9 @@ func Write(data []byte, files *Files) (bool, []error) {
 			written = true
 		}
 	}
+	if len(errs) > 0 {
+		span.SetError(errors.New(util.Errors2(errs...)))
+	}
 
 	return written, errs
 }
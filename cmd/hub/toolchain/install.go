@@ -0,0 +1,303 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const downloadTimeout = 10 * time.Minute
+
+type InstallRequest struct {
+	Mirror       string
+	Offline      bool
+	Force        bool
+	SaveToMirror bool
+	Tools        []string
+}
+
+// Install downloads or copies from mirror pinned tools into per-stack cache verifying artifacts checksum
+func Install(toolchain manifest.Toolchain, baseDir string, request *InstallRequest) error {
+	cacheDir := CacheDir(toolchain, baseDir)
+	mirror := mirrorDir(toolchain, baseDir, request.Mirror)
+	if request.Offline && mirror == "" {
+		return errors.New("Offline mode requires toolchain mirror directory; set --mirror, $" + envVarNameMirror + ", or toolchain.mirror")
+	}
+
+	errs := make([]error, 0)
+	for _, tool := range toolchain.Tools {
+		if len(request.Tools) > 0 && !util.Contains(request.Tools, tool.Name) {
+			continue
+		}
+		if !request.Force && installed(cacheDir, tool) {
+			if config.Verbose {
+				log.Printf("Tool %s %s is already installed", tool.Name, tool.Version)
+			}
+			continue
+		}
+		err := install(tool, cacheDir, mirror, request)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %v", tool.Name, tool.Version, err))
+			continue
+		}
+		if config.Verbose {
+			log.Printf("Installed %s %s into %s", tool.Name, tool.Version, toolDir(cacheDir, tool))
+		}
+	}
+	for _, name := range request.Tools {
+		found := false
+		for _, tool := range toolchain.Tools {
+			if tool.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("%s: no such tool in toolchain", name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Unable to install toolchain:\n\t%s", util.Errors("\n\t", errs...))
+	}
+	return nil
+}
+
+func install(tool manifest.Tool, cacheDir, mirror string, request *InstallRequest) error {
+	if tool.Name == "" || tool.Version == "" {
+		return errors.New("tool `name` and `version` must be set")
+	}
+	if tool.URL == "" {
+		return errors.New("tool `url` must be set")
+	}
+	checksum := toolChecksum(tool)
+	if checksum == "" {
+		return fmt.Errorf("no `sha256` checksum for %s", Platform())
+	}
+	url := toolURL(tool)
+	artifact := path.Base(url)
+
+	dir := toolDir(cacheDir, tool)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".download-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	source := findInMirror(mirror, tool, artifact)
+	if source != "" {
+		if config.Debug {
+			log.Printf("Copying %s %s from %s", tool.Name, tool.Version, source)
+		}
+		err = copyFrom(tmp, source)
+	} else if request.Offline {
+		return fmt.Errorf("`%s` not found in mirror `%s`", artifact, mirror)
+	} else {
+		if config.Verbose {
+			log.Printf("Downloading %s %s from %s", tool.Name, tool.Version, url)
+		}
+		err = download(tmp, url)
+	}
+	if err != nil {
+		return err
+	}
+
+	sum, err := fileSha256(tmp)
+	if err != nil {
+		return err
+	}
+	if sum != checksum {
+		return fmt.Errorf("`%s` checksum mismatch: expected %s, got %s", artifact, checksum, sum)
+	}
+
+	if source == "" && request.SaveToMirror && mirror != "" {
+		err = saveToMirror(tmp, mirror, tool, artifact)
+		if err != nil {
+			util.Warn("Unable to save `%s` to mirror: %v", artifact, err)
+		}
+	}
+
+	binary := filepath.Join(dir, tool.Name)
+	return extract(tmp, artifact, tool, binary)
+}
+
+func findInMirror(mirror string, tool manifest.Tool, artifact string) string {
+	if mirror == "" {
+		return ""
+	}
+	for _, candidate := range []string{
+		filepath.Join(mirror, tool.Name, tool.Version, artifact),
+		filepath.Join(mirror, artifact),
+	} {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func copyFrom(dst *os.File, filename string) error {
+	src, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func download(dst *os.File, url string) error {
+	if strings.HasPrefix(url, "file://") {
+		return copyFrom(dst, strings.TrimPrefix(url, "file://"))
+	}
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	_, err = io.Copy(dst, resp.Body)
+	return err
+}
+
+func fileSha256(file *os.File) (string, error) {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func saveToMirror(file *os.File, mirror string, tool manifest.Tool, artifact string) error {
+	dir := filepath.Join(mirror, tool.Name, tool.Version)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	dst, err := os.Create(filepath.Join(dir, artifact))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, file)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func matchBinary(name string, tool manifest.Tool) bool {
+	name = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if tool.Binary != "" {
+		return name == strings.TrimPrefix(path.Clean("/"+tool.Binary), "/")
+	}
+	return path.Base(name) == tool.Name
+}
+
+// extract writes tool binary from downloaded artifact - zip, tar.gz, tar, or plain executable
+func extract(file *os.File, artifact string, tool manifest.Tool, binary string) error {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	switch {
+	case strings.HasSuffix(artifact, ".zip"):
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		archive, err := zip.NewReader(file, info.Size())
+		if err != nil {
+			return err
+		}
+		for _, entry := range archive.File {
+			if entry.FileInfo().IsDir() || !matchBinary(entry.Name, tool) {
+				continue
+			}
+			reader, err := entry.Open()
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+			return writeBinary(reader, binary)
+		}
+
+	case strings.HasSuffix(artifact, ".tar.gz"), strings.HasSuffix(artifact, ".tgz"), strings.HasSuffix(artifact, ".tar"):
+		var reader io.Reader = file
+		if !strings.HasSuffix(artifact, ".tar") {
+			gz, err := gzip.NewReader(file)
+			if err != nil {
+				return err
+			}
+			defer gz.Close()
+			reader = gz
+		}
+		archive := tar.NewReader(reader)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if header.Typeflag != tar.TypeReg || !matchBinary(header.Name, tool) {
+				continue
+			}
+			return writeBinary(archive, binary)
+		}
+
+	default:
+		return writeBinary(file, binary)
+	}
+	return fmt.Errorf("`%s` not found in `%s`", util.Value(tool.Binary, tool.Name), artifact)
+}
+
+func writeBinary(reader io.Reader, binary string) error {
+	tmp := binary + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, reader)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, binary)
+}
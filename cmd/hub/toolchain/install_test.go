@@ -0,0 +1,158 @@
+package toolchain
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+)
+
+const testBinary = "#!/bin/sh\necho fake\n"
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)),
+			Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		archive.Write([]byte(content))
+	}
+	archive.Close()
+	gz.Close()
+	return buffer.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	archive.Close()
+	return buffer.Bytes()
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeMirrorFile(t *testing.T, path string, content []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallOfflineFromMirror(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-toolchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Unsetenv(envVarNameMirror)
+	os.Unsetenv(envVarNameCacheDir)
+
+	tgz := tarGz(t, map[string]string{"README.md": "docs", "linux-amd64/faketf": testBinary})
+	zipped := zipArchive(t, map[string]string{"bin/fakectl": testBinary, "bin/other": "other"})
+	plain := []byte(testBinary)
+	mirror := filepath.Join(dir, "mirror")
+	// versioned layout and flat layout
+	writeMirrorFile(t, filepath.Join(mirror, "faketf", "1.2.3", "faketf_1.2.3.tar.gz"), tgz)
+	writeMirrorFile(t, filepath.Join(mirror, "fakectl-0.1.zip"), zipped)
+	writeMirrorFile(t, filepath.Join(mirror, "fakejq"), plain)
+
+	toolchain := manifest.Toolchain{
+		Mirror: "mirror",
+		Tools: []manifest.Tool{
+			{Name: "faketf", Version: "1.2.3", URL: "https://example.invalid/faketf_${version}.tar.gz",
+				Binary: "linux-amd64/faketf", Checksums: map[string]string{Platform(): strings.ToUpper(sha256Hex(tgz))}},
+			{Name: "fakectl", Version: "0.1", URL: "https://example.invalid/${version}/fakectl-${version}.zip",
+				Sha256: sha256Hex(zipped)},
+			{Name: "fakejq", Version: "1.6", URL: "https://example.invalid/fakejq", Sha256: sha256Hex(plain)},
+		},
+	}
+	if err := Install(toolchain, dir, &InstallRequest{Offline: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, missing := Path(toolchain, dir)
+	if len(dirs) != 3 || len(missing) != 0 {
+		t.Fatalf("Path() = %v, missing %v", dirs, missing)
+	}
+	for _, tool := range toolchain.Tools {
+		binary := filepath.Join(dir, defaultCacheDir, tool.Name, tool.Version, tool.Name)
+		info, err := os.Stat(binary)
+		if err != nil {
+			t.Errorf("%s is not installed: %v", tool.Name, err)
+			continue
+		}
+		if info.Mode()&0100 == 0 {
+			t.Errorf("%s is not executable: %v", tool.Name, info.Mode())
+		}
+		if content, _ := ioutil.ReadFile(binary); string(content) != testBinary {
+			t.Errorf("%s content = %q", tool.Name, content)
+		}
+	}
+}
+
+func TestInstallOfflineErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hub-toolchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Unsetenv(envVarNameMirror)
+	os.Unsetenv(envVarNameCacheDir)
+
+	mirror := filepath.Join(dir, "mirror")
+	tgz := tarGz(t, map[string]string{"other": testBinary})
+	writeMirrorFile(t, filepath.Join(mirror, "tampered"), []byte(testBinary))
+	writeMirrorFile(t, filepath.Join(mirror, "nobinary.tgz"), tgz)
+
+	tests := []struct {
+		tool manifest.Tool
+		err  string
+	}{
+		{manifest.Tool{Name: "tampered", Version: "1", URL: "https://example.invalid/tampered",
+			Sha256: sha256Hex([]byte("original"))}, "checksum mismatch"},
+		{manifest.Tool{Name: "nobinary", Version: "1", URL: "https://example.invalid/nobinary.tgz",
+			Sha256: sha256Hex(tgz)}, "`nobinary` not found in `nobinary.tgz`"},
+		{manifest.Tool{Name: "absent", Version: "1", URL: "https://example.invalid/absent",
+			Sha256: sha256Hex(nil)}, "`absent` not found in mirror"},
+		{manifest.Tool{Name: "nosum", Version: "1", URL: "https://example.invalid/nosum"}, "no `sha256` checksum"},
+	}
+	for _, test := range tests {
+		toolchain := manifest.Toolchain{Mirror: mirror, Tools: []manifest.Tool{test.tool}}
+		err := Install(toolchain, dir, &InstallRequest{Offline: true})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want `%s`", test.tool.Name, err, test.err)
+		}
+		if _, missing := Path(toolchain, dir); len(missing) != 1 {
+			t.Errorf("%s is installed despite error", test.tool.Name)
+		}
+	}
+
+	err = Install(manifest.Toolchain{Tools: []manifest.Tool{{Name: "x"}}}, dir, &InstallRequest{Offline: true})
+	if err == nil || !strings.Contains(err.Error(), "Offline mode requires toolchain mirror") {
+		t.Errorf("offline without mirror error = %v", err)
+	}
+}
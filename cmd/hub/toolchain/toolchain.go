@@ -0,0 +1,118 @@
+package toolchain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/agilestacks/hub/cmd/hub/manifest"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	defaultCacheDir    = ".hub/toolchain"
+	envVarNameMirror   = "HUB_TOOLCHAIN_MIRROR"
+	envVarNameCacheDir = "HUB_TOOLCHAIN_CACHE"
+)
+
+// Platform is os_arch of the running Hub CLI, ie. linux_amd64, used as checksums key
+func Platform() string {
+	return fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// CacheDir returns per-stack toolchain cache directory, relative paths are resolved against stack base dir
+func CacheDir(toolchain manifest.Toolchain, baseDir string) string {
+	dir := util.Value(os.Getenv(envVarNameCacheDir), toolchain.Cache, defaultCacheDir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	return dir
+}
+
+func mirrorDir(toolchain manifest.Toolchain, baseDir, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if env := os.Getenv(envVarNameMirror); env != "" {
+		return env
+	}
+	dir := toolchain.Mirror
+	if dir != "" && !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	return dir
+}
+
+func toolDir(cacheDir string, tool manifest.Tool) string {
+	return filepath.Join(cacheDir, tool.Name, tool.Version)
+}
+
+func toolURL(tool manifest.Tool) string {
+	return strings.NewReplacer(
+		"${version}", tool.Version,
+		"${os}", runtime.GOOS,
+		"${arch}", runtime.GOARCH,
+	).Replace(tool.URL)
+}
+
+func toolChecksum(tool manifest.Tool) string {
+	if checksum, exist := tool.Checksums[Platform()]; exist {
+		return strings.ToLower(checksum)
+	}
+	return strings.ToLower(tool.Sha256)
+}
+
+func installed(cacheDir string, tool manifest.Tool) bool {
+	info, err := os.Stat(filepath.Join(toolDir(cacheDir, tool), tool.Name))
+	return err == nil && !info.IsDir()
+}
+
+// Path returns toolchain directories to prepend to component PATH and a list of tools not installed yet
+func Path(toolchain manifest.Toolchain, baseDir string) ([]string, []manifest.Tool) {
+	if len(toolchain.Tools) == 0 {
+		return nil, nil
+	}
+	cacheDir := CacheDir(toolchain, baseDir)
+	dirs := make([]string, 0, len(toolchain.Tools))
+	missing := make([]manifest.Tool, 0)
+	for _, tool := range toolchain.Tools {
+		if !installed(cacheDir, tool) {
+			missing = append(missing, tool)
+			continue
+		}
+		dir := toolDir(cacheDir, tool)
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, missing
+}
+
+// ToolsNames formats tools as name@version list
+func ToolsNames(tools []manifest.Tool) string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, fmt.Sprintf("%s@%s", tool.Name, tool.Version))
+	}
+	return strings.Join(names, ", ")
+}
+
+// List prints toolchain status
+func List(toolchain manifest.Toolchain, baseDir string) {
+	cacheDir := CacheDir(toolchain, baseDir)
+	if len(toolchain.Tools) == 0 {
+		fmt.Print("No toolchain declared\n")
+		return
+	}
+	fmt.Printf("Toolchain cache: %s\n", cacheDir)
+	for _, tool := range toolchain.Tools {
+		status := "missing"
+		if installed(cacheDir, tool) {
+			status = "installed"
+		}
+		fmt.Printf("\t%s %s: %s\n", tool.Name, tool.Version, status)
+	}
+}
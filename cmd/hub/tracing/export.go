@@ -0,0 +1,199 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+	"github.com/agilestacks/hub/cmd/hub/util"
+)
+
+const (
+	envVarNameOtelServiceName = "OTEL_SERVICE_NAME"
+	envVarNameOtelHeaders     = "OTEL_EXPORTER_OTLP_HEADERS"
+	otlpTracesPath            = "/v1/traces"
+	defaultServiceName        = "hub-cli"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// OTLP/HTTP JSON encoding, see opentelemetry-proto trace.proto
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func otlpAttributes(attributes []attribute) []otlpAttribute {
+	otlp := make([]otlpAttribute, 0, len(attributes))
+	for _, a := range attributes {
+		otlp = append(otlp, otlpAttribute{Key: a.key, Value: otlpValue{StringValue: a.value}})
+	}
+	return otlp
+}
+
+func encode(spans []*Span) ([]byte, error) {
+	serviceName := util.Value(os.Getenv(envVarNameOtelServiceName), defaultServiceName)
+	otlp := make([]otlpSpan, 0, len(spans))
+	var zeroId [8]byte
+	for _, span := range spans {
+		parentId := ""
+		if span.parentId != zeroId {
+			parentId = hex.EncodeToString(span.parentId[:])
+		}
+		otlp = append(otlp, otlpSpan{
+			TraceId:           hex.EncodeToString(span.traceId[:]),
+			SpanId:            hex.EncodeToString(span.spanId[:]),
+			ParentSpanId:      parentId,
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.attributes),
+			Status:            otlpStatus{Code: span.status, Message: span.message},
+		})
+	}
+	traces := otlpTraces{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: otlpAttributes([]attribute{
+				{"service.name", serviceName},
+				{"service.version", util.CliVersion},
+			})},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/agilestacks/hub", Version: util.CliVersion},
+				Spans: otlp,
+			}},
+		}},
+	}
+	return json.Marshal(traces)
+}
+
+// Flush exports finished spans to OTLP endpoint and/or appends them to JSON lines file
+func Flush() {
+	if !Enabled() {
+		return
+	}
+	mu.Lock()
+	spans := finished
+	finished = nil
+	mu.Unlock()
+	if len(spans) == 0 {
+		return
+	}
+	data, err := encode(spans)
+	if err != nil {
+		util.Warn("Unable to encode trace spans: %v", err)
+		return
+	}
+	if config.OtelFile != "" {
+		if err := appendFile(config.OtelFile, data); err != nil {
+			util.Warn("Unable to write trace spans to `%s`: %v", config.OtelFile, err)
+		}
+	}
+	if config.OtelEndpoint != "" {
+		if err := post(config.OtelEndpoint, data); err != nil {
+			util.Warn("Unable to export trace spans to `%s`: %v", config.OtelEndpoint, err)
+		}
+	}
+	if config.Debug {
+		log.Printf("Exported %d trace %s", len(spans), util.Plural(len(spans), "span"))
+	}
+}
+
+func appendFile(filename string, data []byte) error {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	if err2 := file.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+func post(endpoint string, data []byte) error {
+	url := endpoint
+	if !strings.HasSuffix(url, otlpTracesPath) {
+		url = strings.TrimSuffix(url, "/") + otlpTracesPath
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-type", "application/json")
+	for _, header := range util.SplitPaths(os.Getenv(envVarNameOtelHeaders)) {
+		kv := strings.SplitN(header, "=", 2)
+		if len(kv) == 2 {
+			req.Header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+		}
+	}
+	if config.Trace {
+		log.Printf(">>> %s %s", req.Method, req.URL.String())
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if config.Trace {
+		log.Printf("<<< %s %s: %s", req.Method, req.URL.String(), resp.Status)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%d HTTP: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
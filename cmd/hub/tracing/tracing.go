@@ -0,0 +1,200 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+)
+
+const (
+	envVarNameTraceparent = "TRACEPARENT"
+
+	statusError = 2
+
+	kindInternal = 1
+	kindClient   = 3
+)
+
+type attribute struct {
+	key   string
+	value string
+}
+
+// Span is a unit of work in OpenTelemetry data model; methods are no-op on nil Span
+// which is returned when tracing is not configured
+type Span struct {
+	traceId    [16]byte
+	spanId     [8]byte
+	parentId   [8]byte
+	name       string
+	kind       int
+	start      time.Time
+	end        time.Time
+	attributes []attribute
+	status     int
+	message    string
+	parent     *Span
+}
+
+var (
+	mu       sync.Mutex
+	current  *Span
+	finished []*Span
+
+	remoteParent     *Span
+	remoteParentRead bool
+)
+
+func Enabled() bool {
+	return config.OtelEndpoint != "" || config.OtelFile != ""
+}
+
+// Start begins a child span of the current span, or of TRACEPARENT passed by parent process;
+// attributes are key, value pairs
+func Start(name string, attributes ...string) *Span {
+	return start(name, kindInternal, attributes)
+}
+
+// StartClient begins a span of a remote call, ie. storage or API request; client span is
+// a leaf that never becomes current, so that calls made by background goroutines do not
+// become parents of lifecycle spans
+func StartClient(name string, attributes ...string) *Span {
+	return start(name, kindClient, attributes)
+}
+
+func start(name string, kind int, attributes []string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	span := &Span{
+		name:  name,
+		kind:  kind,
+		start: time.Now(),
+	}
+	parent := current
+	if parent == nil {
+		parent = traceparentFromEnv()
+	}
+	if parent != nil {
+		span.traceId = parent.traceId
+		span.parentId = parent.spanId
+		span.parent = current
+	} else {
+		rand.Read(span.traceId[:])
+	}
+	rand.Read(span.spanId[:])
+	for i := 0; i+1 < len(attributes); i += 2 {
+		span.attributes = append(span.attributes, attribute{attributes[i], attributes[i+1]})
+	}
+	if kind != kindClient {
+		current = span
+	}
+	return span
+}
+
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	s.attributes = append(s.attributes, attribute{key, value})
+}
+
+// SetError marks span as failed, nil error is ignored
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	s.status = statusError
+	s.message = err.Error()
+}
+
+// End finishes the span and makes its nearest un-ended ancestor current; span is exported on Flush
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !s.end.IsZero() {
+		return
+	}
+	s.end = time.Now()
+	if current == s {
+		// parent may have ended before the child
+		current = s.parent
+		for current != nil && !current.end.IsZero() {
+			current = current.parent
+		}
+	}
+	finished = append(finished, s)
+}
+
+// Traceparent returns W3C Trace Context header value for remote call to join the trace
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return s.traceparent()
+}
+
+func (s *Span) traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.traceId[:]), hex.EncodeToString(s.spanId[:]))
+}
+
+// traceparentFromEnv parses W3C Trace Context header passed via environment by the parent process
+func traceparentFromEnv() *Span {
+	if remoteParentRead {
+		return remoteParent
+	}
+	remoteParentRead = true
+	value := os.Getenv(envVarNameTraceparent)
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		log.Printf("Unable to parse %s=%s", envVarNameTraceparent, value)
+		return nil
+	}
+	span := &Span{}
+	if _, err := hex.Decode(span.traceId[:], []byte(parts[1])); err != nil {
+		log.Printf("Unable to parse %s trace id: %v", envVarNameTraceparent, err)
+		return nil
+	}
+	if _, err := hex.Decode(span.spanId[:], []byte(parts[2])); err != nil {
+		log.Printf("Unable to parse %s parent id: %v", envVarNameTraceparent, err)
+		return nil
+	}
+	remoteParent = span
+	return remoteParent
+}
+
+// Env returns TRACEPARENT of the current span for child process to join the trace
+func Env() []string {
+	if !Enabled() {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	span := current
+	if span == nil {
+		span = traceparentFromEnv()
+	}
+	if span == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s=%s", envVarNameTraceparent, span.traceparent())}
+}
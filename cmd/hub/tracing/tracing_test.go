@@ -0,0 +1,204 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/agilestacks/hub/cmd/hub/config"
+)
+
+type collector struct {
+	mu       sync.Mutex
+	server   *httptest.Server
+	paths    []string
+	requests []otlpTraces
+}
+
+func startCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unable to read request: %v", err)
+		}
+		if ct := r.Header.Get("Content-type"); ct != "application/json" {
+			t.Errorf("Content-type = `%s`, want application/json", ct)
+		}
+		var traces otlpTraces
+		if err := json.Unmarshal(body, &traces); err != nil {
+			t.Errorf("unable to unmarshal OTLP request: %v", err)
+		}
+		c.mu.Lock()
+		c.paths = append(c.paths, r.URL.Path)
+		c.requests = append(c.requests, traces)
+		c.mu.Unlock()
+	}))
+	return c
+}
+
+func (c *collector) spans() map[string]otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := make(map[string]otlpSpan)
+	for _, traces := range c.requests {
+		for _, resource := range traces.ResourceSpans {
+			for _, scope := range resource.ScopeSpans {
+				for _, span := range scope.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func setup(t *testing.T) *collector {
+	c := startCollector(t)
+	config.OtelEndpoint = c.server.URL
+	os.Unsetenv(envVarNameTraceparent)
+	current = nil
+	finished = nil
+	remoteParent = nil
+	remoteParentRead = false
+	t.Cleanup(func() {
+		config.OtelEndpoint = ""
+		current = nil
+		finished = nil
+		c.server.Close()
+	})
+	return c
+}
+
+func attributeValue(span otlpSpan, key string) (string, bool) {
+	for _, a := range span.Attributes {
+		if a.Key == key {
+			return a.Value.StringValue, true
+		}
+	}
+	return "", false
+}
+
+func TestExport(t *testing.T) {
+	c := setup(t)
+
+	operation := Start("hub deploy", "hub.operation.id", "op-1")
+	operation.SetAttribute("hub.stack", "test")
+	component := Start("component", "hub.component", "app")
+	env := Env()
+	client := StartClient("s3 put")
+	client.End()
+	component.SetError(errors.New("exit status 1"))
+	component.End()
+	operation.End()
+	Flush()
+
+	if len(c.paths) != 1 || c.paths[0] != otlpTracesPath {
+		t.Fatalf("collector received %v, want single request to %s", c.paths, otlpTracesPath)
+	}
+	resource := c.requests[0].ResourceSpans[0].Resource
+	if len(resource.Attributes) == 0 || resource.Attributes[0].Key != "service.name" ||
+		resource.Attributes[0].Value.StringValue != defaultServiceName {
+		t.Errorf("unexpected resource attributes %+v", resource.Attributes)
+	}
+
+	spans := c.spans()
+	if len(spans) != 3 {
+		t.Fatalf("collector received %d spans, want 3", len(spans))
+	}
+	root, child, leaf := spans["hub deploy"], spans["component"], spans["s3 put"]
+
+	if len(root.TraceId) != 32 || len(root.SpanId) != 16 {
+		t.Errorf("malformed ids: traceId = `%s`, spanId = `%s`", root.TraceId, root.SpanId)
+	}
+	if root.ParentSpanId != "" {
+		t.Errorf("root span has parent `%s`", root.ParentSpanId)
+	}
+	for _, span := range []otlpSpan{child, leaf} {
+		if span.TraceId != root.TraceId {
+			t.Errorf("span `%s` traceId = `%s`, want `%s`", span.Name, span.TraceId, root.TraceId)
+		}
+	}
+	if child.ParentSpanId != root.SpanId {
+		t.Errorf("component parentSpanId = `%s`, want `%s`", child.ParentSpanId, root.SpanId)
+	}
+	if leaf.ParentSpanId != child.SpanId {
+		t.Errorf("client parentSpanId = `%s`, want `%s`", leaf.ParentSpanId, child.SpanId)
+	}
+	if leaf.Kind != kindClient || child.Kind != kindInternal {
+		t.Errorf("kinds = %d, %d; want %d, %d", leaf.Kind, child.Kind, kindClient, kindInternal)
+	}
+
+	if value, _ := attributeValue(root, "hub.operation.id"); value != "op-1" {
+		t.Errorf("hub.operation.id = `%s`, want op-1", value)
+	}
+	if value, _ := attributeValue(root, "hub.stack"); value != "test" {
+		t.Errorf("hub.stack = `%s`, want test", value)
+	}
+	if value, _ := attributeValue(child, "hub.component"); value != "app" {
+		t.Errorf("hub.component = `%s`, want app", value)
+	}
+	if child.Status.Code != statusError || child.Status.Message != "exit status 1" {
+		t.Errorf("component status = %+v", child.Status)
+	}
+	if root.Status.Code != 0 {
+		t.Errorf("root status = %+v", root.Status)
+	}
+	start, _ := strconv.ParseInt(root.StartTimeUnixNano, 10, 64)
+	end, _ := strconv.ParseInt(root.EndTimeUnixNano, 10, 64)
+	if start == 0 || end < start {
+		t.Errorf("root span ends before it starts: %s > %s", root.StartTimeUnixNano, root.EndTimeUnixNano)
+	}
+
+	want := fmt.Sprintf("%s=00-%s-%s-01", envVarNameTraceparent, child.TraceId, child.SpanId)
+	if len(env) != 1 || env[0] != want {
+		t.Errorf("Env() = %v, want [%s]", env, want)
+	}
+}
+
+func TestRemoteParent(t *testing.T) {
+	c := setup(t)
+	traceId, parentId := strings.Repeat("ab", 16), strings.Repeat("cd", 8)
+	os.Setenv(envVarNameTraceparent, fmt.Sprintf("00-%s-%s-01", traceId, parentId))
+	defer os.Unsetenv(envVarNameTraceparent)
+
+	span := Start("hub deploy")
+	span.End()
+	Flush()
+
+	exported := c.spans()["hub deploy"]
+	if exported.TraceId != traceId || exported.ParentSpanId != parentId {
+		t.Errorf("traceId, parentSpanId = `%s`, `%s`; want `%s`, `%s`",
+			exported.TraceId, exported.ParentSpanId, traceId, parentId)
+	}
+}
+
+func TestParentEndsBeforeChild(t *testing.T) {
+	c := setup(t)
+
+	root := Start("root")
+	parent := Start("parent")
+	child := Start("child")
+	parent.End()
+	child.End()
+	next := Start("next")
+	next.End()
+	root.End()
+	Flush()
+
+	spans := c.spans()
+	if spans["next"].ParentSpanId != spans["root"].SpanId {
+		t.Errorf("next parentSpanId = `%s`, want root `%s`", spans["next"].ParentSpanId, spans["root"].SpanId)
+	}
+	if current != nil {
+		t.Errorf("current span `%s` is left after all spans ended", current.name)
+	}
+}
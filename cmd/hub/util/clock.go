@@ -0,0 +1,17 @@
+package util
+
+import "time"
+
+var clock = time.Now
+
+// Now returns current time that is written into state and backup bundles
+func Now() time.Time {
+	return clock()
+}
+
+// FixClock makes Now() return fixed time for reproducible simulation; returned func restores the clock
+func FixClock(fixed time.Time) func() {
+	previous := clock
+	clock = func() time.Time { return fixed }
+	return func() { clock = previous }
+}
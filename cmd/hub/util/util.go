@@ -24,6 +24,7 @@ var (
 	warningsEmitted = make(map[string]struct{})
 	HighlightColor  = maybeHighlight(aurora.BrightCyan)
 	WarnColor       = maybeHighlight(aurora.BrightMagenta)
+	ErrorColor      = maybeHighlight(aurora.BrightRed)
 	logTerminal     *bool
 )
 